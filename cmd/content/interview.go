@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"golearning/internal/audit"
+	"golearning/internal/db"
+	"golearning/internal/interview"
+)
+
+// runInterview — команда interview: импортирует банк вопросов для
+// собеседований из Markdown-файлов (один файл — одна тема, формат —
+// см. internal/interview). Повторный импорт обновляет вопросы по
+// ключу (тема, заголовок), ничего не удаляя.
+func runInterview(args []string) error {
+	fs := flag.NewFlagSet("content interview", flag.ExitOnError)
+	dbPath := fs.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	dir := fs.String("dir", "./lessons_mdx/Интервью", "Директория с Markdown-файлами банка вопросов")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := db.Open(*dbPath)
+	if err != nil {
+		return fmt.Errorf("открытие БД: %w", err)
+	}
+	defer database.Close()
+
+	if err := db.Migrate(database); err != nil {
+		return fmt.Errorf("миграция: %w", err)
+	}
+
+	repo := interview.NewRepository(database)
+	imported, err := interview.ImportDir(*dir, repo)
+	if err != nil {
+		return err
+	}
+
+	total, _ := repo.Count()
+
+	audit.NewLog(database).MustRecord("cli", "content.interview", "question", 0,
+		fmt.Sprintf("content interview: импортировано %d вопросов, всего в банке %d", imported, total))
+
+	fmt.Printf("✅ Импортировано вопросов: %d (всего в банке: %d).\n", imported, total)
+	return nil
+}
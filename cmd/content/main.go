@@ -0,0 +1,62 @@
+package main
+
+// Утилита управления контентом. Команды:
+//
+//	content purge     — перемещает выбранные модули и уроки в корзину.
+//	content sync      — синхронизирует уроки между Markdown-файлами и БД.
+//	content interview — импортирует банк вопросов для собеседований.
+//
+// Заменяет устаревшую cmd/purge_demo: вместо захардкоженных slug'ов
+// принимает селекторы и умеет показывать план без изменений (--dry-run).
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "purge":
+		if err := runPurge(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	case "sync":
+		if err := runSync(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	case "interview":
+		if err := runInterview(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Неизвестная команда: %s\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Использование: content <команда> [флаги]
+
+Команды:
+  purge      Переместить выбранный контент в корзину
+  sync       Синхронизировать уроки между Markdown-файлами и БД
+  interview  Импортировать банк вопросов для собеседований
+
+Примеры:
+  content purge --module=osnovy --dry-run
+  content purge --lesson=vvedenie
+  content purge --source-url-prefix=https://metanit.com/go/tutorial
+  content purge --all-demo
+  content sync --dir=./lessons --dry-run
+  content sync --dir=./lessons --prune
+  content interview --dir=./lessons_mdx/Интервью`)
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"strings"
+
+	"golearning/internal/audit"
+	"golearning/internal/db"
+	"golearning/internal/ingest"
+)
+
+// purgeTarget — что именно уходит в корзину.
+type purgeTarget struct {
+	moduleIDs []int64
+	lessonIDs []int64 // уроки, выбранные напрямую (не через модуль)
+}
+
+// runPurge — команда purge: перемещает выбранный контент в корзину.
+func runPurge(args []string) error {
+	fs := flag.NewFlagSet("content purge", flag.ExitOnError)
+	dbPath := fs.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	moduleSlugs := fs.String("module", "", "Slug модуля (несколько — через запятую)")
+	lessonSlugs := fs.String("lesson", "", "Slug урока (несколько — через запятую)")
+	sourcePrefix := fs.String("source-url-prefix", "", "Уроки, чей source_url начинается с префикса")
+	allDemo := fs.Bool("all-demo", false, "Весь демо-контент (модули и уроки всех seed-паков)")
+	dryRun := fs.Bool("dry-run", false, "Показать план и ничего не менять")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *moduleSlugs == "" && *lessonSlugs == "" && *sourcePrefix == "" && !*allDemo {
+		return fmt.Errorf("не задан ни один селектор (--module, --lesson, --source-url-prefix или --all-demo)")
+	}
+
+	database, err := db.Open(*dbPath)
+	if err != nil {
+		return fmt.Errorf("открытие БД: %w", err)
+	}
+	defer database.Close()
+
+	// На всякий случай убеждаемся, что схема актуальна.
+	if err := db.Migrate(database); err != nil {
+		return fmt.Errorf("миграция: %w", err)
+	}
+
+	modules := splitSlugs(*moduleSlugs)
+	lessons := splitSlugs(*lessonSlugs)
+	if *allDemo {
+		packModules, packLessons, err := demoSlugs()
+		if err != nil {
+			return err
+		}
+		modules = append(modules, packModules...)
+		lessons = append(lessons, packLessons...)
+	}
+
+	target, err := resolveTarget(database, modules, lessons, *sourcePrefix)
+	if err != nil {
+		return err
+	}
+
+	if len(target.moduleIDs) == 0 && len(target.lessonIDs) == 0 {
+		fmt.Println("Под селекторы ничего не попало — нечего удалять.")
+		return nil
+	}
+
+	if err := printPlan(database, target, *dryRun); err != nil {
+		return err
+	}
+	if *dryRun {
+		return nil
+	}
+
+	moved, err := execPurge(database, target)
+	if err != nil {
+		return err
+	}
+
+	audit.NewLog(database).MustRecord("cli", "trash.soft_delete", "module", 0,
+		fmt.Sprintf("content purge: перемещено в корзину %d записей", moved))
+
+	fmt.Printf("✅ Перемещено в корзину: %d записей. Восстановление — через /admin/trash.\n", moved)
+	return nil
+}
+
+// demoSlugs собирает slug'и модулей и уроков всех seed-паков.
+func demoSlugs() (modules, lessons []string, err error) {
+	packs, err := ingest.ListSeedPacks()
+	if err != nil {
+		return nil, nil, fmt.Errorf("чтение seed-паков: %w", err)
+	}
+	for _, pack := range packs {
+		for _, m := range pack.Modules {
+			modules = append(modules, m.Slug)
+		}
+		for _, l := range pack.Lessons {
+			lessons = append(lessons, l.Slug)
+		}
+	}
+	return modules, lessons, nil
+}
+
+// resolveTarget переводит селекторы в конкретные ID модулей и уроков.
+func resolveTarget(database *sql.DB, moduleSlugs, lessonSlugs []string, sourcePrefix string) (*purgeTarget, error) {
+	target := &purgeTarget{}
+
+	if len(moduleSlugs) > 0 {
+		ids, err := queryIDs(database,
+			`SELECT id FROM modules WHERE slug IN (`+placeholders(len(moduleSlugs))+`) AND deleted_at IS NULL`,
+			toArgs(moduleSlugs)...)
+		if err != nil {
+			return nil, fmt.Errorf("поиск модулей: %w", err)
+		}
+		target.moduleIDs = ids
+	}
+
+	if len(lessonSlugs) > 0 {
+		ids, err := queryIDs(database,
+			`SELECT id FROM lessons WHERE slug IN (`+placeholders(len(lessonSlugs))+`) AND deleted_at IS NULL`,
+			toArgs(lessonSlugs)...)
+		if err != nil {
+			return nil, fmt.Errorf("поиск уроков: %w", err)
+		}
+		target.lessonIDs = ids
+	}
+
+	if sourcePrefix != "" {
+		ids, err := queryIDs(database,
+			`SELECT id FROM lessons WHERE source_url LIKE ? || '%' AND deleted_at IS NULL`,
+			sourcePrefix)
+		if err != nil {
+			return nil, fmt.Errorf("поиск уроков по source_url: %w", err)
+		}
+		target.lessonIDs = append(target.lessonIDs, ids...)
+	}
+
+	return target, nil
+}
+
+// printPlan выводит, что попадёт в корзину и какие связанные данные
+// будут каскадно стёрты при последующем окончательном удалении.
+func printPlan(database *sql.DB, target *purgeTarget, dryRun bool) error {
+	if dryRun {
+		fmt.Println("План (--dry-run, изменений не будет):")
+	} else {
+		fmt.Println("В корзину будут перемещены:")
+	}
+
+	affectedLessons := append([]int64{}, target.lessonIDs...)
+
+	for _, id := range target.moduleIDs {
+		var slug, title string
+		var lessonCount int
+		if err := database.QueryRow(
+			`SELECT slug, title, (SELECT COUNT(*) FROM lessons WHERE module_id = modules.id AND deleted_at IS NULL)
+			 FROM modules WHERE id = ?`, id,
+		).Scan(&slug, &title, &lessonCount); err != nil {
+			return fmt.Errorf("чтение модуля %d: %w", id, err)
+		}
+		fmt.Printf("  📦 Модуль %s (%s) — уроков: %d\n", title, slug, lessonCount)
+
+		ids, err := queryIDs(database, `SELECT id FROM lessons WHERE module_id = ? AND deleted_at IS NULL`, id)
+		if err != nil {
+			return err
+		}
+		affectedLessons = append(affectedLessons, ids...)
+	}
+
+	for _, id := range target.lessonIDs {
+		var slug, title string
+		if err := database.QueryRow(`SELECT slug, title FROM lessons WHERE id = ?`, id).Scan(&slug, &title); err != nil {
+			return fmt.Errorf("чтение урока %d: %w", id, err)
+		}
+		fmt.Printf("  📖 Урок %s (%s)\n", title, slug)
+	}
+
+	if len(affectedLessons) > 0 {
+		in := placeholders(len(affectedLessons))
+		args := make([]any, 0, len(affectedLessons))
+		for _, id := range affectedLessons {
+			args = append(args, id)
+		}
+
+		var progress, notes, submissions int
+		_ = database.QueryRow(`SELECT COUNT(*) FROM progress WHERE lesson_id IN (`+in+`)`, args...).Scan(&progress)
+		_ = database.QueryRow(`SELECT COUNT(*) FROM notes WHERE lesson_id IN (`+in+`)`, args...).Scan(&notes)
+		_ = database.QueryRow(
+			`SELECT COUNT(*) FROM submissions WHERE task_id IN (SELECT id FROM tasks WHERE lesson_id IN (`+in+`))`,
+			args...).Scan(&submissions)
+
+		fmt.Printf("  Связанные данные (удалятся каскадно при окончательной очистке): прогресс %d, заметки %d, отправки %d\n",
+			progress, notes, submissions)
+	}
+
+	return nil
+}
+
+// execPurge транзакционно выставляет deleted_at выбранным записям.
+func execPurge(database *sql.DB, target *purgeTarget) (int64, error) {
+	tx, err := database.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("начало транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var moved int64
+	for _, id := range target.moduleIDs {
+		res, err := tx.Exec(`UPDATE modules SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`, id)
+		if err != nil {
+			return 0, fmt.Errorf("удаление модуля %d: %w", id, err)
+		}
+		n, _ := res.RowsAffected()
+		moved += n
+
+		res, err = tx.Exec(`UPDATE lessons SET deleted_at = CURRENT_TIMESTAMP WHERE module_id = ? AND deleted_at IS NULL`, id)
+		if err != nil {
+			return 0, fmt.Errorf("удаление уроков модуля %d: %w", id, err)
+		}
+		n, _ = res.RowsAffected()
+		moved += n
+	}
+
+	for _, id := range target.lessonIDs {
+		res, err := tx.Exec(`UPDATE lessons SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`, id)
+		if err != nil {
+			return 0, fmt.Errorf("удаление урока %d: %w", id, err)
+		}
+		n, _ := res.RowsAffected()
+		moved += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("фиксация транзакции: %w", err)
+	}
+	return moved, nil
+}
+
+// --- Вспомогательные функции ---
+
+func splitSlugs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var slugs []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			slugs = append(slugs, part)
+		}
+	}
+	return slugs
+}
+
+func placeholders(n int) string {
+	return strings.TrimRight(strings.Repeat("?,", n), ",")
+}
+
+func toArgs(values []string) []any {
+	args := make([]any, 0, len(values))
+	for _, v := range values {
+		args = append(args, v)
+	}
+	return args
+}
+
+func queryIDs(database *sql.DB, query string, args ...any) ([]int64, error) {
+	rows, err := database.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golearning/internal/audit"
+	"golearning/internal/content"
+	"golearning/internal/db"
+	"golearning/internal/ingest"
+)
+
+// syncLesson — урок, прочитанный из Markdown-файла.
+type syncLesson struct {
+	path string
+	meta *ingest.LessonFile
+	body string
+}
+
+// syncPlan — что нужно сделать, чтобы БД и файлы совпали.
+type syncPlan struct {
+	create []*syncLesson    // есть файл, нет урока
+	update []*syncLesson    // файл отличается от урока
+	export []content.Lesson // есть урок, нет файла — выгрузить в файл
+	prune  []content.Lesson // есть урок, нет файла — в корзину (--prune)
+}
+
+func (p *syncPlan) empty() bool {
+	return len(p.create) == 0 && len(p.update) == 0 && len(p.export) == 0 && len(p.prune) == 0
+}
+
+// runSync — команда sync: двусторонняя синхронизация уроков между
+// Markdown-файлами (по одному уроку на файл, формат — YAML front
+// matter, см. internal/ingest) и БД. Новые и изменённые файлы
+// импортируются, уроки без файла выгружаются на диск — так файловая
+// система остаётся источником истины, а БД лишь обслуживает приложение.
+func runSync(args []string) error {
+	fs := flag.NewFlagSet("content sync", flag.ExitOnError)
+	dbPath := fs.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	dir := fs.String("dir", "./lessons", "Директория с Markdown-файлами уроков")
+	dryRun := fs.Bool("dry-run", false, "Показать план и ничего не менять")
+	prune := fs.Bool("prune", false, "Уроки без файла перемещать в корзину вместо выгрузки")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := db.Open(*dbPath)
+	if err != nil {
+		return fmt.Errorf("открытие БД: %w", err)
+	}
+	defer database.Close()
+
+	if err := db.Migrate(database); err != nil {
+		return fmt.Errorf("миграция: %w", err)
+	}
+
+	ctx := context.Background()
+	repo := content.NewRepository(database)
+
+	files, err := scanLessonFiles(*dir)
+	if err != nil {
+		return err
+	}
+
+	plan, err := buildSyncPlan(ctx, repo, files, *prune)
+	if err != nil {
+		return err
+	}
+
+	if plan.empty() {
+		fmt.Println("Различий нет — БД и файлы совпадают.")
+		return nil
+	}
+
+	printSyncPlan(plan, *dryRun)
+	if *dryRun {
+		return nil
+	}
+
+	applied, err := applySyncPlan(ctx, database, repo, plan, *dir)
+	if err != nil {
+		return err
+	}
+
+	audit.NewLog(database).MustRecord("cli", "content.sync", "lesson", 0,
+		fmt.Sprintf("content sync: создано %d, обновлено %d, выгружено %d, в корзину %d",
+			len(plan.create), len(plan.update), len(plan.export), len(plan.prune)))
+
+	fmt.Printf("✅ Синхронизировано: %d изменений.\n", applied)
+	return nil
+}
+
+// scanLessonFiles читает и разбирает все *.md в директории.
+// Ошибка разбора любого файла останавливает синхронизацию целиком:
+// молча пропущенный урок хуже, чем прерванный прогон.
+func scanLessonFiles(dir string) (map[string]*syncLesson, error) {
+	files := make(map[string]*syncLesson)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("чтение %s: %w", path, err)
+		}
+		meta, body, err := ingest.ParseLessonFile(string(raw))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if dup, ok := files[meta.Slug]; ok {
+			return fmt.Errorf("slug %s задан дважды: %s и %s", meta.Slug, dup.path, path)
+		}
+		files[meta.Slug] = &syncLesson{path: path, meta: meta, body: body}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("обход %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// buildSyncPlan сравнивает файлы с уроками в БД.
+func buildSyncPlan(ctx context.Context, repo *content.Repository, files map[string]*syncLesson, prune bool) (*syncPlan, error) {
+	lessons, err := repo.ListAllLessons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("чтение уроков: %w", err)
+	}
+	moduleSlugs, err := moduleSlugsByID(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &syncPlan{}
+	seen := make(map[string]bool)
+	for _, l := range lessons {
+		seen[l.Slug] = true
+		f, ok := files[l.Slug]
+		if !ok {
+			if prune {
+				plan.prune = append(plan.prune, l)
+			} else {
+				plan.export = append(plan.export, l)
+			}
+			continue
+		}
+		if lessonDiffers(f, l, moduleSlugs[l.ModuleID]) {
+			plan.update = append(plan.update, f)
+		}
+	}
+	for _, f := range files {
+		if !seen[f.meta.Slug] {
+			plan.create = append(plan.create, f)
+		}
+	}
+	return plan, nil
+}
+
+// lessonDiffers сообщает, отличается ли файл от урока в БД.
+// Время чтения сравнивается только если задано в файле явно —
+// иначе оценка, вычисленная при импорте, считалась бы правкой.
+func lessonDiffers(f *syncLesson, l content.Lesson, moduleSlug string) bool {
+	if f.meta.Module != moduleSlug ||
+		f.meta.Title != l.Title ||
+		f.meta.Order != l.OrderIndex ||
+		f.meta.SourceURL != l.SourceURL ||
+		f.meta.MinGo != l.MinGoVersion {
+		return true
+	}
+	if f.meta.ReadingTime > 0 && f.meta.ReadingTime != l.ReadingTimeMin {
+		return true
+	}
+	return strings.TrimSpace(f.body) != strings.TrimSpace(l.BodyMD)
+}
+
+// printSyncPlan выводит план синхронизации.
+func printSyncPlan(plan *syncPlan, dryRun bool) {
+	if dryRun {
+		fmt.Println("План (--dry-run, изменений не будет):")
+	} else {
+		fmt.Println("План синхронизации:")
+	}
+	for _, f := range plan.create {
+		fmt.Printf("  🆕 Создать урок %s (%s)\n", f.meta.Slug, f.path)
+	}
+	for _, f := range plan.update {
+		fmt.Printf("  ✏️ Обновить урок %s (%s)\n", f.meta.Slug, f.path)
+	}
+	for _, l := range plan.export {
+		fmt.Printf("  📤 Выгрузить урок %s в файл\n", l.Slug)
+	}
+	for _, l := range plan.prune {
+		fmt.Printf("  🗑 Урок %s — в корзину (нет файла)\n", l.Slug)
+	}
+}
+
+// applySyncPlan выполняет план и возвращает число применённых изменений.
+func applySyncPlan(ctx context.Context, database *sql.DB, repo *content.Repository, plan *syncPlan, dir string) (int, error) {
+	applied := 0
+
+	for _, f := range append(append([]*syncLesson{}, plan.create...), plan.update...) {
+		if err := importLessonFile(ctx, repo, f); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+
+	moduleSlugs, err := moduleSlugsByID(ctx, repo)
+	if err != nil {
+		return applied, err
+	}
+	for _, l := range plan.export {
+		if err := exportLessonFile(dir, l, moduleSlugs[l.ModuleID]); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+
+	for _, l := range plan.prune {
+		_, err := database.ExecContext(ctx,
+			`UPDATE lessons SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`, l.ID)
+		if err != nil {
+			return applied, fmt.Errorf("перемещение урока %s в корзину: %w", l.Slug, err)
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// importLessonFile сохраняет урок из файла с пересозданием секций.
+func importLessonFile(ctx context.Context, repo *content.Repository, f *syncLesson) error {
+	module, err := repo.GetModuleBySlug(ctx, f.meta.Module)
+	if err != nil {
+		return fmt.Errorf("поиск модуля %s: %w", f.meta.Module, err)
+	}
+	if module == nil {
+		return fmt.Errorf("%s: модуль %s не найден", f.path, f.meta.Module)
+	}
+
+	lesson := &content.Lesson{
+		ModuleID:       module.ID,
+		Slug:           f.meta.Slug,
+		Title:          f.meta.Title,
+		OrderIndex:     f.meta.Order,
+		SourceURL:      f.meta.SourceURL,
+		BodyMD:         f.body,
+		ReadingTimeMin: f.meta.ReadingTime,
+		MinGoVersion:   f.meta.MinGo,
+	}
+	if lesson.ReadingTimeMin <= 0 {
+		lesson.ReadingTimeMin = ingest.EstimateReadingTime(f.body)
+	}
+	if err := repo.CreateLesson(ctx, lesson); err != nil {
+		return fmt.Errorf("сохранение урока %s: %w", lesson.Slug, err)
+	}
+
+	if err := repo.DeleteSectionsByLessonID(ctx, lesson.ID); err != nil {
+		return fmt.Errorf("очистка секций урока %s: %w", lesson.Slug, err)
+	}
+	if err := repo.CreateSections(ctx, ingest.SplitLessonSections(lesson.ID, f.body)); err != nil {
+		return fmt.Errorf("сохранение секций урока %s: %w", lesson.Slug, err)
+	}
+	return nil
+}
+
+// exportLessonFile выгружает урок в <dir>/<module-slug>/<slug>.md.
+func exportLessonFile(dir string, l content.Lesson, moduleSlug string) error {
+	if moduleSlug == "" {
+		return fmt.Errorf("урок %s: модуль %d не найден", l.Slug, l.ModuleID)
+	}
+
+	doc, err := ingest.RenderLessonFile(&ingest.LessonFile{
+		Module:      moduleSlug,
+		Slug:        l.Slug,
+		Title:       l.Title,
+		Order:       l.OrderIndex,
+		ReadingTime: l.ReadingTimeMin,
+		SourceURL:   l.SourceURL,
+		MinGo:       l.MinGoVersion,
+	}, l.BodyMD)
+	if err != nil {
+		return fmt.Errorf("сборка файла урока %s: %w", l.Slug, err)
+	}
+
+	path := filepath.Join(dir, moduleSlug, l.Slug+".md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("создание директории для %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		return fmt.Errorf("запись %s: %w", path, err)
+	}
+	return nil
+}
+
+// moduleSlugsByID строит отображение ID модуля в его slug.
+func moduleSlugsByID(ctx context.Context, repo *content.Repository) (map[int64]string, error) {
+	modules, err := repo.ListModules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("чтение модулей: %w", err)
+	}
+	slugs := make(map[int64]string, len(modules))
+	for _, m := range modules {
+		slugs[m.ID] = m.Slug
+	}
+	return slugs, nil
+}
@@ -0,0 +1,143 @@
+package main
+
+// Аудит покрытия тем. Канонический список — «скелет» курса Go: если по
+// теме нет ни одного урока, это пропуск; если уроков меньше порога —
+// тема покрыта тонко. Урок относится к теме по ключевым словам в
+// заголовке и тексте: заголовок весит больше, случайное упоминание
+// слова в тексте тему не закрывает.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golearning/internal/content"
+	"golearning/internal/db"
+)
+
+// topic — одна тема канонического списка.
+type topic struct {
+	Title     string
+	Keywords  []string // ищутся без учёта регистра
+	SourceURL string   // где искать материал, если тема не покрыта
+}
+
+// canonicalTopics — минимальный набор тем, без которых курс Go неполон.
+// Порядок — примерный порядок изучения.
+var canonicalTopics = []topic{
+	{"Переменные и базовые типы", []string{"переменн", "тип данных", "типы данных", "variable"}, "https://go.dev/tour/basics"},
+	{"Управляющие конструкции", []string{"условн", " if ", " for ", "цикл", "switch"}, "https://go.dev/tour/flowcontrol"},
+	{"Функции", []string{"функци", "замыкан", "defer"}, "https://go.dev/tour/basics/4"},
+	{"Срезы", []string{"срез", "slice", "append"}, "https://go.dev/blog/slices-intro"},
+	{"Карты", []string{"карт", " map", "словар"}, "https://go.dev/blog/maps"},
+	{"Структуры", []string{"структур", "struct"}, "https://go.dev/tour/moretypes/2"},
+	{"Указатели", []string{"указател", "pointer"}, "https://go.dev/tour/moretypes/1"},
+	{"Методы", []string{"метод", "receiver", "получател"}, "https://go.dev/tour/methods"},
+	{"Интерфейсы", []string{"интерфейс", "interface"}, "https://go.dev/tour/methods/9"},
+	{"Обработка ошибок", []string{"ошибк", "error", "panic", "recover"}, "https://go.dev/blog/error-handling-and-go"},
+	{"Горутины", []string{"горутин", "goroutine"}, "https://go.dev/tour/concurrency"},
+	{"Каналы", []string{"канал", "channel", "select"}, "https://go.dev/tour/concurrency/2"},
+	{"Синхронизация", []string{"mutex", "sync.", "waitgroup", "atomic"}, "https://go.dev/tour/concurrency/9"},
+	{"Контексты", []string{"context", "контекст"}, "https://go.dev/blog/context"},
+	{"Дженерики", []string{"дженерик", "generic", "обобщ", "параметр типа"}, "https://go.dev/doc/tutorial/generics"},
+	{"Тестирование", []string{"тестирован", "testing", "go test", "бенчмарк"}, "https://go.dev/doc/tutorial/add-a-test"},
+	{"Пакеты и модули", []string{"пакет", "go.mod", "модул"}, "https://go.dev/doc/tutorial/create-module"},
+	{"Работа с JSON", []string{"json", "marshal", "сериализ"}, "https://go.dev/blog/json"},
+	{"HTTP и веб", []string{"http", "handler", "веб-сервер"}, "https://go.dev/doc/tutorial/web-service-gin"},
+}
+
+// topicCoverage — покрытие одной темы уроками.
+type topicCoverage struct {
+	Topic   topic
+	Lessons []content.Lesson // уроки, закрывающие тему
+}
+
+func runAudit(args []string) error {
+	fs := flag.NewFlagSet("curriculum audit", flag.ExitOnError)
+	dbPath := fs.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	minLessons := fs.Int("min-lessons", 2, "Минимум уроков, чтобы тема считалась покрытой")
+	fs.Parse(args)
+
+	database, err := db.Open(*dbPath)
+	if err != nil {
+		return fmt.Errorf("открытие БД: %w", err)
+	}
+	defer database.Close()
+
+	repo := content.NewRepository(database)
+	lessons, err := repo.ListAllLessons(context.Background())
+	if err != nil {
+		return fmt.Errorf("чтение уроков: %w", err)
+	}
+	if len(lessons) == 0 {
+		return fmt.Errorf("в базе нет уроков — аудировать нечего")
+	}
+
+	coverage := auditCoverage(lessons)
+
+	var missing, thin int
+	fmt.Printf("Уроков в базе: %d, тем в списке: %d\n\n", len(lessons), len(canonicalTopics))
+	for _, c := range coverage {
+		switch {
+		case len(c.Lessons) == 0:
+			missing++
+			fmt.Printf("✗ %s — нет ни одного урока\n", c.Topic.Title)
+			fmt.Printf("  материал: %s\n", c.Topic.SourceURL)
+		case len(c.Lessons) < *minLessons:
+			thin++
+			fmt.Printf("△ %s — тонкое покрытие (%d из %d): %s\n",
+				c.Topic.Title, len(c.Lessons), *minLessons, lessonSlugs(c.Lessons))
+			fmt.Printf("  материал: %s\n", c.Topic.SourceURL)
+		default:
+			fmt.Printf("✓ %s — уроков: %d\n", c.Topic.Title, len(c.Lessons))
+		}
+	}
+
+	fmt.Printf("\nИтого: пропущено тем %d, покрыто тонко %d\n", missing, thin)
+	if missing > 0 || thin > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// auditCoverage распределяет уроки по темам канонического списка.
+func auditCoverage(lessons []content.Lesson) []topicCoverage {
+	coverage := make([]topicCoverage, len(canonicalTopics))
+	for i, t := range canonicalTopics {
+		coverage[i].Topic = t
+		for _, l := range lessons {
+			if lessonCoversTopic(l, t) {
+				coverage[i].Lessons = append(coverage[i].Lessons, l)
+			}
+		}
+	}
+	return coverage
+}
+
+// lessonCoversTopic решает, относится ли урок к теме. Совпадение в
+// заголовке закрывает тему сразу; в тексте ключевое слово должно
+// встретиться несколько раз, чтобы отсечь случайные упоминания.
+func lessonCoversTopic(l content.Lesson, t topic) bool {
+	title := strings.ToLower(l.Title)
+	body := strings.ToLower(l.BodyMD)
+	for _, kw := range t.Keywords {
+		if strings.Contains(title, kw) {
+			return true
+		}
+		if strings.Count(body, kw) >= 3 {
+			return true
+		}
+	}
+	return false
+}
+
+// lessonSlugs перечисляет slug'и уроков через запятую.
+func lessonSlugs(lessons []content.Lesson) string {
+	slugs := make([]string, len(lessons))
+	for i, l := range lessons {
+		slugs[i] = l.Slug
+	}
+	return strings.Join(slugs, ", ")
+}
@@ -0,0 +1,44 @@
+package main
+
+// Утилита анализа учебной программы. Команды:
+//
+//	curriculum audit — сверяет импортированные уроки с каноническим
+//	списком тем Go и показывает пропущенные и слабо покрытые области.
+//
+// Аудит удобно гонять после крупного импорта: он подсказывает, какие
+// темы ещё стоит добавить и где искать материал.
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "audit":
+		if err := runAudit(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Неизвестная команда: %s\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Использование: curriculum <команда> [флаги]
+
+Команды:
+  audit  Сверить уроки с каноническим списком тем Go
+
+Примеры:
+  curriculum audit --db=./data.db
+  curriculum audit --min-lessons=3`)
+}
@@ -0,0 +1,88 @@
+package main
+
+// Утилита обслуживания SQLite-базы:
+//
+//	dbtool vacuum          — сжать файл БД
+//	dbtool integrity-check — проверить целостность
+//	dbtool analyze         — обновить статистику планировщика
+//	dbtool fts-rebuild     — перестроить полнотекстовые индексы
+//	dbtool migrate         — применить миграции
+//	dbtool migrate down    — откатить миграции до версии --to
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golearning/internal/db"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	args := os.Args[2:]
+
+	// У migrate есть подкоманда down со своим флагом --to
+	var migrateDown bool
+	if command == "migrate" && len(args) > 0 && args[0] == "down" {
+		migrateDown = true
+		args = args[1:]
+	}
+
+	fs := flag.NewFlagSet("dbtool "+command, flag.ExitOnError)
+	dbPath := fs.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	toVersion := fs.String("to", "", "Целевая версия отката, например 013 (для migrate down)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(2)
+	}
+
+	database, err := db.Open(*dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка открытия БД: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	switch command {
+	case "vacuum":
+		err = db.Vacuum(database)
+	case "integrity-check":
+		err = db.IntegrityCheck(database)
+	case "analyze":
+		err = db.Analyze(database)
+	case "fts-rebuild":
+		err = db.RebuildFTS(database)
+	case "migrate":
+		if migrateDown {
+			err = db.MigrateDown(database, *toVersion)
+		} else {
+			err = db.Migrate(database)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Неизвестная команда: %s\n", command)
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %s: готово\n", command)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Использование: dbtool <команда> [--db=./data.db]
+
+Команды:
+  vacuum           Сжать файл БД
+  integrity-check  Проверить целостность
+  analyze          Обновить статистику планировщика
+  fts-rebuild      Перестроить полнотекстовые индексы
+  migrate          Применить миграции
+  migrate down     Откатить миграции до версии --to=NNN (она остаётся)`)
+}
@@ -0,0 +1,156 @@
+package main
+
+// Генерация эталонного Docker Compose-стека. Хранилище — SQLite на
+// volume (отдельный сервис БД серверу не нужен), поэтому стек состоит
+// из веб-сервера, изолированного runnerd, Redis для общего состояния
+// реплик и Caddy с автоматическим TLS от Let's Encrypt.
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golearning/internal/config"
+)
+
+const deployDockerfile = `# Сборка бинарников
+FROM golang:1.22-alpine AS build
+WORKDIR /src
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/server ./cmd/server \
+ && CGO_ENABLED=0 go build -o /out/runnerd ./cmd/runnerd
+
+# Веб-сервер: toolchain не нужен, код выполняет runnerd
+FROM alpine:3.20 AS server
+RUN apk add --no-cache ca-certificates
+COPY --from=build /out/server /usr/local/bin/server
+ENTRYPOINT ["/usr/local/bin/server"]
+
+# Runnerd выполняет код студентов через go run — ему нужен toolchain
+FROM golang:1.22-alpine AS runnerd
+COPY --from=build /out/runnerd /usr/local/bin/runnerd
+ENTRYPOINT ["/usr/local/bin/runnerd"]
+`
+
+// deployComposeTemplate — docker-compose.yml. Подставляются аргументы
+// сервера (монтирование конфигурации) и volume конфигурации.
+const deployComposeTemplate = `# Эталонный стек Go Learning. Токен runnerd — в .env (не коммитьте его).
+# БД — SQLite на volume data: отдельный сервис Postgres серверу не нужен.
+services:
+  server:
+    build:
+      context: ..
+      dockerfile: %[1]s/Dockerfile
+      target: server
+    command: >-
+      --db /data/data.db --addr :8080 --auto-migrate
+      --runner remote --runner-addr http://runnerd:8090 --runner-token ${RUNNER_TOKEN}
+      --redis-addr redis:6379 --trust-proxy%s
+    volumes:
+      - data:/data%s
+    depends_on:
+      - runnerd
+      - redis
+    restart: unless-stopped
+
+  runnerd:
+    build:
+      context: ..
+      dockerfile: %[1]s/Dockerfile
+      target: runnerd
+    command: --addr :8090 --token ${RUNNER_TOKEN}
+    # Код студентов выполняется здесь — ограничиваем ресурсы и сеть
+    mem_limit: 1g
+    pids_limit: 256
+    restart: unless-stopped
+
+  redis:
+    image: redis:7-alpine
+    restart: unless-stopped
+
+  caddy:
+    image: caddy:2
+    ports:
+      - "80:80"
+      - "443:443"
+    volumes:
+      - ./Caddyfile:/etc/caddy/Caddyfile:ro
+      - caddy_data:/data
+    depends_on:
+      - server
+    restart: unless-stopped
+
+volumes:
+  data:
+  caddy_data:
+`
+
+// runInitDeploy генерирует файлы стека в --dir.
+func runInitDeploy(args []string) error {
+	fs := flag.NewFlagSet("golearn init-deploy", flag.ExitOnError)
+	dir := fs.String("dir", "deploy", "Директория для файлов стека")
+	domain := fs.String("domain", "", "Домен для TLS через Caddy (пусто — HTTP на :80)")
+	configPath := fs.String("config", "", "JSON-конфигурация сервера — будет смонтирована в контейнер")
+	fs.Parse(args)
+
+	// Конфигурация проверяется до генерации: битый JSON лучше поймать сейчас
+	if *configPath != "" {
+		if _, err := config.Load(*configPath); err != nil {
+			return fmt.Errorf("проверка конфигурации: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return fmt.Errorf("create deploy dir: %w", err)
+	}
+
+	// Общий токен сервера и runnerd
+	tokenBytes := make([]byte, 24)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return fmt.Errorf("generate runner token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	serverArgs, configVolume := "", ""
+	if *configPath != "" {
+		abs, err := filepath.Abs(*configPath)
+		if err != nil {
+			return fmt.Errorf("resolve config path: %w", err)
+		}
+		serverArgs = " --config /etc/golearning/config.json"
+		configVolume = fmt.Sprintf("\n      - %s:/etc/golearning/config.json:ro", abs)
+	}
+
+	site := ":80"
+	if *domain != "" {
+		site = *domain
+	}
+	caddyfile := fmt.Sprintf("%s {\n\treverse_proxy server:8080\n}\n", site)
+
+	files := map[string]string{
+		"Dockerfile":         deployDockerfile,
+		"docker-compose.yml": fmt.Sprintf(deployComposeTemplate, filepath.Base(*dir), serverArgs, configVolume),
+		"Caddyfile":          caddyfile,
+		".env":               "RUNNER_TOKEN=" + token + "\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(*dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s уже существует — удалите его или выберите другую --dir", path)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+		fmt.Printf("Записан %s\n", path)
+	}
+
+	fmt.Println("\nЗапуск стека:")
+	fmt.Printf("  cd %s && docker compose up -d --build\n", *dir)
+	if *domain == "" {
+		fmt.Println("Без --domain Caddy слушает HTTP :80; для TLS перегенерируйте с --domain learn.example.com")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+// golearn — утилита развёртывания:
+//
+//	golearn init-deploy --domain learn.example.com
+//	golearn self-update --release-url https://releases.example.com/golearn.json
+//
+// init-deploy генерирует эталонный Docker Compose-стек (веб-сервер,
+// runnerd, Redis, reverse proxy Caddy с автоматическим TLS) вместе с
+// Dockerfile и Caddyfile, так что многосервисный деплой воспроизводится
+// одной командой docker compose up.
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	args := os.Args[2:]
+
+	switch command {
+	case "init-deploy":
+		if err := runInitDeploy(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	case "self-update":
+		if err := runSelfUpdate(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Неизвестная команда: %s\n", command)
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Использование: golearn <команда>
+
+Команды:
+  init-deploy  Сгенерировать Docker Compose-стек (см. --help команды)
+  self-update  Обновить бинарник до версии из --release-url`)
+}
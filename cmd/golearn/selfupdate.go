@@ -0,0 +1,67 @@
+package main
+
+// Самообновление CLI: golearn self-update --release-url <URL>.
+// Манифест релиза описывает бинарники по платформам с контрольными
+// суммами SHA-256; файл заменяется атомарно только после сверки суммы.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"golearning/internal/selfupdate"
+	"golearning/internal/version"
+)
+
+func runSelfUpdate(args []string) error {
+	fs := flag.NewFlagSet("golearn self-update", flag.ExitOnError)
+	releaseURL := fs.String("release-url", "", "URL JSON-манифеста релизов (обязателен)")
+	check := fs.Bool("check", false, "Только проверить наличие новой версии, без установки")
+	fs.Parse(args)
+
+	if *releaseURL == "" {
+		return fmt.Errorf("укажите --release-url с манифестом релизов")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	release, err := selfupdate.Fetch(ctx, *releaseURL)
+	if err != nil {
+		return err
+	}
+
+	if !selfupdate.IsNewer(release.Version, version.Version) {
+		fmt.Printf("Версия %s актуальна (в манифесте %s)\n", version.Version, release.Version)
+		return nil
+	}
+	fmt.Printf("Доступна версия %s (текущая %s)\n", release.Version, version.Version)
+	if *check {
+		return nil
+	}
+
+	asset := release.AssetFor(runtime.GOOS, runtime.GOARCH)
+	if asset == nil {
+		return fmt.Errorf("в релизе %s нет бинарника для %s/%s", release.Version, runtime.GOOS, runtime.GOARCH)
+	}
+
+	fmt.Printf("Загрузка %s...\n", asset.URL)
+	data, err := selfupdate.Download(ctx, asset)
+	if err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("путь к бинарнику: %w", err)
+	}
+	if err := selfupdate.Apply(exe, data); err != nil {
+		return err
+	}
+
+	fmt.Printf("Обновлено до %s: %s\n", release.Version, exe)
+	return nil
+}
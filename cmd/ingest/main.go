@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -18,11 +19,30 @@ func main() {
 	dbPath := flag.String("db", "./data.db", "Путь к файлу базы данных SQLite")
 	limit := flag.Int("limit", 0, "Ограничение количества уроков (0 = без ограничения)")
 	baseURL := flag.String("url", "https://metanit.com/go/tutorial", "Базовый URL для импорта")
-	demo := flag.Bool("demo", false, "Использовать демонстрационные данные вместо загрузки")
+	demo := flag.String("demo", "", "Имя демонстрационного seed-пака (например basics или concurrency)")
+	listDemos := flag.Bool("list-demos", false, "Показать доступные seed-паки и выйти")
 	dir := flag.String("dir", "", "Директория с Markdown/MDX файлами уроков")
 	useMDX := flag.Bool("mdx", false, "Использовать MDX парсер (рекомендуется для lessons_mdx)")
+	watch := flag.Bool("watch", false, "После импорта следить за --dir и переимпортировать изменившиеся файлы")
+	attribution := flag.String("attribution", "", "JSON-файл с атрибуцией источников (название, автор, лицензия по хостам)")
 	flag.Parse()
 
+	if *listDemos {
+		packs, err := ingest.ListSeedPacks()
+		if err != nil {
+			log.Fatalf("Ошибка чтения seed-паков: %v", err)
+		}
+		fmt.Println("Доступные seed-паки:")
+		for _, p := range packs {
+			fmt.Printf("  %-12s %s — %s\n", p.Name, p.Title, p.Description)
+		}
+		return
+	}
+
+	if *watch && *dir == "" {
+		log.Fatalf("--watch работает только вместе с --dir")
+	}
+
 	log.Printf("Go Learning — Импорт контента")
 	log.Printf("База данных: %s", *dbPath)
 
@@ -56,25 +76,37 @@ func main() {
 	switch {
 	case *dir != "":
 		// Импорт из директории с файлами уроков
+		var fileImporter ingest.FileImporter
 		if *useMDX {
 			log.Printf("Режим: MDX импорт из директории %s", *dir)
 			importer := ingest.NewMDXImporter(repo, *dir)
 			if err := importer.Import(ctx); err != nil {
 				log.Fatalf("Ошибка MDX импорта: %v", err)
 			}
+			fileImporter = importer
 		} else {
 			log.Printf("Режим: Markdown импорт из директории %s", *dir)
 			importer := ingest.NewMarkdownImporter(repo, *dir)
 			if err := importer.Import(ctx); err != nil {
 				log.Fatalf("Ошибка импорта: %v", err)
 			}
+			fileImporter = importer
 		}
 
-	case *demo:
-		// Демонстрационные данные
-		log.Println("Режим: демонстрационные данные")
-		demoData := ingest.NewDemoData(repo)
-		if err := demoData.Seed(ctx); err != nil {
+		// Режим наблюдения: переимпортируем файлы по мере правок
+		if *watch {
+			if err := ingest.Watch(ctx, *dir, fileImporter); err != nil {
+				log.Fatalf("Ошибка наблюдения: %v", err)
+			}
+			log.Println("Наблюдение остановлено")
+			return
+		}
+
+	case *demo != "":
+		// Демонстрационные данные из выбранного seed-пака
+		log.Printf("Режим: демонстрационные данные (пак %s)", *demo)
+		seeder := ingest.NewSeeder(repo)
+		if err := seeder.Seed(ctx, *demo); err != nil {
 			log.Fatalf("Ошибка создания демо-данных: %v", err)
 		}
 
@@ -89,6 +121,13 @@ func main() {
 
 		// Создаём и запускаем pipeline
 		pipeline := ingest.NewPipeline(crawler, parser, rewriter, repo)
+		if *attribution != "" {
+			attrs, err := ingest.LoadAttributions(*attribution)
+			if err != nil {
+				log.Fatalf("Ошибка чтения атрибуции: %v", err)
+			}
+			pipeline.SetAttributions(attrs)
+		}
 
 		if err := pipeline.Run(ctx, *limit); err != nil {
 			if ctx.Err() != nil {
@@ -99,8 +138,8 @@ func main() {
 			log.Printf("Ошибка загрузки с сайта: %v", err)
 			log.Println("Переключаемся на демонстрационные данные...")
 
-			demoData := ingest.NewDemoData(repo)
-			if err := demoData.Seed(ctx); err != nil {
+			seeder := ingest.NewSeeder(repo)
+			if err := seeder.Seed(ctx, "basics"); err != nil {
 				log.Fatalf("Ошибка создания демо-данных: %v", err)
 			}
 		}
@@ -0,0 +1,56 @@
+package main
+
+// Линтер контента курса:
+//
+//	lint-content --links — проверить внешние ссылки уроков
+//
+// Битые ссылки записываются в таблицу dead_links и показываются
+// в админке (/admin/links). Код выхода 1, если найдены проблемы, —
+// удобно для запуска из CI или cron.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"golearning/internal/content"
+	"golearning/internal/db"
+	"golearning/internal/linkcheck"
+)
+
+func main() {
+	dbPath := flag.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	links := flag.Bool("links", false, "Проверить внешние ссылки уроков")
+	flag.Parse()
+
+	if !*links {
+		fmt.Fprintln(os.Stderr, "Не выбрано ни одной проверки. Доступно: --links")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	database, err := db.Open(*dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка открытия БД: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	contentRepo := content.NewRepository(database)
+	checker := linkcheck.NewChecker(database, contentRepo)
+
+	fmt.Println("Проверяем внешние ссылки уроков...")
+	report, err := checker.Run(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка проверки ссылок: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Проверено ссылок: %d, битых: %d, починилось: %d\n",
+		report.Checked, report.Dead, report.Fixed)
+	if report.Dead > 0 {
+		fmt.Println("Подробности: /admin/links в веб-интерфейсе")
+		os.Exit(1)
+	}
+}
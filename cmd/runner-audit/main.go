@@ -0,0 +1,327 @@
+// runner-audit — самопроверка защиты выполнения кода. Прогоняет через
+// настроенный runner набор заведомо враждебных сниппетов (бесконечный
+// цикл, порождение процессов, сеть, запись вне рабочей директории,
+// гигантский вывод) и отчитывается, какие защиты сработали. Полезно
+// запустить перед тем, как открывать сервер наружу:
+//
+//	runner-audit --runner remote --runner-addr https://runner.internal:8090
+//
+// Сниппеты ограничены по объёму (фиксированное число процессов,
+// мегабайт и итераций), так что даже на незащищённом локальном runner
+// аудит не положит машину. Код выхода 1, если хоть одна защита не
+// сработала, — удобно для запуска из CI.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"golearning/internal/practice"
+)
+
+// escapeMarker печатается сниппетом, если враждебное действие удалось.
+// Вердикт выносится по наличию маркера в stdout.
+const escapeMarker = "AUDIT-ESCAPE"
+
+// probe — одна проверка: сниппет и вердикт по результату его запуска.
+type probe struct {
+	name   string
+	code   string
+	limits practice.RunLimits
+	// judge выносит вердикт (true — защита сработала). nil — вердикт
+	// по маркеру escapeMarker в stdout.
+	judge func(res *practice.RunResult, elapsed time.Duration) (bool, string)
+}
+
+func main() {
+	runnerKind := flag.String("runner", "local", "Выполнение кода: local (go toolchain) или remote (runnerd)")
+	runnerAddr := flag.String("runner-addr", "", "Адрес runnerd для --runner=remote (например https://runner.internal:8090)")
+	runnerToken := flag.String("runner-token", "", "Токен авторизации на runnerd")
+	runnerCert := flag.String("runner-cert", "", "Клиентский сертификат для mTLS с runnerd")
+	runnerKey := flag.String("runner-key", "", "Приватный ключ клиентского сертификата runnerd")
+	runnerCA := flag.String("runner-ca", "", "CA сертификата runnerd")
+	flag.Parse()
+
+	var runner practice.Runner
+	switch *runnerKind {
+	case "local":
+		runner = practice.NewLocalRunner()
+	case "remote":
+		if *runnerAddr == "" {
+			log.Fatalf("--runner=remote требует --runner-addr")
+		}
+		remote := practice.NewRemoteRunner(*runnerAddr, *runnerToken)
+		if *runnerCert != "" || *runnerCA != "" {
+			if err := remote.SetTLS(*runnerCert, *runnerKey, *runnerCA); err != nil {
+				log.Fatalf("Ошибка настройки TLS для runnerd: %v", err)
+			}
+		}
+		runner = remote
+	default:
+		log.Fatalf("Неизвестный runner: %s (ожидается local или remote)", *runnerKind)
+	}
+
+	fmt.Printf("Аудит защиты runner-а (%s), проверок: %d\n\n", *runnerKind, len(probes))
+
+	failed := 0
+	for _, p := range probes {
+		start := time.Now()
+		res, err := runProbe(runner, p)
+		elapsed := time.Since(start)
+		if err != nil {
+			fmt.Printf("❌ %s — не удалось выполнить проверку: %v\n", p.name, err)
+			failed++
+			continue
+		}
+
+		judge := p.judge
+		if judge == nil {
+			judge = markerJudge
+		}
+		held, detail := judge(res, elapsed)
+		if held {
+			fmt.Printf("✅ %s — защита сработала (%s)\n", p.name, detail)
+		} else {
+			fmt.Printf("❌ %s — защита НЕ сработала (%s)\n", p.name, detail)
+			failed++
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		fmt.Printf("Не сработало защит: %d из %d\n", failed, len(probes))
+		if *runnerKind == "local" {
+			fmt.Println("Локальный runner не изолирует код — для продакшена запускайте runnerd в контейнере с лимитами.")
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("Все %d защит сработали\n", len(probes))
+}
+
+// runProbe выполняет сниппет со сторожевым таймером: если runner не
+// вернул результат за бюджет с запасом, проверка считается проваленной
+// вместо зависания всего аудита (известный случай — LocalRunner ждёт
+// вывод осиротевшего потомка go run дольше своего таймаута).
+func runProbe(runner practice.Runner, p probe) (*practice.RunResult, error) {
+	budget := p.limits.Timeout
+	if budget <= 0 {
+		budget = practice.RunTimeout
+	}
+	watchdog := budget + 30*time.Second
+
+	type outcome struct {
+		res *practice.RunResult
+		err error
+	}
+	ch := make(chan outcome, 1)
+	go func() {
+		res, err := runner.Run(context.Background(), p.code, p.limits)
+		ch <- outcome{res, err}
+	}()
+	select {
+	case out := <-ch:
+		return out.res, out.err
+	case <-time.After(watchdog):
+		return &practice.RunResult{
+			Error: fmt.Sprintf("запуск не завершился за %v при бюджете %v", watchdog, budget),
+		}, nil
+	}
+}
+
+// markerJudge — вердикт по умолчанию: защита сработала, если сниппет
+// не напечатал escapeMarker.
+func markerJudge(res *practice.RunResult, _ time.Duration) (bool, string) {
+	for _, line := range strings.Split(res.Stdout, "\n") {
+		if strings.Contains(line, escapeMarker) {
+			return false, strings.TrimSpace(line)
+		}
+	}
+	if res.Error != "" {
+		return true, firstLine(res.Error)
+	}
+	return true, firstLine(res.Stdout)
+}
+
+// firstLine обрезает многострочный вывод до первой непустой строки.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return "вывод пуст"
+}
+
+// probes — набор проверок. Каждый сниппет печатает escapeMarker только
+// если враждебное действие удалось; проверки таймаута и объёма вывода
+// судятся отдельно.
+var probes = []probe{
+	{
+		name: "Бесконечный цикл",
+		// Цикл сам останавливается через 60 секунд: даже если ни
+		// таймаут runner-а, ни сторож аудита не сработают, процесс
+		// не останется крутиться на машине
+		code: `package main
+
+import "time"
+
+func main() {
+	start := time.Now()
+	for time.Since(start) < 60*time.Second {
+	}
+}
+`,
+		limits: practice.RunLimits{Timeout: 5 * time.Second},
+		judge: func(res *practice.RunResult, elapsed time.Duration) (bool, string) {
+			if res.Success {
+				return false, "цикл доработал до конца, таймаут не сработал"
+			}
+			// Запас на компиляцию go run поверх бюджета выполнения
+			if elapsed > 25*time.Second {
+				return false, fmt.Sprintf("запуск длился %v при бюджете 5s", elapsed.Round(time.Second))
+			}
+			return true, fmt.Sprintf("прерван за %v", elapsed.Round(time.Second))
+		},
+	},
+	{
+		name: "Порождение процессов",
+		code: `package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+func main() {
+	spawned := 0
+	for i := 0; i < 64; i++ {
+		if err := exec.Command("/bin/true").Run(); err != nil {
+			fmt.Println("запуск процесса заблокирован:", err)
+			break
+		}
+		spawned++
+	}
+	if spawned > 0 {
+		fmt.Println("` + escapeMarker + `: запущено дочерних процессов:", spawned)
+	}
+}
+`,
+	},
+	{
+		name: "Сетевой доступ",
+		code: `package main
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+func main() {
+	conn, err := net.DialTimeout("tcp", "1.1.1.1:80", 3*time.Second)
+	if err != nil {
+		fmt.Println("соединение заблокировано:", err)
+		return
+	}
+	conn.Close()
+	fmt.Println("` + escapeMarker + `: исходящее соединение установлено")
+}
+`,
+	},
+	{
+		name: "Запись вне рабочей директории",
+		code: `package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	home, _ := os.UserHomeDir()
+	targets := []string{
+		"/etc/runner-audit-probe",
+		filepath.Join(home, ".runner-audit-probe"),
+		filepath.Join("..", "runner-audit-probe"),
+	}
+	for _, path := range targets {
+		if err := os.WriteFile(path, []byte("probe"), 0644); err != nil {
+			fmt.Println("запись заблокирована:", path)
+			continue
+		}
+		os.Remove(path)
+		fmt.Println("` + escapeMarker + `: запись удалась:", path)
+	}
+}
+`,
+	},
+	{
+		name: "Чтение системных файлов",
+		code: `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	data, err := os.ReadFile("/etc/passwd")
+	if err != nil {
+		fmt.Println("чтение заблокировано:", err)
+		return
+	}
+	fmt.Println("` + escapeMarker + `: прочитан /etc/passwd,", len(data), "байт")
+}
+`,
+	},
+	{
+		name: "Гигантский вывод",
+		code: `package main
+
+import (
+	"os"
+	"strings"
+)
+
+func main() {
+	chunk := strings.Repeat("A", 1<<20)
+	for i := 0; i < 64; i++ {
+		os.Stdout.WriteString(chunk)
+	}
+}
+`,
+		judge: func(res *practice.RunResult, _ time.Duration) (bool, string) {
+			const full = 64 << 20
+			if len(res.Stdout) >= full {
+				return false, fmt.Sprintf("весь вывод (%d МиБ) вернулся целиком", full>>20)
+			}
+			return true, fmt.Sprintf("вывод ограничен до %d байт", len(res.Stdout))
+		},
+	},
+	{
+		name:   "Превышение лимита памяти",
+		limits: practice.RunLimits{MemoryMB: 64},
+		code: `package main
+
+import "fmt"
+
+func main() {
+	var hold [][]byte
+	for i := 0; i < 512; i++ {
+		b := make([]byte, 1<<20)
+		for j := 0; j < len(b); j += 4096 {
+			b[j] = 1
+		}
+		hold = append(hold, b)
+	}
+	fmt.Println("` + escapeMarker + `: выделено МиБ при лимите 64:", len(hold))
+}
+`,
+	},
+}
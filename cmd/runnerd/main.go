@@ -0,0 +1,162 @@
+// runnerd — выделенный сервис выполнения кода. Веб-сервер вызывает его
+// по gRPC (practice.RemoteRunner): методы Run и Check стримят вывод
+// программы по мере выполнения и завершают поток финальным результатом,
+// так что сам код студентов можно выполнять на отдельной изолированной
+// машине. Контракт сервиса — internal/runnerapi.
+package main
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"golearning/internal/practice"
+	"golearning/internal/runnerapi"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "Адрес для прослушивания")
+	token := flag.String("token", "", "Общий токен авторизации (пусто — без авторизации)")
+	tlsCert := flag.String("tls-cert", "", "Путь к TLS-сертификату (включает TLS)")
+	tlsKey := flag.String("tls-key", "", "Путь к приватному ключу TLS")
+	clientCA := flag.String("client-ca", "", "CA клиентских сертификатов (включает mTLS)")
+	flag.Parse()
+
+	log.Printf("Go Learning — Runner-сервис (gRPC)")
+	log.Printf("Адрес: %s", *addr)
+
+	opts := []grpc.ServerOption{
+		grpc.StreamInterceptor(authInterceptor(*token)),
+	}
+
+	if *tlsCert != "" && *tlsKey != "" {
+		cert, err := tls.LoadX509KeyPair(*tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatalf("Ошибка загрузки сертификата: %v", err)
+		}
+		tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+		if *clientCA != "" {
+			caPEM, err := os.ReadFile(*clientCA)
+			if err != nil {
+				log.Fatalf("Ошибка чтения CA: %v", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				log.Fatalf("В %s нет валидных сертификатов", *clientCA)
+			}
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			tlsConfig.ClientCAs = pool
+			log.Printf("mTLS: требуются клиентские сертификаты (%s)", *clientCA)
+		}
+		log.Printf("TLS: сертификат %s", *tlsCert)
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if *clientCA != "" {
+		log.Fatalf("--client-ca требует --tls-cert и --tls-key")
+	}
+
+	server := grpc.NewServer(opts...)
+	runnerapi.RegisterRunnerServer(server, &runnerService{runner: practice.NewLocalRunner()})
+	// Проверка живости без авторизации — как прежний /healthz
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Ошибка прослушивания %s: %v", *addr, err)
+	}
+	if err := server.Serve(lis); err != nil {
+		log.Fatalf("Ошибка сервера: %v", err)
+	}
+}
+
+// authInterceptor проверяет токен в метаданных authorization. Методы
+// Runner — только стримы, поэтому stream-интерцептора достаточно;
+// unary health-сервис остаётся открытым.
+func authInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if token != "" && strings.HasPrefix(info.FullMethod, "/"+runnerapi.ServiceName+"/") {
+			got := ""
+			if md, ok := metadata.FromIncomingContext(ss.Context()); ok {
+				if v := md.Get("authorization"); len(v) > 0 {
+					got = v[0]
+				}
+			}
+			if subtle.ConstantTimeCompare([]byte(got), []byte("Bearer "+token)) != 1 {
+				return status.Error(codes.Unauthenticated, "unauthorized")
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// runnerService — реализация сервиса Runner поверх LocalRunner.
+type runnerService struct {
+	runner *practice.LocalRunner
+}
+
+// Run выполняет код, стримя вывод, и завершает поток результатом.
+func (s *runnerService) Run(req *runnerapi.RunRequest, stream grpc.ServerStreamingServer[runnerapi.Event]) error {
+	result, err := s.runner.RunStream(stream.Context(), req.Code, limits(req), &streamLogWriter{stream: stream})
+	return finish(stream, result, err)
+}
+
+// Check запускает тесты, стримя вывод, и завершает поток результатом.
+func (s *runnerService) Check(req *runnerapi.RunRequest, stream grpc.ServerStreamingServer[runnerapi.Event]) error {
+	result, err := s.runner.CheckStream(stream.Context(), req.Code, req.TestsGo, limits(req), &streamLogWriter{stream: stream})
+	return finish(stream, result, err)
+}
+
+// limits собирает лимиты выполнения из запроса. Нулевые значения
+// означают умолчания runner'а, сверху их ограничивает сам LocalRunner.
+func limits(req *runnerapi.RunRequest) practice.RunLimits {
+	return practice.RunLimits{
+		Timeout:  time.Duration(req.TimeoutSec) * time.Second,
+		MemoryMB: req.MemoryMB,
+	}
+}
+
+// finish отправляет финальное событие с результатом или переводит
+// внутреннюю ошибку в статус gRPC, не раскрывая деталей клиенту.
+func finish(stream grpc.ServerStreamingServer[runnerapi.Event], result *practice.RunResult, err error) error {
+	if err != nil {
+		log.Printf("Ошибка выполнения: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+	return stream.Send(&runnerapi.Event{Result: &runnerapi.Result{
+		Success: result.Success,
+		Stdout:  result.Stdout,
+		Stderr:  result.Stderr,
+		Error:   result.Error,
+	}})
+}
+
+// streamLogWriter шлёт порции вывода в поток. Send небезопасен для
+// конкурентных вызовов, а stdout и stderr программы пишутся из разных
+// горутин — поэтому мьютекс.
+type streamLogWriter struct {
+	mu     sync.Mutex
+	stream grpc.ServerStreamingServer[runnerapi.Event]
+}
+
+func (w *streamLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.stream.Send(&runnerapi.Event{Log: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
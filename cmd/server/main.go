@@ -2,28 +2,98 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	"golearning/internal/analytics"
+	"golearning/internal/audit"
+	"golearning/internal/auth"
+	"golearning/internal/branding"
+	"golearning/internal/cheatsheet"
+	"golearning/internal/config"
 	"golearning/internal/content"
 	"golearning/internal/db"
+	"golearning/internal/errlog"
+	"golearning/internal/flags"
+	"golearning/internal/godoc"
+	"golearning/internal/interview"
+	"golearning/internal/issues"
+	"golearning/internal/linkcheck"
+	"golearning/internal/llm"
+	"golearning/internal/notify"
+	"golearning/internal/plan"
 	"golearning/internal/practice"
 	"golearning/internal/progress"
+	"golearning/internal/rag"
+	"golearning/internal/render"
+	"golearning/internal/sched"
+	"golearning/internal/selfupdate"
+	"golearning/internal/snippets"
+	"golearning/internal/storage"
+	"golearning/internal/translate"
+	"golearning/internal/tts"
 	"golearning/internal/web"
 )
 
 func main() {
+	// Подкоманды установки systemd-сервиса (см. service.go)
+	if len(os.Args) > 1 && (os.Args[1] == "install-service" || os.Args[1] == "uninstall-service") {
+		runServiceCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	// Флаги командной строки
 	dbPath := flag.String("db", "./data.db", "Путь к файлу базы данных SQLite")
 	addr := flag.String("addr", ":8080", "Адрес для прослушивания")
+	compressLevel := flag.Int("compress-level", 5, "Уровень сжатия ответов (0 — отключить)")
+	compressMinSize := flag.Int("compress-min-size", 1024, "Минимальный размер ответа для сжатия, байт")
+	compressTypes := flag.String("compress-types", "", "Список content-type для сжатия через запятую (пусто — по умолчанию)")
+	basePath := flag.String("base-path", "", "Префикс пути при работе за reverse proxy (например /golearn)")
+	trustProxy := flag.Bool("trust-proxy", false, "Доверять заголовкам X-Forwarded-For/X-Forwarded-Proto")
+	accessLog := flag.String("access-log", "stdout", "Access-лог: off, stdout или путь к файлу")
+	accessLogSample := flag.Int("access-log-sample", 1, "Писать каждый N-й запрос access-лога")
+	rateLimit := flag.Int("rate-limit", 0, "Максимум API-запросов с одного IP в минуту (0 — без лимита)")
+	redisAddr := flag.String("redis-addr", "", "Адрес Redis host:port для лимитов и сессий (пусто — in-memory)")
+	runnerKind := flag.String("runner", "local", "Выполнение кода: local (go toolchain), fake (без выполнения) или remote (runnerd)")
+	runnerAddr := flag.String("runner-addr", "", "Адрес gRPC runnerd для --runner=remote (например runner.internal:8090)")
+	runnerToken := flag.String("runner-token", "", "Токен авторизации на runnerd")
+	runnerCert := flag.String("runner-cert", "", "Клиентский сертификат для mTLS с runnerd")
+	runnerKey := flag.String("runner-key", "", "Приватный ключ клиентского сертификата runnerd")
+	runnerCA := flag.String("runner-ca", "", "CA сертификата runnerd")
+	tlsCert := flag.String("tls-cert", "", "Путь к TLS-сертификату (включает HTTPS)")
+	tlsKey := flag.String("tls-key", "", "Путь к приватному ключу TLS")
+	autocertDomains := flag.String("autocert", "", "Домены для автоматических сертификатов Let's Encrypt через запятую")
+	autocertCache := flag.String("autocert-cache", "./autocert-cache", "Директория кэша сертификатов autocert")
+	httpAddr := flag.String("http-addr", ":80", "Адрес HTTP-листенера для редиректа на HTTPS (в режиме TLS)")
+	maintenanceInterval := flag.Duration("maintenance-interval", 0, "Интервал фонового обслуживания БД (0 — отключено)")
+	linkCheckInterval := flag.Duration("link-check-interval", 0, "Интервал фоновой проверки внешних ссылок (0 — только вручную)")
+	backupDir := flag.String("backup-dir", "", "Каталог резервных копий БД (пусто — рядом с файлом БД)")
+	autoMigrate := flag.Bool("auto-migrate", false, "Применять недостающие миграции при старте")
+	publicMode := flag.Bool("public", false, "Публичный режим: чтение открыто, практика по ключу доступа")
+	accessKey := flag.String("access-key", "", "Ключ доступа для входа в публичном режиме")
+	cohortKey := flag.String("cohort-key", "", "Ключ когорты: модули cohort-only и админка в публичном режиме")
+	reset2FA := flag.Bool("reset-2fa", false, "Сбросить двухфакторную аутентификацию (восстановление при потере кодов)")
+	configPath := flag.String("config", "", "Путь к JSON-файлу конфигурации (см. config.example.json)")
+	devMode := flag.Bool("dev", false, "Режим разработки: шаблоны и статика с диска на каждый запрос, подробные страницы ошибок")
+	themeDir := flag.String("theme-dir", "", "Каталог темы: файлы templates/ и static/ перекрывают встроенные")
+	godocURL := flag.String("godoc-url", "", "Базовый URL документации stdlib вместо pkg.go.dev (офлайн-зеркало godoc)")
+	offlineDocs := flag.Bool("offline-docs", false, "Отдавать документацию stdlib локально на /godoc/... (из установленного Go toolchain)")
+	releaseURL := flag.String("release-url", "", "URL JSON-манифеста релизов для проверки обновлений (пусто — отключено)")
 	flag.Parse()
 
 	log.Printf("Go Learning — Веб-сервер")
+	if buildProfile != "full" {
+		log.Printf("Профиль сборки: %s (без autocert, Redis и OAuth)", buildProfile)
+	}
 	log.Printf("База данных: %s", *dbPath)
 	log.Printf("Адрес: %s", *addr)
 
@@ -34,33 +104,353 @@ func main() {
 	}
 	defer database.Close()
 
-	// Применяем миграции
-	if err := db.Migrate(database); err != nil {
-		log.Fatalf("Ошибка миграции: %v", err)
+	// Сервер не трогает схему без явного разрешения: при рассинхроне
+	// бинарника и файла БД лучше отказаться стартовать, чем молча мигрировать.
+	if *autoMigrate {
+		if err := db.Migrate(database); err != nil {
+			log.Fatalf("Ошибка миграции: %v", err)
+		}
+	} else {
+		pending, err := db.PendingMigrations(database)
+		if err != nil {
+			log.Fatalf("Ошибка проверки схемы: %v", err)
+		}
+		if len(pending) > 0 {
+			log.Fatalf("БД отстаёт от бинарника: не применено миграций — %d (%s). Запустите с --auto-migrate или выполните dbtool migrate",
+				len(pending), strings.Join(pending, ", "))
+		}
+	}
+
+	schemaVersion, err := db.SchemaVersion(database)
+	if err != nil {
+		log.Fatalf("Ошибка чтения версии схемы: %v", err)
+	}
+
+	// Фоновое обслуживание БД (ANALYZE, optimize, чекпоинт WAL)
+	db.StartMaintenance(context.Background(), database, *maintenanceInterval)
+
+	// Ссылки на документацию stdlib в уроках: внешнее зеркало имеет
+	// приоритет над встроенным (--offline-docs)
+	switch {
+	case *godocURL != "":
+		render.SetStdlibDocBase(*godocURL)
+		log.Printf("Документация stdlib: %s", *godocURL)
+	case *offlineDocs:
+		render.SetStdlibDocBase(*basePath + "/godoc")
+		log.Printf("Документация stdlib: локальное зеркало на /godoc")
 	}
 
 	// Создаём репозитории
 	contentRepo := content.NewRepository(database)
 	progressRepo := progress.NewRepository(database)
 
+	// Сверка после перезапуска: отправки, чью проверку оборвал останов
+	// сервера, иначе навсегда остаются «на проверке»
+	if marked, err := progressRepo.ReconcileInterrupted(context.Background(), progress.InterruptedAfter); err != nil {
+		log.Printf("Ошибка сверки зависших отправок: %v", err)
+	} else if marked > 0 {
+		log.Printf("Прерванных отправок помечено: %d", marked)
+	}
+
 	// Создаём runner и checker
-	runner := practice.NewLocalRunner()
+	var runner practice.Runner
+	switch *runnerKind {
+	case "local":
+		runner = practice.NewLocalRunner()
+	case "fake":
+		runner = practice.NewFakeRunner()
+	case "remote":
+		if *runnerAddr == "" {
+			log.Fatalf("--runner=remote требует --runner-addr")
+		}
+		remote := practice.NewRemoteRunner(*runnerAddr, *runnerToken)
+		if *runnerCert != "" || *runnerCA != "" {
+			if err := remote.SetTLS(*runnerCert, *runnerKey, *runnerCA); err != nil {
+				log.Fatalf("Ошибка настройки TLS для runnerd: %v", err)
+			}
+		}
+		runner = remote
+	default:
+		log.Fatalf("Неизвестный runner: %s (ожидается local, fake или remote)", *runnerKind)
+	}
 	checker := practice.NewChecker(runner, contentRepo, progressRepo)
 
+	// Сверка toolchain при старте: уроки с min_go новее установленной
+	// версии помечаются как невыполнимые в этом окружении
+	toolchainVersion := ""
+	if *runnerKind == "local" {
+		if v, err := practice.ToolchainVersion(context.Background()); err != nil {
+			log.Printf("Go toolchain не найден: %v — выполнение кода работать не будет", err)
+		} else {
+			toolchainVersion = v
+			log.Printf("Go toolchain: %s", v)
+		}
+	}
+
+	// Журнал событий обучения: сырьё для аналитики и рекомендаций
+	tracker := analytics.NewTracker(database)
+	checker.SetTracker(tracker)
+
+	// Файловая конфигурация и хранилище артефактов
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Ошибка загрузки конфигурации: %v", err)
+	}
+	artifacts, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Ошибка создания хранилища артефактов: %v", err)
+	}
+	checker.SetArtifacts(artifacts)
+
+	// Вебхуки о событиях обучения (настраиваются в таблице webhooks)
+	webhooks := notify.NewWebhooks(database)
+	checker.SetNotifier(webhooks)
+
+	// xAPI-стейтменты в LRS (настраиваются через settings)
+	xapi := notify.NewXAPI(database)
+	checker.SetXAPI(xapi)
+
+	milestones := notify.NewMilestones(database)
+
 	// Создаём HTTP-сервер
-	server, err := web.NewServer(contentRepo, progressRepo, checker)
+	opts := web.Options{
+		CompressLevel:   *compressLevel,
+		CompressMinSize: *compressMinSize,
+		BasePath:        *basePath,
+		TrustProxy:      *trustProxy,
+		RateLimitPerMin: *rateLimit,
+		AccessLog:       *accessLog,
+		AccessLogSample: *accessLogSample,
+		PublicMode:      *publicMode,
+		AccessKey:       *accessKey,
+		CohortKey:       *cohortKey,
+		DevMode:         *devMode,
+		ThemeDir:        *themeDir,
+	}
+	if *devMode {
+		log.Printf("Режим разработки: шаблоны и статика перечитываются с диска")
+	}
+	if *themeDir != "" {
+		log.Printf("Тема: %s", *themeDir)
+	}
+	if *compressTypes != "" {
+		opts.CompressTypes = strings.Split(*compressTypes, ",")
+	}
+	if *publicMode {
+		if *accessKey == "" {
+			log.Fatalf("--public требует --access-key")
+		}
+		log.Printf("Публичный режим: чтение открыто, практика по ключу доступа")
+	}
+
+	server, err := web.NewServer(contentRepo, progressRepo, checker, opts)
 	if err != nil {
 		log.Fatalf("Ошибка создания сервера: %v", err)
 	}
+	server.SetNotifier(webhooks)
+	server.SetMilestones(milestones)
+	server.SetXAPI(xapi)
+
+	// Key/value-хранилище: Redis для общего состояния реплик, иначе in-memory
+	if *redisAddr != "" {
+		closeRedis := setupRedis(server, *redisAddr)
+		defer closeRedis()
+	}
+	analyticsService := analytics.NewService(database)
+	server.SetAnalytics(analyticsService)
+	server.SetTracker(tracker)
+	server.SetPlanner(plan.NewPlanner(database))
+	server.SetArtifacts(artifacts)
+	server.SetErrors(errlog.NewLog(database))
+	server.SetFlags(flags.NewService(database))
+	server.SetBranding(branding.NewService(database))
+	if *offlineDocs {
+		server.SetGodoc(godoc.NewService())
+	}
+	server.SetIssues(issues.NewRepository(database))
+	server.SetToolchainVersion(toolchainVersion)
+
+	// «Спросить курс»: включается, когда в конфигурации настроен LLM
+	// с моделью эмбеддингов (секция llm в --config)
+	var ragService *rag.Service
+	if cfg.LLM.Enabled() && cfg.LLM.EmbeddingModel != "" {
+		ragService = rag.NewService(database, contentRepo, cfg.LLM)
+		server.SetAsk(ragService)
+		log.Printf("«Спросить курс»: модель %s", cfg.LLM.Model)
+	}
+
+	// LLM-подсказки по проваленным проверкам: клиент достаточно
+	// настроить без модели эмбеддингов, сами подсказки включаются
+	// фича-флагом llm-hints
+	var translations *translate.Service
+	if cfg.LLM.Enabled() {
+		llmClient := llm.NewClient(cfg.LLM)
+		server.SetLLM(llmClient)
+
+		// Переводы уроков RU↔EN: машинный перевод собирает задача
+		// translate.lessons, вычитка — на странице /admin/translations
+		translations = translate.NewService(database, contentRepo, llmClient)
+		server.SetTranslations(translations)
+	}
+
+	// Озвучка уроков: синтез речи по секциям с кэшем в хранилище
+	// артефактов (секция tts в --config)
+	if cfg.TTS.Enabled() {
+		server.SetTTS(tts.NewService(tts.NewHTTP(cfg.TTS), artifacts))
+		log.Printf("Озвучка уроков: модель %s", cfg.TTS.Model)
+	}
+
+	// Журнал битых ссылок: страница /admin/links
+	links := linkcheck.NewChecker(database, contentRepo)
+	server.SetLinkChecker(links)
+
+	// Планировщик фоновых задач: интервальные запуски и ручной
+	// запуск со страницы /admin/jobs
+	scheduler := sched.NewScheduler(database)
+	scheduler.Register("analytics.rollup", "Пересборка дневного роллапа аналитики", 24*time.Hour,
+		func(ctx context.Context) (string, error) {
+			if err := analyticsService.RebuildRollups(ctx); err != nil {
+				return "", err
+			}
+			return "Роллап пересобран", nil
+		})
+	scheduler.Register("db.backup", "Резервная копия БД (VACUUM INTO)", 24*time.Hour,
+		func(ctx context.Context) (string, error) {
+			return backupDatabase(ctx, database, *dbPath, *backupDir)
+		})
+	scheduler.Register("links.check", "Проверка внешних ссылок уроков", *linkCheckInterval,
+		func(ctx context.Context) (string, error) {
+			report, err := links.Run(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Проверено %d, битых %d, починилось %d", report.Checked, report.Dead, report.Fixed), nil
+		})
+	scheduler.Register("storage.gc", "Удаление осиротевших артефактов отправок", 24*time.Hour,
+		func(ctx context.Context) (string, error) {
+			removed, err := storage.CollectGarbage(ctx, artifacts, database)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Удалено объектов: %d", removed), nil
+		})
+	scheduler.Register("search.codeindex", "Переиндексация блоков кода для поиска", 7*24*time.Hour,
+		func(ctx context.Context) (string, error) {
+			total, err := contentRepo.ReindexCodeBlocks(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Проиндексировано блоков: %d", total), nil
+		})
+	scheduler.Register("notify.digest", "Дайджест прогресса в мессенджеры", 7*24*time.Hour,
+		func(ctx context.Context) (string, error) {
+			stats, err := progressRepo.GetStats(ctx)
+			if err != nil {
+				return "", err
+			}
+			stale, err := progressRepo.GetStaleInProgress(ctx, progress.StaleReadingAfter)
+			if err != nil {
+				return "", err
+			}
+			milestones.Digest(stats.CompletedCount, stats.TotalLessons, stats.EarnedPoints, len(stale))
+			return "Дайджест отправлен", nil
+		})
+	if ragService != nil {
+		scheduler.Register("rag.reindex", "Переиндексация уроков для «спросить курс»", 7*24*time.Hour,
+			func(ctx context.Context) (string, error) {
+				total, err := ragService.Reindex(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Проиндексировано фрагментов: %d", total), nil
+			})
+	}
+	if translations != nil {
+		scheduler.Register("translate.lessons", "Машинный перевод уроков RU↔EN", 7*24*time.Hour,
+			func(ctx context.Context) (string, error) {
+				done, err := translations.TranslateAll(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Переведено частей уроков: %d", done), nil
+			})
+	}
+	// Шпаргалки по урокам: без LLM работает эвристика, с LLM текст
+	// сжимает модель
+	cheatsheets := cheatsheet.NewGenerator(contentRepo)
+	if cfg.LLM.Enabled() {
+		cheatsheets.SetLLM(llm.NewClient(cfg.LLM))
+	}
+	scheduler.Register("cheatsheet.generate", "Генерация шпаргалок по урокам", 7*24*time.Hour,
+		func(ctx context.Context) (string, error) {
+			updated, err := cheatsheets.GenerateAll(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Обновлено шпаргалок: %d", updated), nil
+		})
+	if *releaseURL != "" {
+		updates := selfupdate.NewChecker(*releaseURL)
+		server.SetUpdateChecker(updates)
+		scheduler.Register("update.check", "Проверка новых версий приложения", 24*time.Hour,
+			func(ctx context.Context) (string, error) {
+				return updates.Check(ctx)
+			})
+	}
+	scheduler.Start(context.Background())
+	server.SetScheduler(scheduler)
+	if *linkCheckInterval > 0 {
+		log.Printf("Проверка ссылок: каждые %s", *linkCheckInterval)
+	}
+
+	server.SetSnippets(snippets.NewRepository(database))
+	server.SetInterview(interview.NewRepository(database))
+	server.SetAudit(audit.NewLog(database))
+	server.SetSchemaVersion(schemaVersion)
+
+	// Вход через OAuth-провайдеры в публичном режиме (секция oauth конфигурации)
+	setupOAuth(server, cfg, *publicMode)
+	authRepo := auth.NewRepository(database)
+	server.SetIdentities(authRepo)
+	if *reset2FA {
+		if err := authRepo.DisableTOTP(context.Background()); err != nil {
+			log.Fatalf("Ошибка сброса 2FA: %v", err)
+		}
+		log.Printf("Двухфакторная аутентификация сброшена, настройте заново на /admin/security")
+	}
+	useTLS := (*tlsCert != "" && *tlsKey != "") || *autocertDomains != ""
+
+	handler := server.Router()
+	if useTLS {
+		// Публичный HTTPS-деплой — включаем HSTS
+		handler = hstsMiddleware(handler)
+	}
 
 	httpServer := &http.Server{
 		Addr:         *addr,
-		Handler:      server.Router(),
+		Handler:      handler,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Выбираем способ запуска: обычный HTTP, TLS с файлами или autocert
+	listenAndServe := httpServer.ListenAndServe
+	switch {
+	case *autocertDomains != "":
+		domains := strings.Split(*autocertDomains, ",")
+		for i := range domains {
+			domains[i] = strings.TrimSpace(domains[i])
+		}
+		listenAndServe = setupAutocert(httpServer, domains, *autocertCache, *httpAddr)
+		log.Printf("Autocert: %s (кэш: %s)", *autocertDomains, *autocertCache)
+
+	case *tlsCert != "" && *tlsKey != "":
+		go runHTTPRedirect(*httpAddr, nil)
+		listenAndServe = func() error { return httpServer.ListenAndServeTLS(*tlsCert, *tlsKey) }
+		log.Printf("TLS: сертификат %s", *tlsCert)
+	}
+
 	// Graceful shutdown
 	done := make(chan bool)
 	quit := make(chan os.Signal, 1)
@@ -80,11 +470,57 @@ func main() {
 		close(done)
 	}()
 
-	log.Printf("Сервер запущен: http://localhost%s", *addr)
-	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if useTLS {
+		log.Printf("Сервер запущен: https://localhost%s", *addr)
+	} else {
+		log.Printf("Сервер запущен: http://localhost%s", *addr)
+	}
+	if err := listenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Ошибка запуска сервера: %v", err)
 	}
 
 	<-done
 	log.Println("Сервер остановлен")
 }
+
+// hstsMiddleware добавляет заголовок Strict-Transport-Security для HTTPS-деплоя.
+func hstsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// runHTTPRedirect поднимает HTTP-листенер, который редиректит на HTTPS.
+// acmeHandler (если задан) перехватывает ACME-челленджи autocert.
+func runHTTPRedirect(addr string, acmeHandler http.Handler) {
+	redirect := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := "https://" + r.Host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+	})
+
+	var h http.Handler = redirect
+	if acmeHandler != nil {
+		h = acmeHandler
+	}
+
+	if err := http.ListenAndServe(addr, h); err != nil {
+		log.Printf("HTTP-редирект на %s не запущен: %v", addr, err)
+	}
+}
+
+// backupDatabase снимает консистентную копию SQLite через VACUUM INTO.
+func backupDatabase(ctx context.Context, database *sql.DB, dbPath, dir string) (string, error) {
+	if dir == "" {
+		dir = filepath.Dir(dbPath)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create backup dir: %w", err)
+	}
+
+	target := filepath.Join(dir, fmt.Sprintf("backup-%s.db", time.Now().Format("20060102-150405")))
+	if err := db.Backup(ctx, database, target); err != nil {
+		return "", err
+	}
+	return "Копия: " + target, nil
+}
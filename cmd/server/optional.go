@@ -0,0 +1,56 @@
+//go:build !minimal
+
+package main
+
+// Полная сборка: все опциональные подсистемы включены. Профиль minimal
+// (go build -tags minimal) исключает autocert, Redis и OAuth-вход —
+// остаётся один небольшой статический бинарник со SQLite для работы
+// на ноутбуке.
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"golearning/internal/config"
+	"golearning/internal/kv"
+	"golearning/internal/web"
+)
+
+const buildProfile = "full"
+
+// setupAutocert настраивает автоматические сертификаты Let's Encrypt
+// и возвращает функцию запуска HTTPS-листенера.
+func setupAutocert(httpServer *http.Server, domains []string, cacheDir, httpAddr string) func() error {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Cache:      autocert.DirCache(cacheDir),
+	}
+	httpServer.TLSConfig = m.TLSConfig()
+	// HTTP-листенер обслуживает ACME-челленджи и редиректит всё остальное на HTTPS
+	go runHTTPRedirect(httpAddr, m.HTTPHandler(nil))
+	return func() error { return httpServer.ListenAndServeTLS("", "") }
+}
+
+// setupRedis подключает Redis как общее kv-хранилище реплик.
+// Возвращает функцию закрытия соединения.
+func setupRedis(server *web.Server, addr string) func() {
+	redis := kv.NewRedis(addr)
+	if err := redis.Ping(context.Background()); err != nil {
+		log.Fatalf("Ошибка подключения к Redis: %v", err)
+	}
+	server.SetKV(redis)
+	log.Printf("Redis: %s", addr)
+	return func() { redis.Close() }
+}
+
+// setupOAuth включает вход через внешних OAuth-провайдеров.
+func setupOAuth(server *web.Server, cfg *config.Config, publicMode bool) {
+	server.SetOAuth(cfg.OAuth)
+	if publicMode && (cfg.OAuth.GitHub.ClientID != "" || cfg.OAuth.Google.ClientID != "") {
+		log.Printf("OAuth-вход: настроены внешние провайдеры")
+	}
+}
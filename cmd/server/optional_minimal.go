@@ -0,0 +1,34 @@
+//go:build minimal
+
+package main
+
+// Профиль minimal (go build -tags minimal): один небольшой статический
+// бинарник со SQLite для работы на ноутбуке. Autocert, Redis и
+// OAuth-вход исключены из сборки; соответствующие флаги приводят к
+// ошибке старта, а не к тихому игнорированию.
+
+import (
+	"log"
+	"net/http"
+
+	"golearning/internal/config"
+	"golearning/internal/web"
+)
+
+const buildProfile = "minimal"
+
+func setupAutocert(httpServer *http.Server, domains []string, cacheDir, httpAddr string) func() error {
+	log.Fatalf("Сборка minimal не включает autocert — используйте --tls-cert/--tls-key или соберите без -tags minimal")
+	return nil
+}
+
+func setupRedis(server *web.Server, addr string) func() {
+	log.Fatalf("Сборка minimal не включает Redis — соберите без -tags minimal")
+	return nil
+}
+
+func setupOAuth(server *web.Server, cfg *config.Config, publicMode bool) {
+	if cfg.OAuth.GitHub.ClientID != "" || cfg.OAuth.Google.ClientID != "" {
+		log.Printf("Сборка minimal не включает OAuth-вход — секция oauth конфигурации игнорируется")
+	}
+}
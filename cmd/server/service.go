@@ -0,0 +1,108 @@
+package main
+
+// Установка сервера как systemd-сервиса:
+//
+//	server install-service --db /var/lib/golearning/data.db --args "--public --access-key ..."
+//	server uninstall-service
+//
+// install-service пишет unit-файл с текущим бинарником, рабочей
+// директорией и политикой перезапуска и печатает команды systemctl
+// для включения. Сам systemctl не вызывается: установка видна и
+// обратима, а команда работает и без прав root (с --unit-path).
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// serviceUnitTemplate — unit-файл systemd. Подставляются пользователь,
+// рабочая директория и командная строка запуска.
+const serviceUnitTemplate = `[Unit]
+Description=Go Learning — веб-сервер курса
+After=network.target
+
+[Service]
+Type=simple
+User=%s
+WorkingDirectory=%s
+ExecStart=%s
+Restart=on-failure
+RestartSec=5
+NoNewPrivileges=true
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// runServiceCommand обрабатывает подкоманды install-service и
+// uninstall-service. Возвращает управление только через os.Exit.
+func runServiceCommand(command string, args []string) {
+	if runtime.GOOS != "linux" {
+		fmt.Fprintf(os.Stderr, "Команда %s поддерживает только Linux с systemd (сейчас: %s)\n", command, runtime.GOOS)
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	unitPath := fs.String("unit-path", "/etc/systemd/system/golearning.service", "Путь к unit-файлу systemd")
+	serviceUser := fs.String("user", "", "Пользователь сервиса (пусто — текущий)")
+	dbPath := fs.String("db", "./data.db", "Путь к файлу базы данных SQLite")
+	addr := fs.String("addr", ":8080", "Адрес для прослушивания")
+	extraArgs := fs.String("args", "", "Дополнительные флаги запуска (одной строкой)")
+	fs.Parse(args)
+
+	unitName := strings.TrimSuffix(filepath.Base(*unitPath), ".service")
+
+	if command == "uninstall-service" {
+		if err := os.Remove(*unitPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка удаления unit-файла: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Удалён %s\n\n", *unitPath)
+		fmt.Println("Остановите и перечитайте конфигурацию systemd:")
+		fmt.Printf("  sudo systemctl disable --now %s\n", unitName)
+		fmt.Println("  sudo systemctl daemon-reload")
+		return
+	}
+
+	if *serviceUser == "" {
+		current, err := user.Current()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка определения пользователя: %v (задайте --user)\n", err)
+			os.Exit(1)
+		}
+		*serviceUser = current.Username
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка определения пути бинарника: %v\n", err)
+		os.Exit(1)
+	}
+	absDB, err := filepath.Abs(*dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка определения пути БД: %v\n", err)
+		os.Exit(1)
+	}
+
+	execStart := fmt.Sprintf("%s --db %s --addr %s", executable, absDB, *addr)
+	if *extraArgs != "" {
+		execStart += " " + *extraArgs
+	}
+
+	unit := fmt.Sprintf(serviceUnitTemplate, *serviceUser, filepath.Dir(absDB), execStart)
+	if err := os.WriteFile(*unitPath, []byte(unit), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка записи unit-файла: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Записан %s\n\n", *unitPath)
+	fmt.Println(unit)
+	fmt.Println("Включите и запустите сервис:")
+	fmt.Println("  sudo systemctl daemon-reload")
+	fmt.Printf("  sudo systemctl enable --now %s\n", unitName)
+}
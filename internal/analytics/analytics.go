@@ -0,0 +1,184 @@
+// Package analytics — статистика обучения для админского дашборда.
+// Дневные показатели пишутся в предагрегированный роллап analytics_daily,
+// воронка и проходимость модулей считаются по сырым таблицам при запросе:
+// на single-user базе это дёшево, а роллап остаётся полезным как история
+// по дням, которую не восстановить из мутабельного прогресса.
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DayStat — показатели одного дня.
+type DayStat struct {
+	Day            string // YYYY-MM-DD
+	LessonsTouched int    // уроков с обновлением прогресса
+	Submissions    int    // отправок решений
+	Passes         int    // успешных отправок
+	TasksCompleted int    // впервые решённых заданий
+}
+
+// ModuleStat — проходимость заданий модуля.
+type ModuleStat struct {
+	ModuleID    int64
+	ModuleTitle string
+	Tasks       int // всего заданий
+	Attempted   int // заданий с хотя бы одной отправкой
+	Passed      int // решённых заданий
+	Submissions int // всего отправок
+}
+
+// PassRate — доля решённых заданий от имевших отправки.
+func (m ModuleStat) PassRate() float64 {
+	if m.Attempted == 0 {
+		return 0
+	}
+	return float64(m.Passed) / float64(m.Attempted)
+}
+
+// Funnel — воронка: открыл урок → попробовал задание → решил.
+type Funnel struct {
+	LessonsViewed    int // уроков открыто (прогресс не new)
+	LessonsAttempted int // уроков с отправками решений
+	LessonsPassed    int // уроков с решёнными заданиями
+}
+
+// Service считает статистику поверх общей БД.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService создаёт сервис аналитики.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// RebuildRollups пересобирает дневной роллап из сырых таблиц.
+// Идемпотентно: каждый день перезаписывается целиком.
+func (s *Service) RebuildRollups(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO analytics_daily (day, lessons_touched, submissions, passes, tasks_completed)
+		 SELECT day,
+		        SUM(lessons_touched),
+		        SUM(submissions),
+		        SUM(passes),
+		        SUM(tasks_completed)
+		 FROM (
+		     SELECT DATE(updated_at) AS day, COUNT(*) AS lessons_touched,
+		            0 AS submissions, 0 AS passes, 0 AS tasks_completed
+		     FROM progress GROUP BY DATE(updated_at)
+		     UNION ALL
+		     SELECT DATE(created_at), 0,
+		            COUNT(*),
+		            SUM(CASE WHEN status = 'success' THEN 1 ELSE 0 END),
+		            0
+		     FROM submissions GROUP BY DATE(created_at)
+		     UNION ALL
+		     SELECT DATE(completed_at), 0, 0, 0, COUNT(*)
+		     FROM task_completions GROUP BY DATE(completed_at)
+		 )
+		 WHERE day IS NOT NULL
+		 GROUP BY day
+		 ON CONFLICT(day) DO UPDATE SET
+		    lessons_touched = excluded.lessons_touched,
+		    submissions = excluded.submissions,
+		    passes = excluded.passes,
+		    tasks_completed = excluded.tasks_completed`,
+	)
+	if err != nil {
+		return fmt.Errorf("rebuild analytics rollups: %w", err)
+	}
+	return nil
+}
+
+// Daily возвращает показатели последних days дней (включая пустые — нулями).
+func (s *Service) Daily(ctx context.Context, days int) ([]DayStat, error) {
+	since := time.Now().AddDate(0, 0, -days+1).Format("2006-01-02")
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT day, lessons_touched, submissions, passes, tasks_completed
+		 FROM analytics_daily
+		 WHERE day >= ?
+		 ORDER BY day`,
+		since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("daily stats: %w", err)
+	}
+	defer rows.Close()
+
+	byDay := map[string]DayStat{}
+	for rows.Next() {
+		var d DayStat
+		if err := rows.Scan(&d.Day, &d.LessonsTouched, &d.Submissions, &d.Passes, &d.TasksCompleted); err != nil {
+			return nil, fmt.Errorf("scan day stat: %w", err)
+		}
+		byDay[d.Day] = d
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Разворачиваем в непрерывный ряд: дни без активности тоже видны
+	stats := make([]DayStat, 0, days)
+	for i := days - 1; i >= 0; i-- {
+		day := time.Now().AddDate(0, 0, -i).Format("2006-01-02")
+		d, ok := byDay[day]
+		if !ok {
+			d = DayStat{Day: day}
+		}
+		stats = append(stats, d)
+	}
+	return stats, nil
+}
+
+// Modules возвращает проходимость заданий по модулям.
+func (s *Service) Modules(ctx context.Context) ([]ModuleStat, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT m.id, m.title,
+		        COUNT(t.id),
+		        COUNT(DISTINCT sub.task_id),
+		        COUNT(DISTINCT tc.task_id),
+		        COALESCE(SUM((SELECT COUNT(*) FROM submissions WHERE task_id = t.id)), 0)
+		 FROM modules m
+		 JOIN lessons l ON l.module_id = m.id AND l.deleted_at IS NULL
+		 JOIN tasks t ON t.lesson_id = l.id
+		 LEFT JOIN (SELECT DISTINCT task_id FROM submissions) sub ON sub.task_id = t.id
+		 LEFT JOIN task_completions tc ON tc.task_id = t.id
+		 WHERE m.deleted_at IS NULL
+		 GROUP BY m.id, m.title
+		 ORDER BY m.order_index`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("module stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []ModuleStat
+	for rows.Next() {
+		var m ModuleStat
+		if err := rows.Scan(&m.ModuleID, &m.ModuleTitle, &m.Tasks, &m.Attempted, &m.Passed, &m.Submissions); err != nil {
+			return nil, fmt.Errorf("scan module stat: %w", err)
+		}
+		stats = append(stats, m)
+	}
+	return stats, rows.Err()
+}
+
+// LearningFunnel возвращает воронку урок → попытка → решение.
+func (s *Service) LearningFunnel(ctx context.Context) (*Funnel, error) {
+	f := &Funnel{}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT
+		    (SELECT COUNT(*) FROM progress WHERE status != 'new'),
+		    (SELECT COUNT(DISTINCT t.lesson_id)
+		     FROM submissions s JOIN tasks t ON t.id = s.task_id),
+		    (SELECT COUNT(DISTINCT lesson_id) FROM task_completions)`,
+	).Scan(&f.LessonsViewed, &f.LessonsAttempted, &f.LessonsPassed)
+	if err != nil {
+		return nil, fmt.Errorf("learning funnel: %w", err)
+	}
+	return f, nil
+}
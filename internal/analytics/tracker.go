@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// Виды событий обучения.
+const (
+	EventLessonViewed  = "lesson_viewed"
+	EventTaskAttempted = "task_attempted"
+	EventTaskPassed    = "task_passed"
+	EventHintUsed      = "hint_used"
+	EventNoteSaved     = "note_saved"
+)
+
+// Tracker пишет события обучения в append-only таблицу events.
+// В отличие от прогресса и отправок, события не перезаписываются,
+// поэтому по ним можно считать историю, рекомендации и стрики.
+type Tracker struct {
+	db *sql.DB
+}
+
+// NewTracker создаёт трекер событий.
+func NewTracker(db *sql.DB) *Tracker {
+	return &Tracker{db: db}
+}
+
+// Track записывает событие. Нулевые lesson_id и task_id сохраняются
+// как NULL. Сбой записи логируется, а не возвращается: телеметрия
+// не должна ломать обработку запроса.
+func (t *Tracker) Track(ctx context.Context, kind string, lessonID, taskID int64) {
+	_, err := t.db.ExecContext(ctx,
+		`INSERT INTO events (kind, lesson_id, task_id) VALUES (?, ?, ?)`,
+		kind, nullableID(lessonID), nullableID(taskID),
+	)
+	if err != nil {
+		log.Printf("Ошибка записи события %s: %v", kind, err)
+	}
+}
+
+// nullableID превращает нулевой идентификатор в NULL.
+func nullableID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// EventCount — число событий одного вида.
+type EventCount struct {
+	Kind  string
+	Count int
+}
+
+// EventCounts возвращает число событий каждого вида за последние days дней.
+func (s *Service) EventCounts(ctx context.Context, days int) ([]EventCount, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT kind, COUNT(*)
+		 FROM events
+		 WHERE created_at >= DATETIME('now', ?)
+		 GROUP BY kind
+		 ORDER BY COUNT(*) DESC`,
+		fmt.Sprintf("-%d days", days),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("event counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []EventCount
+	for rows.Next() {
+		var c EventCount
+		if err := rows.Scan(&c.Kind, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan event count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
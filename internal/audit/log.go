@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Entry — запись журнала административных действий.
+type Entry struct {
+	ID        int64
+	Actor     string // "admin" — из веб-интерфейса, "cli" — из консольных утилит
+	Action    string // например "trash.restore", "trash.purge", "content.edit"
+	Entity    string // тип сущности: "module", "lesson", "task"
+	EntityID  int64
+	Summary   string // краткое описание изменения
+	CreatedAt time.Time
+}
+
+// Log — append-only журнал административных действий.
+type Log struct {
+	db *sql.DB
+}
+
+// NewLog создаёт журнал поверх общей БД.
+func NewLog(db *sql.DB) *Log {
+	return &Log{db: db}
+}
+
+// Record добавляет запись в журнал.
+func (l *Log) Record(e *Entry) error {
+	result, err := l.db.Exec(
+		`INSERT INTO audit_log (actor, action, entity, entity_id, summary)
+		 VALUES (?, ?, ?, ?, ?)`,
+		e.Actor, e.Action, e.Entity, e.EntityID, e.Summary,
+	)
+	if err != nil {
+		return fmt.Errorf("record audit entry: %w", err)
+	}
+	e.ID, _ = result.LastInsertId()
+	return nil
+}
+
+// MustRecord добавляет запись, логируя ошибку вместо её возврата.
+// Журналирование не должно ломать само действие.
+func (l *Log) MustRecord(actor, action, entity string, entityID int64, summary string) {
+	err := l.Record(&Entry{
+		Actor:    actor,
+		Action:   action,
+		Entity:   entity,
+		EntityID: entityID,
+		Summary:  summary,
+	})
+	if err != nil {
+		log.Printf("Ошибка записи в журнал аудита: %v", err)
+	}
+}
+
+// List возвращает записи журнала, свежие первыми.
+// Пустые action/entity означают «без фильтра».
+func (l *Log) List(action, entity string, limit int) ([]Entry, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := l.db.Query(
+		`SELECT id, actor, action, entity, COALESCE(entity_id, 0), summary, created_at
+		 FROM audit_log
+		 WHERE (? = '' OR action = ?) AND (? = '' OR entity = ?)
+		 ORDER BY id DESC
+		 LIMIT ?`,
+		action, action, entity, entity, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Action, &e.Entity, &e.EntityID, &e.Summary, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// Actions возвращает список встречающихся в журнале действий (для фильтра).
+func (l *Log) Actions() ([]string, error) {
+	rows, err := l.db.Query(`SELECT DISTINCT action FROM audit_log ORDER BY action`)
+	if err != nil {
+		return nil, fmt.Errorf("list audit actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []string
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			return nil, fmt.Errorf("scan audit action: %w", err)
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, rows.Err()
+}
@@ -0,0 +1,203 @@
+// Package auth — внешние OAuth-аккаунты: кто входил через GitHub или
+// Google и когда. Сами сессии живут в kv-хранилище и таблиц не требуют.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Identity — привязанный внешний аккаунт.
+type Identity struct {
+	ID          int64
+	Provider    string // github или google
+	ProviderID  string // идентификатор пользователя у провайдера
+	Login       string
+	Name        string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// Repository — репозиторий внешних аккаунтов.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создаёт новый репозиторий.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Upsert сохраняет аккаунт по паре (provider, provider_id) и сообщает,
+// первый ли это вход: true — создана новая запись, false — обновлены
+// профиль и время последнего входа существующей.
+func (r *Repository) Upsert(ctx context.Context, ident *Identity) (bool, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id FROM oauth_identities WHERE provider = ? AND provider_id = ?`,
+		ident.Provider, ident.ProviderID,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		result, err := r.db.ExecContext(ctx,
+			`INSERT INTO oauth_identities (provider, provider_id, login, name, email)
+			 VALUES (?, ?, ?, ?, ?)`,
+			ident.Provider, ident.ProviderID, ident.Login, ident.Name, ident.Email,
+		)
+		if err != nil {
+			return false, fmt.Errorf("insert identity: %w", err)
+		}
+		if id, err := result.LastInsertId(); err == nil {
+			ident.ID = id
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("find identity: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`UPDATE oauth_identities
+		 SET login = ?, name = ?, email = ?, last_login_at = CURRENT_TIMESTAMP
+		 WHERE id = ?`,
+		ident.Login, ident.Name, ident.Email, id,
+	)
+	if err != nil {
+		return false, fmt.Errorf("update identity: %w", err)
+	}
+	ident.ID = id
+	return false, nil
+}
+
+// List возвращает привязанные аккаунты, недавно входившие — первыми.
+func (r *Repository) List(ctx context.Context) ([]Identity, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, provider, provider_id, login, name, email, created_at, last_login_at
+		 FROM oauth_identities
+		 ORDER BY last_login_at DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []Identity
+	for rows.Next() {
+		var ident Identity
+		if err := rows.Scan(&ident.ID, &ident.Provider, &ident.ProviderID,
+			&ident.Login, &ident.Name, &ident.Email, &ident.CreatedAt, &ident.LastLoginAt); err != nil {
+			return nil, fmt.Errorf("scan identity: %w", err)
+		}
+		identities = append(identities, ident)
+	}
+	return identities, rows.Err()
+}
+
+// --- Состояние двухфакторной аутентификации ---
+
+// Ключи настроек 2FA в таблице settings.
+const (
+	settingTOTPSecret  = "auth.totp_secret"
+	settingTOTPBackup  = "auth.totp_backup"
+	settingTOTPEnforce = "auth.totp_enforce"
+)
+
+// getSetting возвращает значение настройки или пустую строку.
+func (r *Repository) getSetting(ctx context.Context, key string) (string, error) {
+	var value string
+	err := r.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get setting %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// setSetting сохраняет значение настройки.
+func (r *Repository) setSetting(ctx context.Context, key, value string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO settings (key, value, updated_at)
+		 VALUES (?, ?, CURRENT_TIMESTAMP)
+		 ON CONFLICT(key) DO UPDATE SET
+		   value = excluded.value,
+		   updated_at = CURRENT_TIMESTAMP`,
+		key, value,
+	)
+	if err != nil {
+		return fmt.Errorf("set setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// TOTPSecret возвращает активный TOTP-секрет; пустая строка — 2FA выключена.
+func (r *Repository) TOTPSecret(ctx context.Context) (string, error) {
+	return r.getSetting(ctx, settingTOTPSecret)
+}
+
+// EnableTOTP включает 2FA: сохраняет секрет и хэши резервных кодов.
+func (r *Repository) EnableTOTP(ctx context.Context, secret string, backupHashes []string) error {
+	if err := r.setSetting(ctx, settingTOTPSecret, secret); err != nil {
+		return err
+	}
+	return r.setSetting(ctx, settingTOTPBackup, strings.Join(backupHashes, ","))
+}
+
+// DisableTOTP выключает 2FA и удаляет резервные коды.
+func (r *Repository) DisableTOTP(ctx context.Context) error {
+	if err := r.setSetting(ctx, settingTOTPSecret, ""); err != nil {
+		return err
+	}
+	if err := r.setSetting(ctx, settingTOTPBackup, ""); err != nil {
+		return err
+	}
+	return r.setSetting(ctx, settingTOTPEnforce, "")
+}
+
+// TOTPEnforced сообщает, требуется ли код 2FA при входе по админ-ключу.
+func (r *Repository) TOTPEnforced(ctx context.Context) (bool, error) {
+	value, err := r.getSetting(ctx, settingTOTPEnforce)
+	return value == "1", err
+}
+
+// SetTOTPEnforced включает или выключает требование 2FA для админ-ключа.
+func (r *Repository) SetTOTPEnforced(ctx context.Context, enforced bool) error {
+	value := ""
+	if enforced {
+		value = "1"
+	}
+	return r.setSetting(ctx, settingTOTPEnforce, value)
+}
+
+// BackupCodesLeft возвращает число неиспользованных резервных кодов.
+func (r *Repository) BackupCodesLeft(ctx context.Context) (int, error) {
+	value, err := r.getSetting(ctx, settingTOTPBackup)
+	if err != nil || value == "" {
+		return 0, err
+	}
+	return len(strings.Split(value, ",")), nil
+}
+
+// UseBackupCode проверяет резервный код и, если он верен, гасит его:
+// каждый код одноразовый. Это путь восстановления при потере телефона.
+func (r *Repository) UseBackupCode(ctx context.Context, code string) (bool, error) {
+	value, err := r.getSetting(ctx, settingTOTPBackup)
+	if err != nil || value == "" {
+		return false, err
+	}
+	hash := HashBackupCode(code)
+	hashes := strings.Split(value, ",")
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			remaining := append(hashes[:i:i], hashes[i+1:]...)
+			return true, r.setSetting(ctx, settingTOTPBackup, strings.Join(remaining, ","))
+		}
+	}
+	return false, nil
+}
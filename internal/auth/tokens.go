@@ -0,0 +1,132 @@
+package auth
+
+// API-токены для REST API: создаются админом, показываются один раз,
+// хранятся хэшированными. На каждый запрос обновляются счётчик и время
+// последнего использования — метрики видны на странице управления.
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// APIToken — токен доступа к REST API.
+type APIToken struct {
+	ID              int64
+	Name            string
+	RateLimitPerMin int // 0 — действует только общий лимит сервера
+	RequestCount    int64
+	LastUsedAt      sql.NullTime
+	CreatedAt       time.Time
+}
+
+// HashToken возвращает hex-хэш SHA-256 токена.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateToken создаёт токен и возвращает его открытым текстом —
+// единственный раз, дальше хранится только хэш.
+func (r *Repository) CreateToken(ctx context.Context, name string, rateLimitPerMin int) (string, *APIToken, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, fmt.Errorf("generate token: %w", err)
+	}
+	plain := hex.EncodeToString(buf)
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO api_tokens (name, token_hash, rate_limit_per_min) VALUES (?, ?, ?)`,
+		name, HashToken(plain), rateLimitPerMin,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("insert token: %w", err)
+	}
+	token := &APIToken{Name: name, RateLimitPerMin: rateLimitPerMin, CreatedAt: time.Now()}
+	if id, err := result.LastInsertId(); err == nil {
+		token.ID = id
+	}
+	return plain, token, nil
+}
+
+// FindTokenByHash возвращает токен по хэшу или (nil, nil), если его нет.
+func (r *Repository) FindTokenByHash(ctx context.Context, hash string) (*APIToken, error) {
+	var token APIToken
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, rate_limit_per_min, request_count, last_used_at, created_at
+		 FROM api_tokens WHERE token_hash = ?`,
+		hash,
+	).Scan(&token.ID, &token.Name, &token.RateLimitPerMin, &token.RequestCount,
+		&token.LastUsedAt, &token.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find token: %w", err)
+	}
+	return &token, nil
+}
+
+// ListTokens возвращает токены, новые первыми.
+func (r *Repository) ListTokens(ctx context.Context) ([]APIToken, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, name, rate_limit_per_min, request_count, last_used_at, created_at
+		 FROM api_tokens ORDER BY created_at DESC, id DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []APIToken
+	for rows.Next() {
+		var token APIToken
+		if err := rows.Scan(&token.ID, &token.Name, &token.RateLimitPerMin,
+			&token.RequestCount, &token.LastUsedAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// TouchToken учитывает запрос: счётчик и время последнего использования.
+func (r *Repository) TouchToken(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE api_tokens
+		 SET request_count = request_count + 1, last_used_at = CURRENT_TIMESTAMP
+		 WHERE id = ?`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("touch token: %w", err)
+	}
+	return nil
+}
+
+// SetTokenLimit задаёт персональный rate-лимит токена (0 — снять).
+func (r *Repository) SetTokenLimit(ctx context.Context, id int64, rateLimitPerMin int) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE api_tokens SET rate_limit_per_min = ? WHERE id = ?`,
+		rateLimitPerMin, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set token limit: %w", err)
+	}
+	return nil
+}
+
+// DeleteToken отзывает токен. Отзыв действует сразу: токен проверяется
+// по таблице на каждом запросе.
+func (r *Repository) DeleteToken(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM api_tokens WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete token: %w", err)
+	}
+	return nil
+}
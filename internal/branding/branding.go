@@ -0,0 +1,156 @@
+// Package branding — white-label-настройки инстанса с хранением в БД
+// (таблица settings, ключи brand.*). Название сайта, логотип, подвал,
+// основной цвет и тексты главной страницы редактируются через админку
+// и подставляются во все шаблоны — учреждение брендирует свой
+// инстанс без форка.
+package branding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Branding — текущие настройки брендирования. Пустые поля означают
+// значения по умолчанию (вид инстанса не меняется).
+type Branding struct {
+	// SiteName — название сайта в шапке, подвале и заголовках страниц.
+	SiteName string
+	// LogoEmoji — эмодзи-логотип в шапке.
+	LogoEmoji string
+	// Tagline — строка подвала.
+	Tagline string
+	// PrimaryColor — основной цвет интерфейса (CSS-значение, например #6866d9).
+	PrimaryColor string
+	// HeroTitle и HeroSubtitle — тексты главной страницы.
+	HeroTitle    string
+	HeroSubtitle string
+	// FooterLinksRaw — ссылки подвала, по строке на ссылку в формате
+	// "Текст|URL" (хранится как есть для формы редактирования).
+	FooterLinksRaw string
+}
+
+// FooterLink — разобранная ссылка подвала.
+type FooterLink struct {
+	Title string
+	URL   string
+}
+
+// FooterLinks разбирает FooterLinksRaw. Строки без "|" пропускаются.
+func (b Branding) FooterLinks() []FooterLink {
+	var links []FooterLink
+	for _, line := range strings.Split(b.FooterLinksRaw, "\n") {
+		title, url, ok := strings.Cut(strings.TrimSpace(line), "|")
+		if !ok || title == "" || url == "" {
+			continue
+		}
+		links = append(links, FooterLink{Title: strings.TrimSpace(title), URL: strings.TrimSpace(url)})
+	}
+	return links
+}
+
+// defaults — значения по умолчанию: текущий вид Go Learning.
+var defaults = Branding{
+	SiteName:     "Go Learning",
+	LogoEmoji:    "🐹",
+	Tagline:      "Go Learning — учись программировать на Go 💙",
+	HeroTitle:    "Изучай Go",
+	HeroSubtitle: "Структурированные уроки с теорией, примерами и практикой",
+}
+
+// keys — соответствие полей ключам settings.
+var keys = map[string]func(*Branding) *string{
+	"brand.site_name":     func(b *Branding) *string { return &b.SiteName },
+	"brand.logo_emoji":    func(b *Branding) *string { return &b.LogoEmoji },
+	"brand.tagline":       func(b *Branding) *string { return &b.Tagline },
+	"brand.primary_color": func(b *Branding) *string { return &b.PrimaryColor },
+	"brand.hero_title":    func(b *Branding) *string { return &b.HeroTitle },
+	"brand.hero_subtitle": func(b *Branding) *string { return &b.HeroSubtitle },
+	"brand.footer_links":  func(b *Branding) *string { return &b.FooterLinksRaw },
+}
+
+// Default возвращает настройки по умолчанию. Используется, когда
+// сервис брендирования не подключён.
+func Default() Branding {
+	return defaults
+}
+
+// cacheTTL — как долго настройки живут в памяти: читаются на каждый
+// запрос, меняются редко.
+const cacheTTL = 5 * time.Second
+
+// Service читает и сохраняет настройки брендирования.
+type Service struct {
+	db *sql.DB
+
+	mu       sync.Mutex
+	cache    *Branding
+	cachedAt time.Time
+}
+
+// NewService создаёт сервис брендирования.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// Current возвращает настройки с коротким кэшем. При ошибке чтения БД
+// возвращаются значения по умолчанию.
+func (s *Service) Current(ctx context.Context) Branding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache != nil && time.Since(s.cachedAt) < cacheTTL {
+		return *s.cache
+	}
+
+	b := defaults
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT key, value FROM settings WHERE key LIKE 'brand.%'`)
+	if err != nil {
+		return b
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			continue
+		}
+		if field, ok := keys[key]; ok && value != "" {
+			*field(&b) = value
+		}
+	}
+
+	s.cache = &b
+	s.cachedAt = time.Now()
+	return b
+}
+
+// Save сохраняет настройки. Пустое значение удаляет ключ — поле
+// возвращается к значению по умолчанию.
+func (s *Service) Save(ctx context.Context, b Branding) error {
+	for key, field := range keys {
+		value := strings.TrimSpace(*field(&b))
+		var err error
+		if value == "" {
+			_, err = s.db.ExecContext(ctx, `DELETE FROM settings WHERE key = ?`, key)
+		} else {
+			_, err = s.db.ExecContext(ctx,
+				`INSERT INTO settings (key, value) VALUES (?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP`,
+				key, value,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("save branding %s: %w", key, err)
+		}
+	}
+
+	s.mu.Lock()
+	s.cache = nil // сбрасываем кэш, чтобы изменения подействовали сразу
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,201 @@
+// Package cheatsheet — генератор шпаргалок: сжимает каждый урок в
+// одностраничную карточку и сохраняет её секцией kind=cheatsheet.
+// Базовый путь — эвристика по готовым секциям урока (конспект из
+// обзора, код из синтаксиса и примеров, подводные камни); с настроенным
+// LLM текст сжимает модель, эвристика остаётся запасным вариантом.
+package cheatsheet
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"golearning/internal/content"
+	"golearning/internal/llm"
+)
+
+// SectionTitle — заголовок секции-шпаргалки в уроке.
+const SectionTitle = "Шпаргалка"
+
+// sectionOrderIndex — шпаргалка идёт после остальных секций урока.
+const sectionOrderIndex = 99
+
+// Пределы эвристики: шпаргалка — одна страница, а не пересказ урока.
+const (
+	maxSummaryChars  = 300
+	maxSyntaxBlocks  = 2
+	maxExampleBlocks = 1
+	maxPitfalls      = 5
+	maxLLMInputChars = 6000
+)
+
+// Generator собирает шпаргалки по урокам.
+type Generator struct {
+	contentRepo content.Store
+	llm         *llm.Client
+}
+
+// NewGenerator создаёт генератор шпаргалок.
+func NewGenerator(contentRepo content.Store) *Generator {
+	return &Generator{contentRepo: contentRepo}
+}
+
+// SetLLM подключает языковую модель: шпаргалку сжимает она, эвристика
+// остаётся запасным путём при ошибках.
+func (g *Generator) SetLLM(c *llm.Client) {
+	g.llm = c
+}
+
+// GenerateAll перегенерирует шпаргалки всех уроков. Возвращает число
+// обновлённых; уроки, по которым собрать нечего, пропускаются.
+func (g *Generator) GenerateAll(ctx context.Context) (int, error) {
+	lessons, err := g.contentRepo.ListAllLessons(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("чтение уроков: %w", err)
+	}
+
+	updated := 0
+	for _, l := range lessons {
+		sections, err := g.contentRepo.GetSectionsByLessonID(ctx, l.ID)
+		if err != nil {
+			return updated, fmt.Errorf("секции урока %s: %w", l.Slug, err)
+		}
+
+		bodyMD := g.generate(ctx, &l, sections)
+		if bodyMD == "" {
+			continue
+		}
+
+		section := &content.Section{
+			LessonID:   l.ID,
+			Kind:       content.SectionCheatsheet,
+			Title:      SectionTitle,
+			BodyMD:     bodyMD,
+			OrderIndex: sectionOrderIndex,
+		}
+		if err := g.contentRepo.ReplaceSectionByKind(ctx, section); err != nil {
+			return updated, fmt.Errorf("сохранение шпаргалки %s: %w", l.Slug, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// generate строит Markdown шпаргалки. Пустая строка — собрать нечего.
+func (g *Generator) generate(ctx context.Context, lesson *content.Lesson, sections []content.Section) string {
+	if g.llm != nil {
+		if md, err := g.generateLLM(ctx, lesson, sections); err == nil && md != "" {
+			return md
+		} else if err != nil {
+			log.Printf("Шпаргалка %s: LLM недоступен, используем эвристику: %v", lesson.Slug, err)
+		}
+	}
+	return ruleBased(sections)
+}
+
+// generateLLM просит модель сжать урок в одностраничную шпаргалку.
+func (g *Generator) generateLLM(ctx context.Context, lesson *content.Lesson, sections []content.Section) (string, error) {
+	var input strings.Builder
+	fmt.Fprintf(&input, "Урок «%s».\n\n", lesson.Title)
+	for _, s := range sections {
+		if s.Kind == content.SectionCheatsheet || s.Kind == content.SectionLinks {
+			continue
+		}
+		fmt.Fprintf(&input, "## %s\n%s\n\n", s.Title, s.BodyMD)
+		if input.Len() > maxLLMInputChars {
+			break
+		}
+	}
+	if input.Len() == 0 {
+		return "", nil
+	}
+
+	system := "Ты составляешь шпаргалку по уроку учебного курса Go. Сожми материал в одну страницу Markdown: " +
+		"короткие пункты, минимум прозы, 1–2 блока кода с самым характерным синтаксисом. " +
+		"Не добавляй ничего, чего нет в уроке, и не пиши заголовок урока — только содержимое шпаргалки."
+
+	md, err := g.llm.Complete(ctx, system, input.String())
+	return strings.TrimSpace(md), err
+}
+
+// ruleBased собирает шпаргалку без LLM: конспект из обзора, код из
+// секций синтаксиса и примеров, маркированные подводные камни.
+func ruleBased(sections []content.Section) string {
+	var out strings.Builder
+
+	for _, s := range sections {
+		switch s.Kind {
+		case content.SectionOverview:
+			if summary := firstParagraph(s.BodyMD); summary != "" {
+				out.WriteString(summary + "\n\n")
+			}
+		case content.SectionSyntax:
+			writeCodeBlocks(&out, "Синтаксис", s.BodyMD, maxSyntaxBlocks)
+		case content.SectionExamples:
+			writeCodeBlocks(&out, "Пример", s.BodyMD, maxExampleBlocks)
+		case content.SectionPitfalls:
+			if bullets := bulletLines(s.BodyMD, maxPitfalls); len(bullets) > 0 {
+				out.WriteString("## Подводные камни\n\n")
+				for _, b := range bullets {
+					out.WriteString(b + "\n")
+				}
+				out.WriteString("\n")
+			}
+		}
+	}
+
+	return strings.TrimSpace(out.String())
+}
+
+// writeCodeBlocks добавляет в шпаргалку до limit блоков кода секции.
+func writeCodeBlocks(out *strings.Builder, heading, bodyMD string, limit int) {
+	blocks := content.ExtractCodeBlocks(bodyMD)
+	if len(blocks) == 0 {
+		return
+	}
+	if len(blocks) > limit {
+		blocks = blocks[:limit]
+	}
+	fmt.Fprintf(out, "## %s\n\n", heading)
+	for _, b := range blocks {
+		fmt.Fprintf(out, "```%s\n%s\n```\n\n", b.Language, b.Code)
+	}
+}
+
+// firstParagraph возвращает первый абзац обычного текста, обрезанный
+// по границе предложения до maxSummaryChars.
+func firstParagraph(bodyMD string) string {
+	for _, para := range strings.Split(bodyMD, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" || strings.HasPrefix(para, "#") || strings.HasPrefix(para, "```") {
+			continue
+		}
+		para = strings.Join(strings.Fields(para), " ")
+		runes := []rune(para)
+		if len(runes) <= maxSummaryChars {
+			return para
+		}
+		head := string(runes[:maxSummaryChars])
+		if cut := strings.LastIndex(head, ". "); cut > 0 {
+			return head[:cut+1]
+		}
+		return head + "…"
+	}
+	return ""
+}
+
+// bulletLines возвращает до limit маркированных строк секции.
+func bulletLines(bodyMD string, limit int) []string {
+	var bullets []string
+	for _, line := range strings.Split(bodyMD, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
+			bullets = append(bullets, "- "+strings.TrimSpace(trimmed[2:]))
+			if len(bullets) == limit {
+				break
+			}
+		}
+	}
+	return bullets
+}
@@ -0,0 +1,104 @@
+// Package config — файловая конфигурация. Параметры процесса задаются
+// флагами командной строки; в файл выносятся структурные настройки
+// вроде хранилища артефактов, которые неудобно выражать флагами.
+// Пустой путь означает значения по умолчанию.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// S3 — доступ к S3-совместимому хранилищу (AWS, MinIO и другие).
+type S3 struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// Storage — хранилище крупных артефактов.
+type Storage struct {
+	Kind string `json:"kind"` // local или s3
+	Dir  string `json:"dir"`  // каталог для kind=local
+	S3   S3     `json:"s3"`
+}
+
+// OAuthProvider — регистрация OAuth2-приложения у провайдера входа.
+type OAuthProvider struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// OAuth — вход через внешние аккаунты в публичном режиме сервера.
+// Провайдер включается, когда заполнен его client_id.
+type OAuth struct {
+	GitHub OAuthProvider `json:"github"`
+	Google OAuthProvider `json:"google"`
+}
+
+// LLM — доступ к OpenAI-совместимому API для функций на основе
+// языковой модели («спросить курс»). Функции включаются, когда
+// заполнены endpoint и model.
+type LLM struct {
+	Endpoint       string `json:"endpoint"` // базовый URL, например https://api.openai.com/v1
+	APIKey         string `json:"api_key"`
+	Model          string `json:"model"`           // модель для ответов
+	EmbeddingModel string `json:"embedding_model"` // модель эмбеддингов для поиска по урокам
+}
+
+// Enabled сообщает, настроен ли доступ к LLM.
+func (l LLM) Enabled() bool {
+	return l.Endpoint != "" && l.Model != ""
+}
+
+// TTS — синтез речи для озвучки уроков через OpenAI-совместимый API.
+// Озвучка включается, когда заполнены endpoint и model.
+type TTS struct {
+	Endpoint string `json:"endpoint"` // базовый URL, например https://api.openai.com/v1
+	APIKey   string `json:"api_key"`
+	Model    string `json:"model"`
+	Voice    string `json:"voice"` // голос синтеза, например alloy
+}
+
+// Enabled сообщает, настроен ли синтез речи.
+func (t TTS) Enabled() bool {
+	return t.Endpoint != "" && t.Model != ""
+}
+
+// Config — конфигурация приложения.
+type Config struct {
+	Storage Storage `json:"storage"`
+	OAuth   OAuth   `json:"oauth"`
+	LLM     LLM     `json:"llm"`
+	TTS     TTS     `json:"tts"`
+}
+
+// Default возвращает конфигурацию по умолчанию: локальное хранилище
+// артефактов рядом с базой.
+func Default() *Config {
+	return &Config{
+		Storage: Storage{Kind: "local", Dir: "./artifacts"},
+	}
+}
+
+// Load читает JSON-файл конфигурации, накладывая его на значения
+// по умолчанию. Пустой путь — не ошибка, отсутствующий файл — ошибка:
+// раз путь указан явно, молча игнорировать его нельзя.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
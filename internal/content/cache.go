@@ -0,0 +1,123 @@
+package content
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL — время жизни записей кэша контента.
+// Контент меняется редко (только при импорте), поэтому 5 минут — безопасный компромисс.
+const defaultCacheTTL = 5 * time.Minute
+
+// cacheEntry — запись кэша со временем истечения.
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// generationProbeInterval — как часто кэш сверяет поколение контента с БД.
+// Чаще не нужно: это лишний запрос на каждое чтение.
+const generationProbeInterval = time.Second
+
+// memoryCache — простой in-memory кэш с TTL.
+// Используется репозиторием контента: уроки читаются на каждой странице,
+// но обновляются только при импорте.
+//
+// Для работы нескольких реплик за балансировщиком кэш дополнительно
+// сверяет «поколение» контента в БД (ключ content_generation в settings):
+// запись на одной реплике повышает поколение, остальные сбрасывают кэш
+// при следующем чтении, не дожидаясь истечения TTL.
+type memoryCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+
+	// probe читает текущее поколение контента из БД, onFlush повышает его.
+	// Оба опциональны (nil — одиночный процесс, ведём себя как раньше).
+	probe   func() (int64, error)
+	onFlush func()
+
+	generation int64
+	lastProbe  time.Time
+}
+
+// newMemoryCache создаёт новый кэш с указанным TTL.
+func newMemoryCache(ttl time.Duration) *memoryCache {
+	return &memoryCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get возвращает значение по ключу, если оно есть и не истекло.
+func (c *memoryCache) get(key string) (interface{}, bool) {
+	c.checkGeneration()
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		// Запись истекла — удаляем лениво
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set сохраняет значение по ключу.
+func (c *memoryCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+}
+
+// flush полностью очищает кэш.
+// Вызывается при любой записи в контент: проще и надёжнее,
+// чем точечная инвалидация связанных ключей (урок + списки + курс).
+func (c *memoryCache) flush() {
+	c.mu.Lock()
+	c.entries = make(map[string]cacheEntry)
+	c.mu.Unlock()
+
+	if c.onFlush != nil {
+		c.onFlush()
+	}
+}
+
+// checkGeneration сбрасывает кэш, если другая реплика изменила контент.
+// Поколение проверяется не чаще generationProbeInterval.
+func (c *memoryCache) checkGeneration() {
+	if c.probe == nil {
+		return
+	}
+
+	c.mu.Lock()
+	if time.Since(c.lastProbe) < generationProbeInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.lastProbe = time.Now()
+	known := c.generation
+	c.mu.Unlock()
+
+	gen, err := c.probe()
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	if gen != known {
+		c.generation = gen
+		c.entries = make(map[string]cacheEntry)
+	}
+	c.mu.Unlock()
+}
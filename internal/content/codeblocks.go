@@ -0,0 +1,293 @@
+package content
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// CodeBlock — блок кода, извлечённый из секции урока. Блоки индексируются
+// отдельно от текста, чтобы поиск по коду не тонул в прозе.
+type CodeBlock struct {
+	ID        int64
+	LessonID  int64
+	SectionID int64
+	Language  string
+	Code      string
+}
+
+// CodeSearchResult — результат поиска по блокам кода.
+type CodeSearchResult struct {
+	LessonID int64
+	Slug     string
+	Title    string
+	Language string
+	Snippet  string
+	Rank     float64
+}
+
+// ExtractCodeBlocks находит в Markdown ограждённые блоки кода
+// (```язык ... ```). Язык берётся из строки открывающего ограждения.
+func ExtractCodeBlocks(bodyMD string) []CodeBlock {
+	var blocks []CodeBlock
+	var current []string
+	language := ""
+	inside := false
+
+	for _, line := range strings.Split(bodyMD, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			if inside {
+				code := strings.TrimRight(strings.Join(current, "\n"), "\n")
+				if code != "" {
+					blocks = append(blocks, CodeBlock{Language: language, Code: code})
+				}
+				current = current[:0]
+				inside = false
+				continue
+			}
+			language = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, "```")))
+			inside = true
+			continue
+		}
+		if inside {
+			current = append(current, line)
+		}
+	}
+
+	return blocks
+}
+
+// ftsPhraseQuery приводит пользовательский запрос к безопасному
+// запросу FTS5: точки и скобки ломают синтаксис, поэтому каждый терм
+// берётся в кавычки и ищется как фраза.
+func ftsPhraseQuery(query string) string {
+	terms := strings.Fields(query)
+	quoted := make([]string, 0, len(terms))
+	for _, t := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(t, `"`, `""`)+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// insertSectionCode индексирует блоки кода одной секции.
+func (r *Repository) insertSectionCode(ctx context.Context, sectionID, lessonID int64, bodyMD string) error {
+	for _, b := range ExtractCodeBlocks(bodyMD) {
+		_, err := r.db.ExecContext(ctx,
+			`INSERT INTO code_blocks (lesson_id, section_id, language, code) VALUES (?, ?, ?, ?)`,
+			lessonID, sectionID, b.Language, b.Code,
+		)
+		if err != nil {
+			return fmt.Errorf("insert code block: %w", err)
+		}
+	}
+	return nil
+}
+
+// reindexLessonCode перестраивает индекс блоков кода урока по его
+// текущим секциям.
+func (r *Repository) reindexLessonCode(ctx context.Context, lessonID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM code_blocks WHERE lesson_id = ?`, lessonID); err != nil {
+		return fmt.Errorf("delete code blocks: %w", err)
+	}
+
+	sections, err := r.GetSectionsByLessonID(ctx, lessonID)
+	if err != nil {
+		return err
+	}
+	for _, s := range sections {
+		if err := r.insertSectionCode(ctx, s.ID, lessonID, s.BodyMD); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReindexCodeBlocks перестраивает индекс блоков кода по всем урокам.
+// Используется фоновой задачей для контента, импортированного до
+// появления индекса. Возвращает количество проиндексированных блоков.
+func (r *Repository) ReindexCodeBlocks(ctx context.Context) (int, error) {
+	lessons, err := r.ListAllLessons(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, l := range lessons {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if err := r.reindexLessonCode(ctx, l.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM code_blocks`).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count code blocks: %w", err)
+	}
+	return total, nil
+}
+
+// ExampleBlock — пример кода для внешних клиентов: блок целиком плюс
+// контекст, откуда он взят (урок и секция).
+type ExampleBlock struct {
+	ID           int64
+	LessonID     int64
+	ModuleID     int64
+	LessonSlug   string
+	LessonTitle  string
+	SectionID    int64
+	SectionTitle string
+	Language     string
+	Code         string
+	Rank         float64
+}
+
+// SearchExamples ищет блоки кода по теме и возвращает их целиком
+// с контекстом. language сужает выборку до одного языка, пустая
+// строка — любой язык.
+func (r *Repository) SearchExamples(ctx context.Context, topic, language string, limit int) ([]ExampleBlock, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	match := ftsPhraseQuery(topic)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT l.id, l.module_id, l.slug, l.title, s.id, s.title, cb.language, cb.code,
+		        bm25(code_blocks_fts) as rank
+		 FROM code_blocks_fts
+		 JOIN code_blocks cb ON cb.id = code_blocks_fts.rowid
+		 JOIN lessons l ON l.id = cb.lesson_id
+		 JOIN lesson_sections s ON s.id = cb.section_id
+		 WHERE code_blocks_fts MATCH ? AND l.deleted_at IS NULL
+		   AND (? = '' OR cb.language = ?)
+		 ORDER BY rank
+		 LIMIT ?`,
+		match, language, language, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search examples: %w", err)
+	}
+	defer rows.Close()
+
+	var examples []ExampleBlock
+	for rows.Next() {
+		var e ExampleBlock
+		if err := rows.Scan(&e.LessonID, &e.ModuleID, &e.LessonSlug, &e.LessonTitle,
+			&e.SectionID, &e.SectionTitle, &e.Language, &e.Code, &e.Rank); err != nil {
+			return nil, fmt.Errorf("scan example: %w", err)
+		}
+		examples = append(examples, e)
+	}
+
+	return examples, rows.Err()
+}
+
+// ListExamples возвращает все блоки кода с контекстом в порядке
+// прохождения курса. language сужает выборку до одного языка, пустая
+// строка — любой язык; maxChars отсекает слишком длинные блоки
+// (0 — без ограничения).
+func (r *Repository) ListExamples(ctx context.Context, language string, maxChars int) ([]ExampleBlock, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT cb.id, l.id, l.module_id, l.slug, l.title, s.id, s.title, cb.language, cb.code
+		 FROM code_blocks cb
+		 JOIN lessons l ON l.id = cb.lesson_id
+		 JOIN lesson_sections s ON s.id = cb.section_id
+		 WHERE l.deleted_at IS NULL
+		   AND (? = '' OR cb.language = ?)
+		   AND (? <= 0 OR LENGTH(cb.code) <= ?)
+		 ORDER BY l.module_id, l.order_index, s.order_index, cb.id`,
+		language, language, maxChars, maxChars,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list examples: %w", err)
+	}
+	defer rows.Close()
+
+	var examples []ExampleBlock
+	for rows.Next() {
+		var e ExampleBlock
+		if err := rows.Scan(&e.ID, &e.LessonID, &e.ModuleID, &e.LessonSlug, &e.LessonTitle,
+			&e.SectionID, &e.SectionTitle, &e.Language, &e.Code); err != nil {
+			return nil, fmt.Errorf("scan example: %w", err)
+		}
+		examples = append(examples, e)
+	}
+
+	return examples, rows.Err()
+}
+
+// GetExampleByID возвращает блок кода по id вместе с контекстом.
+func (r *Repository) GetExampleByID(ctx context.Context, id int64) (*ExampleBlock, error) {
+	var e ExampleBlock
+	err := r.db.QueryRowContext(ctx,
+		`SELECT cb.id, l.id, l.module_id, l.slug, l.title, s.id, s.title, cb.language, cb.code
+		 FROM code_blocks cb
+		 JOIN lessons l ON l.id = cb.lesson_id
+		 JOIN lesson_sections s ON s.id = cb.section_id
+		 WHERE cb.id = ? AND l.deleted_at IS NULL`,
+		id,
+	).Scan(&e.ID, &e.LessonID, &e.ModuleID, &e.LessonSlug, &e.LessonTitle,
+		&e.SectionID, &e.SectionTitle, &e.Language, &e.Code)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get example: %w", err)
+	}
+	return &e, nil
+}
+
+// SearchCode выполняет полнотекстовый поиск по блокам кода. На урок
+// возвращается не больше одного (лучшего) совпадения.
+func (r *Repository) SearchCode(ctx context.Context, query string, limit int) ([]CodeSearchResult, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	match := ftsPhraseQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT l.id, l.slug, l.title, cb.language,
+		        snippet(code_blocks_fts, 0, '<mark>', '</mark>', '…', 32) as snippet,
+		        bm25(code_blocks_fts) as rank
+		 FROM code_blocks_fts
+		 JOIN code_blocks cb ON cb.id = code_blocks_fts.rowid
+		 JOIN lessons l ON l.id = cb.lesson_id
+		 WHERE code_blocks_fts MATCH ? AND l.deleted_at IS NULL
+		 ORDER BY rank
+		 LIMIT ?`,
+		match, limit*4,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search code: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[int64]bool)
+	var results []CodeSearchResult
+	for rows.Next() {
+		var res CodeSearchResult
+		if err := rows.Scan(&res.LessonID, &res.Slug, &res.Title, &res.Language, &res.Snippet, &res.Rank); err != nil {
+			return nil, fmt.Errorf("scan code search result: %w", err)
+		}
+		if seen[res.LessonID] {
+			continue
+		}
+		seen[res.LessonID] = true
+		results = append(results, res)
+		if len(results) >= limit {
+			break
+		}
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,758 @@
+package content
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeStore — in-memory реализация Store для тестов и окружений без SQLite.
+// Поведение повторяет SQL-репозиторий в главном: upsert по slug, мягкое
+// удаление с корзиной, «не найдено» — это (nil, nil). Поиск — наивное
+// вхождение подстроки вместо FTS. Транзакционность WithTx не эмулируется.
+type FakeStore struct {
+	mu sync.Mutex
+
+	nextID    int64
+	courses   []Course
+	modules   []Module
+	lessons   []Lesson
+	sections  []Section
+	tasks     []Task
+	taskSteps []TaskStep
+
+	// Мягко удалённые модули и уроки (id -> время удаления)
+	deletedModules map[int64]time.Time
+	deletedLessons map[int64]time.Time
+}
+
+// NewFakeStore создаёт пустое in-memory хранилище контента.
+func NewFakeStore() *FakeStore {
+	return &FakeStore{
+		deletedModules: make(map[int64]time.Time),
+		deletedLessons: make(map[int64]time.Time),
+	}
+}
+
+var _ Store = (*FakeStore)(nil)
+
+func (f *FakeStore) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+// WithTx у фейка просто вызывает fn: отката нет.
+func (f *FakeStore) WithTx(ctx context.Context, fn func(s Store) error) error {
+	return fn(f)
+}
+
+// --- Courses ---
+
+func (f *FakeStore) CreateCourse(ctx context.Context, c *Course) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.courses {
+		if f.courses[i].Slug == c.Slug {
+			c.ID = f.courses[i].ID
+			f.courses[i] = *c
+			return nil
+		}
+	}
+	c.ID = f.id()
+	f.courses = append(f.courses, *c)
+	return nil
+}
+
+func (f *FakeStore) GetCourseBySlug(ctx context.Context, slug string) (*Course, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.courses {
+		if f.courses[i].Slug == slug {
+			c := f.courses[i]
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) GetCourseByID(ctx context.Context, id int64) (*Course, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.courses {
+		if f.courses[i].ID == id {
+			c := f.courses[i]
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) ListCourses(ctx context.Context) ([]Course, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := append([]Course(nil), f.courses...)
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out, nil
+}
+
+// --- Modules ---
+
+func (f *FakeStore) CreateModule(ctx context.Context, m *Module) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.modules {
+		if f.modules[i].Slug == m.Slug {
+			m.ID = f.modules[i].ID
+			f.modules[i] = *m
+			return nil
+		}
+	}
+	m.ID = f.id()
+	f.modules = append(f.modules, *m)
+	return nil
+}
+
+func (f *FakeStore) GetModuleBySlug(ctx context.Context, slug string) (*Module, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.modules {
+		if f.modules[i].Slug == slug && !f.isModuleDeleted(f.modules[i].ID) {
+			m := f.modules[i]
+			return &m, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) ListModules(ctx context.Context) ([]Module, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []Module
+	for _, m := range f.modules {
+		if !f.isModuleDeleted(m.ID) {
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out, nil
+}
+
+func (f *FakeStore) ListModulesByCourseID(ctx context.Context, courseID int64) ([]Module, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []Module
+	for _, m := range f.modules {
+		if m.CourseID == courseID && !f.isModuleDeleted(m.ID) {
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out, nil
+}
+
+func (f *FakeStore) SetModuleVisibility(ctx context.Context, id int64, visibility ModuleVisibility) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.modules {
+		if f.modules[i].ID == id {
+			f.modules[i].Visibility = visibility
+			return nil
+		}
+	}
+	return nil
+}
+
+// --- Lessons ---
+
+func (f *FakeStore) CreateLesson(ctx context.Context, l *Lesson) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	l.UpdatedAt = now
+	// Как и SQL-репозиторий, копируем course_id из модуля
+	for i := range f.modules {
+		if f.modules[i].ID == l.ModuleID {
+			l.CourseID = f.modules[i].CourseID
+			break
+		}
+	}
+	for i := range f.lessons {
+		if f.lessons[i].Slug == l.Slug {
+			l.ID = f.lessons[i].ID
+			l.CreatedAt = f.lessons[i].CreatedAt
+			f.lessons[i] = *l
+			return nil
+		}
+	}
+	l.ID = f.id()
+	l.CreatedAt = now
+	f.lessons = append(f.lessons, *l)
+	return nil
+}
+
+func (f *FakeStore) GetLessonBySlug(ctx context.Context, slug string) (*Lesson, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.lessons {
+		if f.lessons[i].Slug == slug && f.isLessonVisible(f.lessons[i]) {
+			return f.lessonWithRelations(f.lessons[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) GetLessonByID(ctx context.Context, id int64) (*Lesson, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.lessons {
+		if f.lessons[i].ID == id && f.isLessonVisible(f.lessons[i]) {
+			return f.lessonWithRelations(f.lessons[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) ListLessonsByModuleID(ctx context.Context, moduleID int64) ([]Lesson, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []Lesson
+	for _, l := range f.lessons {
+		if l.ModuleID == moduleID && f.isLessonVisible(l) {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out, nil
+}
+
+func (f *FakeStore) ListLessonsPage(ctx context.Context, moduleID, afterID int64, limit int) ([]Lesson, error) {
+	all, _ := f.ListLessonsByModuleID(ctx, moduleID)
+	start := 0
+	if afterID != 0 {
+		for i, l := range all {
+			if l.ID == afterID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	if start > len(all) {
+		start = len(all)
+	}
+	end := start + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[start:end], nil
+}
+
+func (f *FakeStore) ListAllLessons(ctx context.Context) ([]Lesson, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []Lesson
+	for _, l := range f.lessons {
+		if f.isLessonVisible(l) {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ModuleID != out[j].ModuleID {
+			return out[i].ModuleID < out[j].ModuleID
+		}
+		return out[i].OrderIndex < out[j].OrderIndex
+	})
+	return out, nil
+}
+
+// --- Sections ---
+
+func (f *FakeStore) CreateSection(ctx context.Context, s *Section) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s.ID = f.id()
+	if s.Outline == "" {
+		s.Outline = BuildOutline(s.BodyMD)
+	}
+	f.sections = append(f.sections, *s)
+	return nil
+}
+
+func (f *FakeStore) CreateSections(ctx context.Context, sections []Section) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range sections {
+		s.ID = f.id()
+		if s.Outline == "" {
+			s.Outline = BuildOutline(s.BodyMD)
+		}
+		f.sections = append(f.sections, s)
+	}
+	return nil
+}
+
+func (f *FakeStore) DeleteSectionsByLessonID(ctx context.Context, lessonID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	kept := f.sections[:0]
+	for _, s := range f.sections {
+		if s.LessonID != lessonID {
+			kept = append(kept, s)
+		}
+	}
+	f.sections = kept
+	return nil
+}
+
+func (f *FakeStore) GetSectionsByLessonID(ctx context.Context, lessonID int64) ([]Section, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sectionsOf(lessonID), nil
+}
+
+func (f *FakeStore) ReplaceSectionByKind(ctx context.Context, s *Section) error {
+	f.mu.Lock()
+	kept := f.sections[:0]
+	for _, old := range f.sections {
+		if old.LessonID != s.LessonID || old.Kind != s.Kind {
+			kept = append(kept, old)
+		}
+	}
+	f.sections = kept
+	f.mu.Unlock()
+	return f.CreateSection(ctx, s)
+}
+
+func (f *FakeStore) ListSectionsByKind(ctx context.Context, kind SectionKind) ([]Section, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []Section
+	for _, s := range f.sections {
+		if s.Kind == kind {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// --- Tasks ---
+
+func (f *FakeStore) CreateTask(ctx context.Context, t *Task) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t.ID = f.id()
+	f.tasks = append(f.tasks, *t)
+	return nil
+}
+
+func (f *FakeStore) CreateTasks(ctx context.Context, tasks []Task) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, t := range tasks {
+		t.ID = f.id()
+		f.tasks = append(f.tasks, t)
+	}
+	return nil
+}
+
+func (f *FakeStore) DeleteTasksByLessonID(ctx context.Context, lessonID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	kept := f.tasks[:0]
+	for _, t := range f.tasks {
+		if t.LessonID != lessonID {
+			kept = append(kept, t)
+		}
+	}
+	f.tasks = kept
+	return nil
+}
+
+func (f *FakeStore) GetTasksByLessonID(ctx context.Context, lessonID int64) ([]Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.tasksOf(lessonID), nil
+}
+
+func (f *FakeStore) GetTaskByID(ctx context.Context, id int64) (*Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.tasks {
+		if f.tasks[i].ID == id {
+			t := f.tasks[i]
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) CreateTaskSteps(ctx context.Context, steps []TaskStep) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range steps {
+		steps[i].ID = f.id()
+		f.taskSteps = append(f.taskSteps, steps[i])
+	}
+	return nil
+}
+
+func (f *FakeStore) GetTaskStepsByTaskID(ctx context.Context, taskID int64) ([]TaskStep, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []TaskStep
+	for _, st := range f.taskSteps {
+		if st.TaskID == taskID {
+			out = append(out, st)
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) SetTaskCanonicalOutput(ctx context.Context, taskID int64, output string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.tasks {
+		if f.tasks[i].ID == taskID {
+			f.tasks[i].CanonicalOutput = output
+		}
+	}
+	return nil
+}
+
+// --- Search ---
+
+func (f *FakeStore) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit <= 0 {
+		limit = 20
+	}
+	needle := strings.ToLower(query)
+
+	var out []SearchResult
+	for _, l := range f.lessons {
+		if !f.isLessonVisible(l) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(l.Title), needle) ||
+			strings.Contains(strings.ToLower(l.BodyMD), needle) {
+			out = append(out, SearchResult{
+				LessonID: l.ID,
+				Slug:     l.Slug,
+				Title:    l.Title,
+				Snippet:  l.Title,
+			})
+			if len(out) >= limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) SearchCode(ctx context.Context, query string, limit int) ([]CodeSearchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit <= 0 {
+		limit = 20
+	}
+	needle := strings.ToLower(query)
+
+	seen := make(map[int64]bool)
+	var out []CodeSearchResult
+	for _, s := range f.sections {
+		for _, b := range ExtractCodeBlocks(s.BodyMD) {
+			if !strings.Contains(strings.ToLower(b.Code), needle) {
+				continue
+			}
+			for _, l := range f.lessons {
+				if l.ID != s.LessonID || !f.isLessonVisible(l) || seen[l.ID] {
+					continue
+				}
+				seen[l.ID] = true
+				out = append(out, CodeSearchResult{
+					LessonID: l.ID,
+					Slug:     l.Slug,
+					Title:    l.Title,
+					Language: b.Language,
+					Snippet:  b.Code,
+				})
+			}
+			if len(out) >= limit {
+				return out, nil
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) SearchExamples(ctx context.Context, topic, language string, limit int) ([]ExampleBlock, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit <= 0 {
+		limit = 20
+	}
+	needle := strings.ToLower(topic)
+
+	var out []ExampleBlock
+	for _, s := range f.sections {
+		for _, b := range ExtractCodeBlocks(s.BodyMD) {
+			if language != "" && b.Language != language {
+				continue
+			}
+			if !strings.Contains(strings.ToLower(b.Code), needle) {
+				continue
+			}
+			for _, l := range f.lessons {
+				if l.ID != s.LessonID || !f.isLessonVisible(l) {
+					continue
+				}
+				out = append(out, ExampleBlock{
+					LessonID:     l.ID,
+					ModuleID:     l.ModuleID,
+					LessonSlug:   l.Slug,
+					LessonTitle:  l.Title,
+					SectionID:    s.ID,
+					SectionTitle: s.Title,
+					Language:     b.Language,
+					Code:         b.Code,
+				})
+			}
+			if len(out) >= limit {
+				return out, nil
+			}
+		}
+	}
+	return out, nil
+}
+
+// ListExamples возвращает все блоки кода видимых уроков. В фейке у
+// блоков нет строк в БД, поэтому id — порядковый номер при обходе
+// секций; он стабилен, пока не меняется контент.
+func (f *FakeStore) ListExamples(ctx context.Context, language string, maxChars int) ([]ExampleBlock, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []ExampleBlock
+	for _, e := range f.exampleBlocks() {
+		if language != "" && e.Language != language {
+			continue
+		}
+		if maxChars > 0 && len(e.Code) > maxChars {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// GetExampleByID возвращает блок кода по синтетическому id из ListExamples.
+func (f *FakeStore) GetExampleByID(ctx context.Context, id int64) (*ExampleBlock, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, e := range f.exampleBlocks() {
+		if e.ID == id {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// exampleBlocks обходит секции видимых уроков и нумерует блоки кода.
+// Вызывается под мьютексом.
+func (f *FakeStore) exampleBlocks() []ExampleBlock {
+	var out []ExampleBlock
+	var nextID int64
+	for _, s := range f.sections {
+		for _, b := range ExtractCodeBlocks(s.BodyMD) {
+			nextID++
+			for _, l := range f.lessons {
+				if l.ID != s.LessonID || !f.isLessonVisible(l) {
+					continue
+				}
+				out = append(out, ExampleBlock{
+					ID:           nextID,
+					LessonID:     l.ID,
+					ModuleID:     l.ModuleID,
+					LessonSlug:   l.Slug,
+					LessonTitle:  l.Title,
+					SectionID:    s.ID,
+					SectionTitle: s.Title,
+					Language:     b.Language,
+					Code:         b.Code,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// --- Trash ---
+
+func (f *FakeStore) SoftDeleteModule(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	f.deletedModules[id] = now
+	for _, l := range f.lessons {
+		if l.ModuleID == id {
+			if _, ok := f.deletedLessons[l.ID]; !ok {
+				f.deletedLessons[l.ID] = now
+			}
+		}
+	}
+	return nil
+}
+
+func (f *FakeStore) SoftDeleteLesson(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deletedLessons[id] = time.Now()
+	return nil
+}
+
+func (f *FakeStore) RestoreModule(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.deletedModules, id)
+	for _, l := range f.lessons {
+		if l.ModuleID == id {
+			delete(f.deletedLessons, l.ID)
+		}
+	}
+	return nil
+}
+
+func (f *FakeStore) RestoreLesson(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.deletedLessons, id)
+	return nil
+}
+
+func (f *FakeStore) PurgeModule(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.deletedModules[id]; !ok {
+		return nil
+	}
+	delete(f.deletedModules, id)
+	kept := f.modules[:0]
+	for _, m := range f.modules {
+		if m.ID != id {
+			kept = append(kept, m)
+		}
+	}
+	f.modules = kept
+	for _, l := range f.lessons {
+		if l.ModuleID == id {
+			f.purgeLessonLocked(l.ID)
+		}
+	}
+	return nil
+}
+
+func (f *FakeStore) PurgeLesson(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.deletedLessons[id]; !ok {
+		return nil
+	}
+	f.purgeLessonLocked(id)
+	return nil
+}
+
+func (f *FakeStore) ListTrash(ctx context.Context) ([]TrashItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []TrashItem
+	for _, m := range f.modules {
+		if at, ok := f.deletedModules[m.ID]; ok {
+			out = append(out, TrashItem{Kind: "module", ID: m.ID, Slug: m.Slug, Title: m.Title, DeletedAt: at})
+		}
+	}
+	for _, l := range f.lessons {
+		if at, ok := f.deletedLessons[l.ID]; ok {
+			out = append(out, TrashItem{Kind: "lesson", ID: l.ID, Slug: l.Slug, Title: l.Title, DeletedAt: at})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DeletedAt.After(out[j].DeletedAt) })
+	return out, nil
+}
+
+// --- Внутренние помощники (вызываются под mu) ---
+
+func (f *FakeStore) isModuleDeleted(id int64) bool {
+	_, ok := f.deletedModules[id]
+	return ok
+}
+
+func (f *FakeStore) isLessonVisible(l Lesson) bool {
+	if _, ok := f.deletedLessons[l.ID]; ok {
+		return false
+	}
+	return !f.isModuleDeleted(l.ModuleID)
+}
+
+func (f *FakeStore) lessonWithRelations(l Lesson) *Lesson {
+	out := l
+	for i := range f.modules {
+		if f.modules[i].ID == l.ModuleID {
+			m := f.modules[i]
+			out.Module = &m
+			break
+		}
+	}
+	out.Sections = f.sectionsOf(l.ID)
+	out.Tasks = f.tasksOf(l.ID)
+	return &out
+}
+
+func (f *FakeStore) sectionsOf(lessonID int64) []Section {
+	var out []Section
+	for _, s := range f.sections {
+		if s.LessonID == lessonID {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out
+}
+
+func (f *FakeStore) tasksOf(lessonID int64) []Task {
+	var out []Task
+	for _, t := range f.tasks {
+		if t.LessonID == lessonID {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out
+}
+
+func (f *FakeStore) purgeLessonLocked(id int64) {
+	delete(f.deletedLessons, id)
+	kept := f.lessons[:0]
+	for _, l := range f.lessons {
+		if l.ID != id {
+			kept = append(kept, l)
+		}
+	}
+	f.lessons = kept
+	keptSections := f.sections[:0]
+	for _, s := range f.sections {
+		if s.LessonID != id {
+			keptSections = append(keptSections, s)
+		}
+	}
+	f.sections = keptSections
+	keptTasks := f.tasks[:0]
+	for _, t := range f.tasks {
+		if t.LessonID != id {
+			keptTasks = append(keptTasks, t)
+		}
+	}
+	f.tasks = keptTasks
+}
@@ -1,6 +1,11 @@
 package content
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"golearning/internal/render"
+)
 
 // SectionKind — тип секции урока.
 type SectionKind string
@@ -13,6 +18,9 @@ const (
 	SectionPitfalls SectionKind = "pitfalls"
 	SectionLinks    SectionKind = "links"
 	SectionExtra    SectionKind = "extra"
+	// SectionCheatsheet — автосгенерированная шпаргалка урока
+	// (см. internal/cheatsheet); при реимпорте урока перегенерируется.
+	SectionCheatsheet SectionKind = "cheatsheet"
 )
 
 // Course — руководство/курс (верхний уровень иерархии).
@@ -25,6 +33,18 @@ type Course struct {
 	OrderIndex  int
 }
 
+// ModuleVisibility — кому виден модуль в публичном режиме сервера.
+type ModuleVisibility string
+
+const (
+	// VisibilityPublic — уроки модуля видны без входа.
+	VisibilityPublic ModuleVisibility = "public"
+	// VisibilityRegistered — нужен вход по ключу доступа.
+	VisibilityRegistered ModuleVisibility = "registered"
+	// VisibilityCohort — только для участников когорты.
+	VisibilityCohort ModuleVisibility = "cohort"
+)
+
 // Module — раздел курса (например, "Основы", "Функции", "Структуры").
 type Module struct {
 	ID         int64
@@ -32,6 +52,7 @@ type Module struct {
 	Slug       string
 	Title      string
 	OrderIndex int
+	Visibility ModuleVisibility
 
 	// Связанные данные
 	Course *Course
@@ -41,12 +62,17 @@ type Module struct {
 type Lesson struct {
 	ID             int64
 	ModuleID       int64
+	CourseID       int64 // курс урока (копия modules.course_id, заполняется в CreateLesson)
 	Slug           string
 	Title          string
 	OrderIndex     int
 	SourceURL      string
+	SourceName     string // Название источника (для атрибуции)
+	SourceAuthor   string // Автор исходного материала
+	SourceLicense  string // Лицензия исходного материала
 	BodyMD         string
 	ReadingTimeMin int
+	MinGoVersion   string // минимальная версия Go ("1.18"); пусто — любая
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 
@@ -63,23 +89,88 @@ type Section struct {
 	Kind       SectionKind
 	Title      string
 	BodyMD     string
+	Outline    string // JSON-оглавление секции (см. BuildOutline)
 	OrderIndex int
 }
 
+// Headings разбирает сохранённое оглавление секции. Для записей,
+// сохранённых до появления оглавлений, возвращается nil.
+func (s *Section) Headings() []render.Heading {
+	if s.Outline == "" {
+		return nil
+	}
+	var headings []render.Heading
+	if err := json.Unmarshal([]byte(s.Outline), &headings); err != nil {
+		return nil
+	}
+	return headings
+}
+
+// outlineRenderer разбирает Markdown секций при сохранении.
+var outlineRenderer = render.New()
+
+// BuildOutline извлекает заголовки из Markdown секции и сериализует
+// их в JSON для хранения. Секция без заголовков — пустая строка.
+func BuildOutline(bodyMD string) string {
+	headings := outlineRenderer.Outline(bodyMD)
+	if len(headings) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(headings)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// TaskKind — тип задания.
+type TaskKind string
+
+const (
+	// TaskKindCode — обычное задание: учащийся пишет и запускает код.
+	TaskKindCode TaskKind = "code"
+	// TaskKindPredictOutput — «предскажи вывод»: учащийся читает
+	// сниппет из StarterCode и вводит ожидаемый вывод текстом.
+	TaskKindPredictOutput TaskKind = "predict_output"
+	// TaskKindCloze — задание с пропусками: StarterCode — шаблон с
+	// маркерами /*___*/, учащийся заполняет только пропуски.
+	TaskKindCloze TaskKind = "cloze"
+	// TaskKindGuided — пошаговое задание: несколько шагов со своими
+	// условиями и проверками, код переносится между шагами.
+	TaskKindGuided TaskKind = "guided"
+)
+
 // Task — практическое задание.
 type Task struct {
-	ID               int64
-	LessonID         int64
-	Title            string
-	PromptMD         string
-	Criteria         string // Критерии приёмки
-	Hints            string // Подсказки
-	StarterCode      string
-	TestsGo          string
-	ExpectedOutput   string // Ожидаемый вывод программы
-	RequiredPatterns string // Паттерны, которые должны быть в коде (разделённые |)
-	Points           int
-	OrderIndex       int
+	ID              int64
+	LessonID        int64
+	Kind            TaskKind
+	Title           string
+	PromptMD        string
+	Criteria        string // Критерии приёмки
+	Hints           string // Подсказки
+	StarterCode     string
+	StarterVersion  int // версия начального кода; растёт при правках автора
+	TestsGo         string
+	ExpectedOutput  string // Ожидаемый вывод программы
+	CanonicalOutput string // Кеш вывода эталонного сниппета (для predict_output)
+	ASTRules        string // Правила astcheck (разделённые |), например require:switch
+	OutputOptions   string // Флаги сравнения вывода (разделённые |), например case-insensitive
+	RunTimeoutSec   int    // Таймаут выполнения в секундах (0 — по умолчанию)
+	MemoryLimitMB   int    // Лимит памяти в мегабайтах (0 — без лимита)
+	Points          int
+	OrderIndex      int
+}
+
+// TaskStep — шаг пошагового задания (kind = guided).
+type TaskStep struct {
+	ID             int64
+	TaskID         int64
+	Title          string
+	PromptMD       string
+	ExpectedOutput string
+	TestsGo        string
+	OrderIndex     int
 }
 
 // StructuredLesson — структурированный урок после обработки rewriter.
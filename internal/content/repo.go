@@ -1,25 +1,99 @@
 package content
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
+// dbtx — общая часть *sql.DB и *sql.Tx. Благодаря ей методы репозитория
+// работают и напрямую с базой, и внутри транзакции (см. WithTx).
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 // Repository — репозиторий для работы с контентом.
+// Чтения проходят через in-memory кэш с TTL (см. cache.go),
+// любая запись сбрасывает кэш целиком.
 type Repository struct {
-	db *sql.DB
+	db    dbtx
+	base  *sql.DB // для открытия транзакций; nil внутри WithTx
+	cache *memoryCache
 }
 
 // NewRepository создаёт новый репозиторий.
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: db}
+	cache := newMemoryCache(defaultCacheTTL)
+
+	// Согласование кэша между репликами: запись повышает поколение
+	// контента в БД, чтения других реплик замечают это и сбрасывают кэш.
+	// Ошибки игнорируем: без settings (старая схема) кэш живёт по TTL.
+	cache.onFlush = func() {
+		_, _ = db.ExecContext(context.Background(),
+			`INSERT INTO settings (key, value) VALUES ('content_generation', '1')
+			 ON CONFLICT(key) DO UPDATE SET value = CAST(value AS INTEGER) + 1, updated_at = CURRENT_TIMESTAMP`)
+	}
+	cache.probe = func() (int64, error) {
+		var gen int64
+		err := db.QueryRowContext(context.Background(),
+			`SELECT COALESCE((SELECT CAST(value AS INTEGER) FROM settings WHERE key = 'content_generation'), 0)`,
+		).Scan(&gen)
+		return gen, err
+	}
+
+	return &Repository{
+		db:    db,
+		base:  db,
+		cache: cache,
+	}
+}
+
+// WithTx выполняет fn в одной транзакции: все вызовы методов переданного
+// репозитория попадают в неё, ошибка из fn откатывает транзакцию целиком.
+func (r *Repository) WithTx(ctx context.Context, fn func(s Store) error) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Внутри транзакции — отдельный кэш без onFlush: бамп поколения
+	// идёт по другому соединению и упёрся бы в блокировку записи,
+	// которую держит сама транзакция. Общий кэш сбрасывается один раз
+	// после коммита.
+	txRepo := &Repository{db: tx, cache: newMemoryCache(defaultCacheTTL)}
+	if err := fn(txRepo); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// begin открывает новую транзакцию. Внутри WithTx это запрещено:
+// вложенных транзакций в SQLite нет.
+func (r *Repository) begin(ctx context.Context) (*sql.Tx, error) {
+	if r.base == nil {
+		return nil, fmt.Errorf("вложенная транзакция внутри WithTx")
+	}
+	tx, err := r.base.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	return tx, nil
 }
 
 // --- Courses ---
 
 // CreateCourse создаёт или обновляет курс.
-func (r *Repository) CreateCourse(c *Course) error {
-	_, err := r.db.Exec(
+func (r *Repository) CreateCourse(ctx context.Context, c *Course) error {
+	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO courses (slug, title, description, icon, order_index) VALUES (?, ?, ?, ?, ?)
 		 ON CONFLICT(slug) DO UPDATE SET title = excluded.title, description = excluded.description, 
 		 icon = excluded.icon, order_index = excluded.order_index`,
@@ -29,18 +103,19 @@ func (r *Repository) CreateCourse(c *Course) error {
 		return fmt.Errorf("insert course: %w", err)
 	}
 
-	err = r.db.QueryRow("SELECT id FROM courses WHERE slug = ?", c.Slug).Scan(&c.ID)
+	err = r.db.QueryRowContext(ctx, "SELECT id FROM courses WHERE slug = ?", c.Slug).Scan(&c.ID)
 	if err != nil {
 		return fmt.Errorf("get course id: %w", err)
 	}
 
+	r.cache.flush()
 	return nil
 }
 
 // GetCourseBySlug возвращает курс по slug.
-func (r *Repository) GetCourseBySlug(slug string) (*Course, error) {
+func (r *Repository) GetCourseBySlug(ctx context.Context, slug string) (*Course, error) {
 	c := &Course{}
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(ctx,
 		`SELECT id, slug, title, description, icon, order_index FROM courses WHERE slug = ?`,
 		slug,
 	).Scan(&c.ID, &c.Slug, &c.Title, &c.Description, &c.Icon, &c.OrderIndex)
@@ -53,9 +128,29 @@ func (r *Repository) GetCourseBySlug(slug string) (*Course, error) {
 	return c, nil
 }
 
+// GetCourseByID возвращает курс по ID.
+func (r *Repository) GetCourseByID(ctx context.Context, id int64) (*Course, error) {
+	c := &Course{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, slug, title, description, icon, order_index FROM courses WHERE id = ?`,
+		id,
+	).Scan(&c.ID, &c.Slug, &c.Title, &c.Description, &c.Icon, &c.OrderIndex)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get course by id: %w", err)
+	}
+	return c, nil
+}
+
 // ListCourses возвращает все курсы.
-func (r *Repository) ListCourses() ([]Course, error) {
-	rows, err := r.db.Query(`SELECT id, slug, title, description, icon, order_index FROM courses ORDER BY order_index`)
+func (r *Repository) ListCourses(ctx context.Context) ([]Course, error) {
+	if v, ok := r.cache.get("courses"); ok {
+		return v.([]Course), nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, slug, title, description, icon, order_index FROM courses ORDER BY order_index`)
 	if err != nil {
 		return nil, fmt.Errorf("list courses: %w", err)
 	}
@@ -69,15 +164,19 @@ func (r *Repository) ListCourses() ([]Course, error) {
 		}
 		courses = append(courses, c)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return courses, rows.Err()
+	r.cache.set("courses", courses)
+	return courses, nil
 }
 
 // --- Modules ---
 
 // CreateModule создаёт новый модуль.
-func (r *Repository) CreateModule(m *Module) error {
-	_, err := r.db.Exec(
+func (r *Repository) CreateModule(ctx context.Context, m *Module) error {
+	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO modules (slug, title, order_index, course_id) VALUES (?, ?, ?, ?)
 		 ON CONFLICT(slug) DO UPDATE SET title = excluded.title, order_index = excluded.order_index, course_id = excluded.course_id`,
 		m.Slug, m.Title, m.OrderIndex, m.CourseID,
@@ -87,22 +186,23 @@ func (r *Repository) CreateModule(m *Module) error {
 	}
 
 	// Всегда получаем ID по slug (надёжнее чем LastInsertId при ON CONFLICT)
-	err = r.db.QueryRow("SELECT id FROM modules WHERE slug = ?", m.Slug).Scan(&m.ID)
+	err = r.db.QueryRowContext(ctx, "SELECT id FROM modules WHERE slug = ?", m.Slug).Scan(&m.ID)
 	if err != nil {
 		return fmt.Errorf("get module id: %w", err)
 	}
 
+	r.cache.flush()
 	return nil
 }
 
 // GetModuleBySlug возвращает модуль по slug.
-func (r *Repository) GetModuleBySlug(slug string) (*Module, error) {
+func (r *Repository) GetModuleBySlug(ctx context.Context, slug string) (*Module, error) {
 	m := &Module{}
 	var courseID sql.NullInt64
-	err := r.db.QueryRow(
-		`SELECT id, slug, title, order_index, course_id FROM modules WHERE slug = ?`,
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, slug, title, order_index, course_id, COALESCE(visibility, 'public') FROM modules WHERE slug = ? AND deleted_at IS NULL`,
 		slug,
-	).Scan(&m.ID, &m.Slug, &m.Title, &m.OrderIndex, &courseID)
+	).Scan(&m.ID, &m.Slug, &m.Title, &m.OrderIndex, &courseID, &m.Visibility)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -116,8 +216,12 @@ func (r *Repository) GetModuleBySlug(slug string) (*Module, error) {
 }
 
 // ListModules возвращает все модули.
-func (r *Repository) ListModules() ([]Module, error) {
-	rows, err := r.db.Query(`SELECT id, slug, title, order_index, COALESCE(course_id, 0) FROM modules ORDER BY order_index`)
+func (r *Repository) ListModules(ctx context.Context) ([]Module, error) {
+	if v, ok := r.cache.get("modules"); ok {
+		return v.([]Module), nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, slug, title, order_index, COALESCE(course_id, 0), COALESCE(visibility, 'public') FROM modules WHERE deleted_at IS NULL ORDER BY order_index`)
 	if err != nil {
 		return nil, fmt.Errorf("list modules: %w", err)
 	}
@@ -126,19 +230,28 @@ func (r *Repository) ListModules() ([]Module, error) {
 	var modules []Module
 	for rows.Next() {
 		var m Module
-		if err := rows.Scan(&m.ID, &m.Slug, &m.Title, &m.OrderIndex, &m.CourseID); err != nil {
+		if err := rows.Scan(&m.ID, &m.Slug, &m.Title, &m.OrderIndex, &m.CourseID, &m.Visibility); err != nil {
 			return nil, fmt.Errorf("scan module: %w", err)
 		}
 		modules = append(modules, m)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return modules, rows.Err()
+	r.cache.set("modules", modules)
+	return modules, nil
 }
 
 // ListModulesByCourseID возвращает модули для указанного курса.
-func (r *Repository) ListModulesByCourseID(courseID int64) ([]Module, error) {
-	rows, err := r.db.Query(
-		`SELECT id, slug, title, order_index, COALESCE(course_id, 0) FROM modules WHERE course_id = ? ORDER BY order_index`,
+func (r *Repository) ListModulesByCourseID(ctx context.Context, courseID int64) ([]Module, error) {
+	cacheKey := fmt.Sprintf("modules:course:%d", courseID)
+	if v, ok := r.cache.get(cacheKey); ok {
+		return v.([]Module), nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, slug, title, order_index, COALESCE(course_id, 0), COALESCE(visibility, 'public') FROM modules WHERE course_id = ? AND deleted_at IS NULL ORDER BY order_index`,
 		courseID,
 	)
 	if err != nil {
@@ -149,60 +262,90 @@ func (r *Repository) ListModulesByCourseID(courseID int64) ([]Module, error) {
 	var modules []Module
 	for rows.Next() {
 		var m Module
-		if err := rows.Scan(&m.ID, &m.Slug, &m.Title, &m.OrderIndex, &m.CourseID); err != nil {
+		if err := rows.Scan(&m.ID, &m.Slug, &m.Title, &m.OrderIndex, &m.CourseID, &m.Visibility); err != nil {
 			return nil, fmt.Errorf("scan module: %w", err)
 		}
 		modules = append(modules, m)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return modules, rows.Err()
+	r.cache.set(cacheKey, modules)
+	return modules, nil
+}
+
+// SetModuleVisibility меняет видимость модуля для публичного режима.
+func (r *Repository) SetModuleVisibility(ctx context.Context, id int64, visibility ModuleVisibility) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE modules SET visibility = ? WHERE id = ?`, visibility, id)
+	if err != nil {
+		return fmt.Errorf("set module visibility: %w", err)
+	}
+	r.cache.flush()
+	return nil
 }
 
 // --- Lessons ---
 
 // CreateLesson создаёт новый урок.
-func (r *Repository) CreateLesson(l *Lesson) error {
-	_, err := r.db.Exec(
-		`INSERT INTO lessons (module_id, slug, title, order_index, source_url, body_md, reading_time_min)
-		 VALUES (?, ?, ?, ?, ?, ?, ?)
-		 ON CONFLICT(slug) DO UPDATE SET 
+func (r *Repository) CreateLesson(ctx context.Context, l *Lesson) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO lessons (module_id, course_id, slug, title, order_index, source_url,
+		                      source_name, source_author, source_license, body_md, reading_time_min, min_go_version)
+		 VALUES (?, (SELECT course_id FROM modules WHERE id = ?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(slug) DO UPDATE SET
 		   module_id = excluded.module_id,
-		   title = excluded.title, 
+		   course_id = excluded.course_id,
+		   title = excluded.title,
 		   order_index = excluded.order_index,
 		   source_url = excluded.source_url,
+		   source_name = excluded.source_name,
+		   source_author = excluded.source_author,
+		   source_license = excluded.source_license,
 		   body_md = excluded.body_md,
 		   reading_time_min = excluded.reading_time_min,
+		   min_go_version = excluded.min_go_version,
 		   updated_at = CURRENT_TIMESTAMP`,
-		l.ModuleID, l.Slug, l.Title, l.OrderIndex, l.SourceURL, l.BodyMD, l.ReadingTimeMin,
+		l.ModuleID, l.ModuleID, l.Slug, l.Title, l.OrderIndex, l.SourceURL,
+		l.SourceName, l.SourceAuthor, l.SourceLicense, l.BodyMD, l.ReadingTimeMin, l.MinGoVersion,
 	)
 	if err != nil {
 		return fmt.Errorf("insert lesson: %w", err)
 	}
 
 	// Всегда получаем ID по slug (надёжнее чем LastInsertId при ON CONFLICT)
-	err = r.db.QueryRow("SELECT id FROM lessons WHERE slug = ?", l.Slug).Scan(&l.ID)
+	err = r.db.QueryRowContext(ctx, "SELECT id FROM lessons WHERE slug = ?", l.Slug).Scan(&l.ID)
 	if err != nil {
 		return fmt.Errorf("get lesson id: %w", err)
 	}
 
+	r.cache.flush()
 	return nil
 }
 
 // GetLessonBySlug возвращает урок по slug с секциями и заданиями.
-func (r *Repository) GetLessonBySlug(slug string) (*Lesson, error) {
+func (r *Repository) GetLessonBySlug(ctx context.Context, slug string) (*Lesson, error) {
+	cacheKey := "lesson:slug:" + slug
+	if v, ok := r.cache.get(cacheKey); ok {
+		return v.(*Lesson), nil
+	}
+
 	l := &Lesson{Module: &Module{}}
-	err := r.db.QueryRow(
-		`SELECT l.id, l.module_id, l.slug, l.title, l.order_index, l.source_url, l.body_md, 
-		        l.reading_time_min, l.created_at, l.updated_at,
-		        m.id, m.slug, m.title, m.order_index
+	err := r.db.QueryRowContext(ctx,
+		`SELECT l.id, l.module_id, COALESCE(l.course_id, 0), l.slug, l.title, l.order_index, l.source_url,
+		        COALESCE(l.source_name, ''), COALESCE(l.source_author, ''), COALESCE(l.source_license, ''),
+		        l.body_md, l.reading_time_min, l.min_go_version, l.created_at, l.updated_at,
+		        m.id, m.slug, m.title, m.order_index, COALESCE(m.visibility, 'public')
 		 FROM lessons l
 		 JOIN modules m ON m.id = l.module_id
-		 WHERE l.slug = ?`,
+		 WHERE l.slug = ? AND l.deleted_at IS NULL AND m.deleted_at IS NULL`,
 		slug,
 	).Scan(
-		&l.ID, &l.ModuleID, &l.Slug, &l.Title, &l.OrderIndex, &l.SourceURL, &l.BodyMD,
-		&l.ReadingTimeMin, &l.CreatedAt, &l.UpdatedAt,
-		&l.Module.ID, &l.Module.Slug, &l.Module.Title, &l.Module.OrderIndex,
+		&l.ID, &l.ModuleID, &l.CourseID, &l.Slug, &l.Title, &l.OrderIndex, &l.SourceURL,
+		&l.SourceName, &l.SourceAuthor, &l.SourceLicense,
+		&l.BodyMD, &l.ReadingTimeMin, &l.MinGoVersion, &l.CreatedAt, &l.UpdatedAt,
+		&l.Module.ID, &l.Module.Slug, &l.Module.Title, &l.Module.OrderIndex, &l.Module.Visibility,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -212,35 +355,43 @@ func (r *Repository) GetLessonBySlug(slug string) (*Lesson, error) {
 	}
 
 	// Загружаем секции
-	l.Sections, err = r.GetSectionsByLessonID(l.ID)
+	l.Sections, err = r.GetSectionsByLessonID(ctx, l.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Загружаем задания
-	l.Tasks, err = r.GetTasksByLessonID(l.ID)
+	l.Tasks, err = r.GetTasksByLessonID(ctx, l.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	r.cache.set(cacheKey, l)
 	return l, nil
 }
 
 // GetLessonByID возвращает урок по ID.
-func (r *Repository) GetLessonByID(id int64) (*Lesson, error) {
+func (r *Repository) GetLessonByID(ctx context.Context, id int64) (*Lesson, error) {
+	cacheKey := fmt.Sprintf("lesson:id:%d", id)
+	if v, ok := r.cache.get(cacheKey); ok {
+		return v.(*Lesson), nil
+	}
+
 	l := &Lesson{Module: &Module{}}
-	err := r.db.QueryRow(
-		`SELECT l.id, l.module_id, l.slug, l.title, l.order_index, l.source_url, l.body_md, 
-		        l.reading_time_min, l.created_at, l.updated_at,
-		        m.id, m.slug, m.title, m.order_index
+	err := r.db.QueryRowContext(ctx,
+		`SELECT l.id, l.module_id, COALESCE(l.course_id, 0), l.slug, l.title, l.order_index, l.source_url,
+		        COALESCE(l.source_name, ''), COALESCE(l.source_author, ''), COALESCE(l.source_license, ''),
+		        l.body_md, l.reading_time_min, l.min_go_version, l.created_at, l.updated_at,
+		        m.id, m.slug, m.title, m.order_index, COALESCE(m.course_id, 0), COALESCE(m.visibility, 'public')
 		 FROM lessons l
 		 JOIN modules m ON m.id = l.module_id
-		 WHERE l.id = ?`,
+		 WHERE l.id = ? AND l.deleted_at IS NULL AND m.deleted_at IS NULL`,
 		id,
 	).Scan(
-		&l.ID, &l.ModuleID, &l.Slug, &l.Title, &l.OrderIndex, &l.SourceURL, &l.BodyMD,
-		&l.ReadingTimeMin, &l.CreatedAt, &l.UpdatedAt,
-		&l.Module.ID, &l.Module.Slug, &l.Module.Title, &l.Module.OrderIndex,
+		&l.ID, &l.ModuleID, &l.CourseID, &l.Slug, &l.Title, &l.OrderIndex, &l.SourceURL,
+		&l.SourceName, &l.SourceAuthor, &l.SourceLicense,
+		&l.BodyMD, &l.ReadingTimeMin, &l.MinGoVersion, &l.CreatedAt, &l.UpdatedAt,
+		&l.Module.ID, &l.Module.Slug, &l.Module.Title, &l.Module.OrderIndex, &l.Module.CourseID, &l.Module.Visibility,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -249,17 +400,23 @@ func (r *Repository) GetLessonByID(id int64) (*Lesson, error) {
 		return nil, fmt.Errorf("get lesson by id: %w", err)
 	}
 
-	l.Sections, _ = r.GetSectionsByLessonID(l.ID)
-	l.Tasks, _ = r.GetTasksByLessonID(l.ID)
+	l.Sections, _ = r.GetSectionsByLessonID(ctx, l.ID)
+	l.Tasks, _ = r.GetTasksByLessonID(ctx, l.ID)
 
+	r.cache.set(cacheKey, l)
 	return l, nil
 }
 
 // ListLessonsByModuleID возвращает уроки модуля.
-func (r *Repository) ListLessonsByModuleID(moduleID int64) ([]Lesson, error) {
-	rows, err := r.db.Query(
-		`SELECT id, module_id, slug, title, order_index, source_url, body_md, reading_time_min, created_at, updated_at
-		 FROM lessons WHERE module_id = ? ORDER BY order_index`,
+func (r *Repository) ListLessonsByModuleID(ctx context.Context, moduleID int64) ([]Lesson, error) {
+	cacheKey := fmt.Sprintf("lessons:module:%d", moduleID)
+	if v, ok := r.cache.get(cacheKey); ok {
+		return v.([]Lesson), nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, module_id, slug, title, order_index, source_url, body_md, reading_time_min, min_go_version, created_at, updated_at
+		 FROM lessons WHERE module_id = ? AND deleted_at IS NULL ORDER BY order_index`,
 		moduleID,
 	)
 	if err != nil {
@@ -271,22 +428,71 @@ func (r *Repository) ListLessonsByModuleID(moduleID int64) ([]Lesson, error) {
 	for rows.Next() {
 		var l Lesson
 		if err := rows.Scan(&l.ID, &l.ModuleID, &l.Slug, &l.Title, &l.OrderIndex,
-			&l.SourceURL, &l.BodyMD, &l.ReadingTimeMin, &l.CreatedAt, &l.UpdatedAt); err != nil {
+			&l.SourceURL, &l.BodyMD, &l.ReadingTimeMin, &l.MinGoVersion, &l.CreatedAt, &l.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan lesson: %w", err)
+		}
+		lessons = append(lessons, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	r.cache.set(cacheKey, lessons)
+	return lessons, nil
+}
+
+// ListLessonsPage возвращает страницу уроков модуля: limit уроков после
+// урока afterID (keyset-пагинация). afterID = 0 — с начала модуля.
+// Порядок тот же, что на странице курса: order_index, затем id.
+func (r *Repository) ListLessonsPage(ctx context.Context, moduleID, afterID int64, limit int) ([]Lesson, error) {
+	cacheKey := fmt.Sprintf("lessons:module:%d:after:%d:limit:%d", moduleID, afterID, limit)
+	if v, ok := r.cache.get(cacheKey); ok {
+		return v.([]Lesson), nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, module_id, slug, title, order_index, source_url, body_md, reading_time_min, min_go_version, created_at, updated_at
+		 FROM lessons
+		 WHERE module_id = ? AND deleted_at IS NULL
+		   AND (? = 0 OR (order_index, id) > (SELECT order_index, id FROM lessons WHERE id = ?))
+		 ORDER BY order_index, id
+		 LIMIT ?`,
+		moduleID, afterID, afterID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list lessons page: %w", err)
+	}
+	defer rows.Close()
+
+	var lessons []Lesson
+	for rows.Next() {
+		var l Lesson
+		if err := rows.Scan(&l.ID, &l.ModuleID, &l.Slug, &l.Title, &l.OrderIndex,
+			&l.SourceURL, &l.BodyMD, &l.ReadingTimeMin, &l.MinGoVersion, &l.CreatedAt, &l.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("scan lesson: %w", err)
 		}
 		lessons = append(lessons, l)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return lessons, rows.Err()
+	r.cache.set(cacheKey, lessons)
+	return lessons, nil
 }
 
 // ListAllLessons возвращает все уроки.
-func (r *Repository) ListAllLessons() ([]Lesson, error) {
-	rows, err := r.db.Query(
-		`SELECT l.id, l.module_id, l.slug, l.title, l.order_index, l.source_url, l.body_md, 
-		        l.reading_time_min, l.created_at, l.updated_at
+func (r *Repository) ListAllLessons(ctx context.Context) ([]Lesson, error) {
+	if v, ok := r.cache.get("lessons:all"); ok {
+		return v.([]Lesson), nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT l.id, l.module_id, l.slug, l.title, l.order_index, l.source_url, l.body_md,
+		        l.reading_time_min, l.min_go_version, l.created_at, l.updated_at
 		 FROM lessons l
 		 JOIN modules m ON m.id = l.module_id
+		 WHERE l.deleted_at IS NULL AND m.deleted_at IS NULL
 		 ORDER BY m.order_index, l.order_index`,
 	)
 	if err != nil {
@@ -298,42 +504,111 @@ func (r *Repository) ListAllLessons() ([]Lesson, error) {
 	for rows.Next() {
 		var l Lesson
 		if err := rows.Scan(&l.ID, &l.ModuleID, &l.Slug, &l.Title, &l.OrderIndex,
-			&l.SourceURL, &l.BodyMD, &l.ReadingTimeMin, &l.CreatedAt, &l.UpdatedAt); err != nil {
+			&l.SourceURL, &l.BodyMD, &l.ReadingTimeMin, &l.MinGoVersion, &l.CreatedAt, &l.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("scan lesson: %w", err)
 		}
 		lessons = append(lessons, l)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return lessons, rows.Err()
+	r.cache.set("lessons:all", lessons)
+	return lessons, nil
 }
 
 // --- Sections ---
 
-// CreateSection создаёт секцию урока.
-func (r *Repository) CreateSection(s *Section) error {
-	result, err := r.db.Exec(
-		`INSERT INTO lesson_sections (lesson_id, kind, title, body_md, order_index)
-		 VALUES (?, ?, ?, ?, ?)`,
-		s.LessonID, s.Kind, s.Title, s.BodyMD, s.OrderIndex,
+// CreateSection создаёт секцию урока. Оглавление вычисляется здесь же,
+// чтобы любой путь импорта сохранял его автоматически.
+func (r *Repository) CreateSection(ctx context.Context, s *Section) error {
+	if s.Outline == "" {
+		s.Outline = BuildOutline(s.BodyMD)
+	}
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO lesson_sections (lesson_id, kind, title, body_md, outline, order_index)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		s.LessonID, s.Kind, s.Title, s.BodyMD, s.Outline, s.OrderIndex,
 	)
 	if err != nil {
 		return fmt.Errorf("insert section: %w", err)
 	}
 
 	s.ID, _ = result.LastInsertId()
+
+	// Индексируем блоки кода секции для поиска по коду
+	if err := r.insertSectionCode(ctx, s.ID, s.LessonID, s.BodyMD); err != nil {
+		return err
+	}
+
+	r.cache.flush()
+	return nil
+}
+
+// CreateSections вставляет секции батчами по несколько строк на запрос.
+// На полном импорте курса это на порядок быстрее поштучных вставок.
+// ID созданных записей не заполняются.
+func (r *Repository) CreateSections(ctx context.Context, sections []Section) error {
+	const chunk = 100
+	for start := 0; start < len(sections); start += chunk {
+		end := start + chunk
+		if end > len(sections) {
+			end = len(sections)
+		}
+		batch := sections[start:end]
+
+		values := make([]string, 0, len(batch))
+		args := make([]interface{}, 0, len(batch)*6)
+		for _, s := range batch {
+			outline := s.Outline
+			if outline == "" {
+				outline = BuildOutline(s.BodyMD)
+			}
+			values = append(values, "(?, ?, ?, ?, ?, ?)")
+			args = append(args, s.LessonID, s.Kind, s.Title, s.BodyMD, outline, s.OrderIndex)
+		}
+
+		_, err := r.db.ExecContext(ctx,
+			`INSERT INTO lesson_sections (lesson_id, kind, title, body_md, outline, order_index)
+			 VALUES `+strings.Join(values, ", "),
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("insert sections: %w", err)
+		}
+	}
+
+	// Батчевая вставка не возвращает ID секций, поэтому индекс блоков
+	// кода перестраивается по уже сохранённым секциям каждого урока
+	lessonIDs := make(map[int64]bool)
+	for _, s := range sections {
+		lessonIDs[s.LessonID] = true
+	}
+	for lessonID := range lessonIDs {
+		if err := r.reindexLessonCode(ctx, lessonID); err != nil {
+			return err
+		}
+	}
+
+	r.cache.flush()
 	return nil
 }
 
-// DeleteSectionsByLessonID удаляет все секции урока.
-func (r *Repository) DeleteSectionsByLessonID(lessonID int64) error {
-	_, err := r.db.Exec(`DELETE FROM lesson_sections WHERE lesson_id = ?`, lessonID)
+// DeleteSectionsByLessonID удаляет все секции урока вместе с
+// проиндексированными блоками кода.
+func (r *Repository) DeleteSectionsByLessonID(ctx context.Context, lessonID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM code_blocks WHERE lesson_id = ?`, lessonID); err != nil {
+		return fmt.Errorf("delete code blocks: %w", err)
+	}
+	_, err := r.db.ExecContext(ctx, `DELETE FROM lesson_sections WHERE lesson_id = ?`, lessonID)
+	r.cache.flush()
 	return err
 }
 
 // GetSectionsByLessonID возвращает секции урока.
-func (r *Repository) GetSectionsByLessonID(lessonID int64) ([]Section, error) {
-	rows, err := r.db.Query(
-		`SELECT id, lesson_id, kind, title, body_md, order_index 
+func (r *Repository) GetSectionsByLessonID(ctx context.Context, lessonID int64) ([]Section, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, lesson_id, kind, title, body_md, COALESCE(outline, ''), order_index
 		 FROM lesson_sections WHERE lesson_id = ? ORDER BY order_index`,
 		lessonID,
 	)
@@ -345,7 +620,55 @@ func (r *Repository) GetSectionsByLessonID(lessonID int64) ([]Section, error) {
 	var sections []Section
 	for rows.Next() {
 		var s Section
-		if err := rows.Scan(&s.ID, &s.LessonID, &s.Kind, &s.Title, &s.BodyMD, &s.OrderIndex); err != nil {
+		if err := rows.Scan(&s.ID, &s.LessonID, &s.Kind, &s.Title, &s.BodyMD, &s.Outline, &s.OrderIndex); err != nil {
+			return nil, fmt.Errorf("scan section: %w", err)
+		}
+		sections = append(sections, s)
+	}
+
+	return sections, rows.Err()
+}
+
+// ReplaceSectionByKind заменяет секцию заданного типа у урока: старая
+// удаляется, новая вставляется. Используется генератором шпаргалок —
+// у урока не больше одной секции каждого служебного типа.
+func (r *Repository) ReplaceSectionByKind(ctx context.Context, s *Section) error {
+	if _, err := r.db.ExecContext(ctx,
+		`DELETE FROM code_blocks WHERE section_id IN (
+		   SELECT id FROM lesson_sections WHERE lesson_id = ? AND kind = ?
+		 )`,
+		s.LessonID, s.Kind,
+	); err != nil {
+		return fmt.Errorf("delete section code blocks: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx,
+		`DELETE FROM lesson_sections WHERE lesson_id = ? AND kind = ?`,
+		s.LessonID, s.Kind,
+	); err != nil {
+		return fmt.Errorf("delete section: %w", err)
+	}
+	return r.CreateSection(ctx, s)
+}
+
+// ListSectionsByKind возвращает все секции заданного типа по неудалённым
+// урокам (для индекса шпаргалок).
+func (r *Repository) ListSectionsByKind(ctx context.Context, kind SectionKind) ([]Section, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT s.id, s.lesson_id, s.kind, s.title, s.body_md, COALESCE(s.outline, ''), s.order_index
+		 FROM lesson_sections s
+		 JOIN lessons l ON l.id = s.lesson_id AND l.deleted_at IS NULL
+		 WHERE s.kind = ?`,
+		kind,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list sections by kind: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []Section
+	for rows.Next() {
+		var s Section
+		if err := rows.Scan(&s.ID, &s.LessonID, &s.Kind, &s.Title, &s.BodyMD, &s.Outline, &s.OrderIndex); err != nil {
 			return nil, fmt.Errorf("scan section: %w", err)
 		}
 		sections = append(sections, s)
@@ -357,35 +680,146 @@ func (r *Repository) GetSectionsByLessonID(lessonID int64) ([]Section, error) {
 // --- Tasks ---
 
 // CreateTask создаёт задание.
-func (r *Repository) CreateTask(t *Task) error {
-	result, err := r.db.Exec(
-		`INSERT INTO tasks (lesson_id, title, prompt_md, criteria, hints, starter_code, tests_go, expected_output, required_patterns, points, order_index)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		t.LessonID, t.Title, t.PromptMD, t.Criteria, t.Hints, t.StarterCode, t.TestsGo, t.ExpectedOutput, t.RequiredPatterns, t.Points, t.OrderIndex,
+func (r *Repository) CreateTask(ctx context.Context, t *Task) error {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO tasks (lesson_id, kind, title, prompt_md, criteria, hints, starter_code, starter_version, tests_go, expected_output, ast_rules, output_options, run_timeout_sec, memory_limit_mb, points, order_index)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.LessonID, normalizeTaskKind(t.Kind), t.Title, t.PromptMD, t.Criteria, t.Hints, t.StarterCode, normalizeStarterVersion(t.StarterVersion), t.TestsGo, t.ExpectedOutput, t.ASTRules, t.OutputOptions, t.RunTimeoutSec, t.MemoryLimitMB, t.Points, t.OrderIndex,
 	)
 	if err != nil {
 		return fmt.Errorf("insert task: %w", err)
 	}
 
 	t.ID, _ = result.LastInsertId()
+	r.cache.flush()
+	return nil
+}
+
+// CreateTasks вставляет задания батчами по несколько строк на запрос.
+// ID созданных записей не заполняются.
+func (r *Repository) CreateTasks(ctx context.Context, tasks []Task) error {
+	const chunk = 80
+	for start := 0; start < len(tasks); start += chunk {
+		end := start + chunk
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+		batch := tasks[start:end]
+
+		values := make([]string, 0, len(batch))
+		args := make([]interface{}, 0, len(batch)*16)
+		for _, t := range batch {
+			values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args, t.LessonID, normalizeTaskKind(t.Kind), t.Title, t.PromptMD, t.Criteria, t.Hints,
+				t.StarterCode, normalizeStarterVersion(t.StarterVersion), t.TestsGo, t.ExpectedOutput, t.ASTRules, t.OutputOptions, t.RunTimeoutSec, t.MemoryLimitMB, t.Points, t.OrderIndex)
+		}
+
+		_, err := r.db.ExecContext(ctx,
+			`INSERT INTO tasks (lesson_id, kind, title, prompt_md, criteria, hints, starter_code, starter_version, tests_go, expected_output, ast_rules, output_options, run_timeout_sec, memory_limit_mb, points, order_index)
+			 VALUES `+strings.Join(values, ", "),
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("insert tasks: %w", err)
+		}
+	}
+
+	r.cache.flush()
+	return nil
+}
+
+// CreateTaskSteps вставляет шаги пошагового задания.
+func (r *Repository) CreateTaskSteps(ctx context.Context, steps []TaskStep) error {
+	for i := range steps {
+		result, err := r.db.ExecContext(ctx,
+			`INSERT INTO task_steps (task_id, title, prompt_md, expected_output, tests_go, order_index)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			steps[i].TaskID, steps[i].Title, steps[i].PromptMD, steps[i].ExpectedOutput, steps[i].TestsGo, steps[i].OrderIndex,
+		)
+		if err != nil {
+			return fmt.Errorf("insert task step: %w", err)
+		}
+		steps[i].ID, _ = result.LastInsertId()
+	}
+	r.cache.flush()
+	return nil
+}
+
+// GetTaskStepsByTaskID возвращает шаги задания в порядке прохождения.
+func (r *Repository) GetTaskStepsByTaskID(ctx context.Context, taskID int64) ([]TaskStep, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, task_id, title, prompt_md, expected_output, tests_go, order_index
+		 FROM task_steps WHERE task_id = ? ORDER BY order_index`,
+		taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get task steps: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []TaskStep
+	for rows.Next() {
+		var st TaskStep
+		if err := rows.Scan(&st.ID, &st.TaskID, &st.Title, &st.PromptMD, &st.ExpectedOutput, &st.TestsGo, &st.OrderIndex); err != nil {
+			return nil, fmt.Errorf("scan task step: %w", err)
+		}
+		steps = append(steps, st)
+	}
+
+	return steps, rows.Err()
+}
+
+// normalizeTaskKind подставляет тип задания по умолчанию.
+func normalizeTaskKind(k TaskKind) TaskKind {
+	if k == "" {
+		return TaskKindCode
+	}
+	return k
+}
+
+// normalizeStarterVersion подставляет первую версию начального кода.
+func normalizeStarterVersion(v int) int {
+	if v <= 0 {
+		return 1
+	}
+	return v
+}
+
+// SetTaskCanonicalOutput сохраняет кеш вывода эталонного сниппета
+// задания «предскажи вывод».
+func (r *Repository) SetTaskCanonicalOutput(ctx context.Context, taskID int64, output string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE tasks SET canonical_output = ? WHERE id = ?`, output, taskID)
+	if err != nil {
+		return fmt.Errorf("set canonical output: %w", err)
+	}
+	r.cache.flush()
 	return nil
 }
 
 // DeleteTasksByLessonID удаляет все задания урока.
-func (r *Repository) DeleteTasksByLessonID(lessonID int64) error {
-	_, err := r.db.Exec(`DELETE FROM tasks WHERE lesson_id = ?`, lessonID)
+func (r *Repository) DeleteTasksByLessonID(ctx context.Context, lessonID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM tasks WHERE lesson_id = ?`, lessonID)
+	r.cache.flush()
 	return err
 }
 
 // GetTasksByLessonID возвращает задания урока.
-func (r *Repository) GetTasksByLessonID(lessonID int64) ([]Task, error) {
-	rows, err := r.db.Query(
-		`SELECT id, lesson_id, title, prompt_md, 
+func (r *Repository) GetTasksByLessonID(ctx context.Context, lessonID int64) ([]Task, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, lesson_id,
+		        COALESCE(kind, 'code') as kind,
+		        title, prompt_md,
 		        COALESCE(criteria, '') as criteria,
 		        COALESCE(hints, '') as hints,
-		        starter_code, tests_go, 
+		        starter_code,
+		        COALESCE(starter_version, 1) as starter_version,
+		        tests_go,
 		        COALESCE(expected_output, '') as expected_output,
-		        COALESCE(required_patterns, '') as required_patterns,
+		        COALESCE(canonical_output, '') as canonical_output,
+		        COALESCE(ast_rules, '') as ast_rules,
+		        COALESCE(output_options, '') as output_options,
+		        COALESCE(run_timeout_sec, 0) as run_timeout_sec,
+		        COALESCE(memory_limit_mb, 0) as memory_limit_mb,
 		        points, order_index
 		 FROM tasks WHERE lesson_id = ? ORDER BY order_index`,
 		lessonID,
@@ -398,7 +832,7 @@ func (r *Repository) GetTasksByLessonID(lessonID int64) ([]Task, error) {
 	var tasks []Task
 	for rows.Next() {
 		var t Task
-		if err := rows.Scan(&t.ID, &t.LessonID, &t.Title, &t.PromptMD, &t.Criteria, &t.Hints, &t.StarterCode, &t.TestsGo, &t.ExpectedOutput, &t.RequiredPatterns, &t.Points, &t.OrderIndex); err != nil {
+		if err := rows.Scan(&t.ID, &t.LessonID, &t.Kind, &t.Title, &t.PromptMD, &t.Criteria, &t.Hints, &t.StarterCode, &t.StarterVersion, &t.TestsGo, &t.ExpectedOutput, &t.CanonicalOutput, &t.ASTRules, &t.OutputOptions, &t.RunTimeoutSec, &t.MemoryLimitMB, &t.Points, &t.OrderIndex); err != nil {
 			return nil, fmt.Errorf("scan task: %w", err)
 		}
 		tasks = append(tasks, t)
@@ -408,19 +842,27 @@ func (r *Repository) GetTasksByLessonID(lessonID int64) ([]Task, error) {
 }
 
 // GetTaskByID возвращает задание по ID.
-func (r *Repository) GetTaskByID(id int64) (*Task, error) {
+func (r *Repository) GetTaskByID(ctx context.Context, id int64) (*Task, error) {
 	t := &Task{}
-	err := r.db.QueryRow(
-		`SELECT id, lesson_id, title, prompt_md, 
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, lesson_id,
+		        COALESCE(kind, 'code') as kind,
+		        title, prompt_md,
 		        COALESCE(criteria, '') as criteria,
 		        COALESCE(hints, '') as hints,
-		        starter_code, tests_go, 
-		        COALESCE(expected_output, '') as expected_output, 
-		        COALESCE(required_patterns, '') as required_patterns, 
+		        starter_code,
+		        COALESCE(starter_version, 1) as starter_version,
+		        tests_go,
+		        COALESCE(expected_output, '') as expected_output,
+		        COALESCE(canonical_output, '') as canonical_output,
+		        COALESCE(ast_rules, '') as ast_rules,
+		        COALESCE(output_options, '') as output_options,
+		        COALESCE(run_timeout_sec, 0) as run_timeout_sec,
+		        COALESCE(memory_limit_mb, 0) as memory_limit_mb,
 		        points, order_index
 		 FROM tasks WHERE id = ?`,
 		id,
-	).Scan(&t.ID, &t.LessonID, &t.Title, &t.PromptMD, &t.Criteria, &t.Hints, &t.StarterCode, &t.TestsGo, &t.ExpectedOutput, &t.RequiredPatterns, &t.Points, &t.OrderIndex)
+	).Scan(&t.ID, &t.LessonID, &t.Kind, &t.Title, &t.PromptMD, &t.Criteria, &t.Hints, &t.StarterCode, &t.StarterVersion, &t.TestsGo, &t.ExpectedOutput, &t.CanonicalOutput, &t.ASTRules, &t.OutputOptions, &t.RunTimeoutSec, &t.MemoryLimitMB, &t.Points, &t.OrderIndex)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -433,17 +875,17 @@ func (r *Repository) GetTaskByID(id int64) (*Task, error) {
 // --- Search ---
 
 // Search выполняет полнотекстовый поиск по урокам.
-func (r *Repository) Search(query string, limit int) ([]SearchResult, error) {
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
-	rows, err := r.db.Query(
+	rows, err := r.db.QueryContext(ctx,
 		`SELECT l.id, l.slug, l.title, snippet(lessons_fts, 1, '<mark>', '</mark>', '...', 32) as snippet,
 		        bm25(lessons_fts) as rank
-		 FROM lessons_fts 
+		 FROM lessons_fts
 		 JOIN lessons l ON l.id = lessons_fts.rowid
-		 WHERE lessons_fts MATCH ?
+		 WHERE lessons_fts MATCH ? AND l.deleted_at IS NULL
 		 ORDER BY rank
 		 LIMIT ?`,
 		query, limit,
@@ -0,0 +1,69 @@
+package content
+
+import "context"
+
+// Store — интерфейс хранилища контента. Его реализуют SQL-репозиторий
+// (Repository) и in-memory фейк (FakeStore) для тестов без SQLite.
+// Веб-слой, чекер и конвейер импорта зависят только от этого интерфейса.
+type Store interface {
+	// WithTx выполняет fn в одной транзакции (для фейка — просто вызывает fn).
+	WithTx(ctx context.Context, fn func(s Store) error) error
+
+	// Курсы
+	CreateCourse(ctx context.Context, c *Course) error
+	GetCourseBySlug(ctx context.Context, slug string) (*Course, error)
+	GetCourseByID(ctx context.Context, id int64) (*Course, error)
+	ListCourses(ctx context.Context) ([]Course, error)
+
+	// Модули
+	CreateModule(ctx context.Context, m *Module) error
+	GetModuleBySlug(ctx context.Context, slug string) (*Module, error)
+	ListModules(ctx context.Context) ([]Module, error)
+	ListModulesByCourseID(ctx context.Context, courseID int64) ([]Module, error)
+	SetModuleVisibility(ctx context.Context, id int64, visibility ModuleVisibility) error
+
+	// Уроки
+	CreateLesson(ctx context.Context, l *Lesson) error
+	GetLessonBySlug(ctx context.Context, slug string) (*Lesson, error)
+	GetLessonByID(ctx context.Context, id int64) (*Lesson, error)
+	ListLessonsByModuleID(ctx context.Context, moduleID int64) ([]Lesson, error)
+	ListLessonsPage(ctx context.Context, moduleID, afterID int64, limit int) ([]Lesson, error)
+	ListAllLessons(ctx context.Context) ([]Lesson, error)
+
+	// Секции
+	CreateSection(ctx context.Context, s *Section) error
+	CreateSections(ctx context.Context, sections []Section) error
+	DeleteSectionsByLessonID(ctx context.Context, lessonID int64) error
+	GetSectionsByLessonID(ctx context.Context, lessonID int64) ([]Section, error)
+	ReplaceSectionByKind(ctx context.Context, s *Section) error
+	ListSectionsByKind(ctx context.Context, kind SectionKind) ([]Section, error)
+
+	// Задания
+	CreateTask(ctx context.Context, t *Task) error
+	CreateTasks(ctx context.Context, tasks []Task) error
+	DeleteTasksByLessonID(ctx context.Context, lessonID int64) error
+	GetTasksByLessonID(ctx context.Context, lessonID int64) ([]Task, error)
+	GetTaskByID(ctx context.Context, id int64) (*Task, error)
+	SetTaskCanonicalOutput(ctx context.Context, taskID int64, output string) error
+	CreateTaskSteps(ctx context.Context, steps []TaskStep) error
+	GetTaskStepsByTaskID(ctx context.Context, taskID int64) ([]TaskStep, error)
+
+	// Поиск
+	Search(ctx context.Context, query string, limit int) ([]SearchResult, error)
+	SearchCode(ctx context.Context, query string, limit int) ([]CodeSearchResult, error)
+	SearchExamples(ctx context.Context, topic, language string, limit int) ([]ExampleBlock, error)
+	ListExamples(ctx context.Context, language string, maxChars int) ([]ExampleBlock, error)
+	GetExampleByID(ctx context.Context, id int64) (*ExampleBlock, error)
+
+	// Корзина
+	SoftDeleteModule(ctx context.Context, id int64) error
+	SoftDeleteLesson(ctx context.Context, id int64) error
+	RestoreModule(ctx context.Context, id int64) error
+	RestoreLesson(ctx context.Context, id int64) error
+	PurgeModule(ctx context.Context, id int64) error
+	PurgeLesson(ctx context.Context, id int64) error
+	ListTrash(ctx context.Context) ([]TrashItem, error)
+}
+
+// Проверка соответствия интерфейсу на этапе компиляции.
+var _ Store = (*Repository)(nil)
@@ -0,0 +1,135 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TrashItem — элемент корзины: мягко удалённый модуль или урок.
+type TrashItem struct {
+	Kind      string // "module" или "lesson"
+	ID        int64
+	Slug      string
+	Title     string
+	DeletedAt time.Time
+}
+
+// SoftDeleteModule перемещает модуль и его уроки в корзину.
+func (r *Repository) SoftDeleteModule(ctx context.Context, id int64) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("soft delete module: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE modules SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	); err != nil {
+		return fmt.Errorf("soft delete module: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE lessons SET deleted_at = CURRENT_TIMESTAMP WHERE module_id = ? AND deleted_at IS NULL`,
+		id,
+	); err != nil {
+		return fmt.Errorf("soft delete module lessons: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("soft delete module: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// SoftDeleteLesson перемещает урок в корзину.
+func (r *Repository) SoftDeleteLesson(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE lessons SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("soft delete lesson: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// RestoreModule восстанавливает модуль и его уроки из корзины.
+func (r *Repository) RestoreModule(ctx context.Context, id int64) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("restore module: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE modules SET deleted_at = NULL WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("restore module: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE lessons SET deleted_at = NULL WHERE module_id = ?`, id); err != nil {
+		return fmt.Errorf("restore module lessons: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("restore module: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// RestoreLesson восстанавливает урок из корзины.
+func (r *Repository) RestoreLesson(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE lessons SET deleted_at = NULL WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("restore lesson: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// PurgeModule окончательно удаляет модуль из корзины.
+// Каскадно удаляются уроки, секции, задания и связанный прогресс.
+func (r *Repository) PurgeModule(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM modules WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("purge module: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// PurgeLesson окончательно удаляет урок из корзины.
+func (r *Repository) PurgeLesson(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM lessons WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("purge lesson: %w", err)
+	}
+	r.cache.flush()
+	return nil
+}
+
+// ListTrash возвращает содержимое корзины (сначала свежеудалённое).
+func (r *Repository) ListTrash(ctx context.Context) ([]TrashItem, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT 'module' AS kind, id, slug, title, deleted_at FROM modules WHERE deleted_at IS NOT NULL
+		 UNION ALL
+		 SELECT 'lesson' AS kind, id, slug, title, deleted_at FROM lessons WHERE deleted_at IS NOT NULL
+		 ORDER BY deleted_at DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	defer rows.Close()
+
+	var items []TrashItem
+	for rows.Next() {
+		var item TrashItem
+		if err := rows.Scan(&item.Kind, &item.ID, &item.Slug, &item.Title, &item.DeletedAt); err != nil {
+			return nil, fmt.Errorf("scan trash item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
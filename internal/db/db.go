@@ -15,7 +15,9 @@ var migrationsFS embed.FS
 
 // Open открывает или создаёт базу данных SQLite.
 func Open(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", path+"?_foreign_keys=on&_journal_mode=WAL")
+	// busy_timeout нужен фоновым задачам планировщика: параллельные
+	// записи иначе сразу падают с SQLITE_BUSY
+	db, err := sql.Open("sqlite", path+"?_foreign_keys=on&_journal_mode=WAL&_pragma=busy_timeout(5000)")
 	if err != nil {
 		return nil, fmt.Errorf("open db: %w", err)
 	}
@@ -28,16 +30,13 @@ func Open(path string) (*sql.DB, error) {
 }
 
 // Migrate выполняет все SQL миграции из папки migrations.
+// Файлы *.down.sql — парные откаты, вверх они не применяются.
 func Migrate(db *sql.DB) error {
-	// Создаём таблицу для отслеживания миграций
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version TEXT PRIMARY KEY,
-			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)
-	`)
-	if err != nil {
-		return fmt.Errorf("create migrations table: %w", err)
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+	if err := checkDirty(db); err != nil {
+		return err
 	}
 
 	// Читаем файлы миграций
@@ -50,6 +49,9 @@ func Migrate(db *sql.DB) error {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
 			continue
 		}
+		if strings.HasSuffix(entry.Name(), ".down.sql") {
+			continue
+		}
 
 		version := entry.Name()
 
@@ -72,40 +74,240 @@ func Migrate(db *sql.DB) error {
 
 		log.Printf("Applying migration: %s", version)
 
-		tx, err := db.Begin()
-		if err != nil {
-			return fmt.Errorf("begin tx for %s: %w", version, err)
+		// Помечаем миграцию как dirty до применения: если процесс упадёт
+		// посреди неё, следующий запуск откажется работать вслепую.
+		if _, err := db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, 1)", version); err != nil {
+			return fmt.Errorf("mark migration %s dirty: %w", version, err)
 		}
 
-		// Разбиваем на отдельные команды и выполняем
-		statements := splitStatements(string(content))
-		for _, stmt := range statements {
-			stmt = strings.TrimSpace(stmt)
-			if stmt == "" {
-				continue
-			}
-			if _, err := tx.Exec(stmt); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("exec migration %s: %w\nStatement: %s", version, err, stmt)
-			}
+		if err := applyStatements(db, string(content)); err != nil {
+			// Транзакция откатила изменения схемы — снимаем отметку.
+			// Если снять не удалось, запись останется dirty и сработает guard.
+			_, _ = db.Exec("DELETE FROM schema_migrations WHERE version = ?", version)
+			return fmt.Errorf("exec migration %s: %w", version, err)
 		}
 
-		// Отмечаем миграцию как выполненную
-		if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
-			tx.Rollback()
+		if _, err := db.Exec("UPDATE schema_migrations SET dirty = 0 WHERE version = ?", version); err != nil {
 			return fmt.Errorf("mark migration %s: %w", version, err)
 		}
 
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("commit migration %s: %w", version, err)
+		log.Printf("Migration %s applied successfully", version)
+	}
+
+	return nil
+}
+
+// MigrateDown откатывает применённые миграции до версии toVersion
+// включительно (она остаётся применённой). Принимает номер ("013"),
+// имя файла или "0" — полный откат. Для каждой откатываемой версии
+// должен существовать парный файл NNN_name.down.sql.
+func MigrateDown(db *sql.DB, toVersion string) error {
+	if toVersion == "" {
+		return fmt.Errorf("не указана целевая версия (--to)")
+	}
+
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+	if err := checkDirty(db); err != nil {
+		return err
+	}
+
+	rows, err := db.Query("SELECT version FROM schema_migrations ORDER BY version DESC")
+	if err != nil {
+		return fmt.Errorf("read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var applied []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return fmt.Errorf("scan applied migration: %w", err)
 		}
+		applied = append(applied, v)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
-		log.Printf("Migration %s applied successfully", version)
+	target := versionPrefix(toVersion)
+	if target != "0" {
+		found := false
+		for _, v := range applied {
+			if versionPrefix(v) == target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("версия %s не применена — откатывать не к чему", toVersion)
+		}
+	}
+
+	for _, version := range applied {
+		if target != "0" && versionPrefix(version) <= target {
+			continue
+		}
+
+		downFile := strings.TrimSuffix(version, ".sql") + ".down.sql"
+		content, err := migrationsFS.ReadFile("migrations/" + downFile)
+		if err != nil {
+			return fmt.Errorf("нет файла отката %s для миграции %s: %w", downFile, version, err)
+		}
+
+		log.Printf("Reverting migration: %s", version)
+
+		if _, err := db.Exec("UPDATE schema_migrations SET dirty = 1 WHERE version = ?", version); err != nil {
+			return fmt.Errorf("mark migration %s dirty: %w", version, err)
+		}
+
+		if err := applyStatements(db, string(content)); err != nil {
+			_, _ = db.Exec("UPDATE schema_migrations SET dirty = 0 WHERE version = ?", version)
+			return fmt.Errorf("exec down migration %s: %w", version, err)
+		}
+
+		if _, err := db.Exec("DELETE FROM schema_migrations WHERE version = ?", version); err != nil {
+			return fmt.Errorf("unmark migration %s: %w", version, err)
+		}
+
+		log.Printf("Migration %s reverted successfully", version)
 	}
 
 	return nil
 }
 
+// SchemaVersion возвращает последнюю применённую версию схемы
+// (имя файла миграции) или пустую строку для свежей БД.
+func SchemaVersion(db *sql.DB) (string, error) {
+	if err := ensureMigrationsTable(db); err != nil {
+		return "", err
+	}
+	var version string
+	err := db.QueryRow("SELECT COALESCE(MAX(version), '') FROM schema_migrations WHERE dirty = 0").Scan(&version)
+	if err != nil {
+		return "", fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// PendingMigrations возвращает список миграций, которые ещё
+// не применены к базе, в порядке применения.
+func PendingMigrations(db *sql.DB) ([]string, error) {
+	if err := ensureMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	entries, err := migrationsFS.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+
+	var pending []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") || strings.HasSuffix(entry.Name(), ".down.sql") {
+			continue
+		}
+		var applied int
+		if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE version = ?", entry.Name()).Scan(&applied); err != nil {
+			return nil, fmt.Errorf("check migration %s: %w", entry.Name(), err)
+		}
+		if applied == 0 {
+			pending = append(pending, entry.Name())
+		}
+	}
+	return pending, nil
+}
+
+// ensureMigrationsTable создаёт таблицу schema_migrations и добавляет
+// колонку dirty в базы, созданные до её появления.
+func ensureMigrationsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			dirty INTEGER NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+
+	var hasDirty bool
+	rows, err := db.Query(`PRAGMA table_info(schema_migrations)`)
+	if err != nil {
+		return fmt.Errorf("inspect migrations table: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cid int
+		var name, colType string
+		var notNull, pk int
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return fmt.Errorf("scan migrations table info: %w", err)
+		}
+		if name == "dirty" {
+			hasDirty = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if !hasDirty {
+		if _, err := db.Exec(`ALTER TABLE schema_migrations ADD COLUMN dirty INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add dirty column: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkDirty отказывается работать с базой, где осталась незавершённая
+// миграция: её состояние неизвестно, и автоматика может всё усугубить.
+func checkDirty(db *sql.DB) error {
+	var version string
+	err := db.QueryRow("SELECT version FROM schema_migrations WHERE dirty = 1 LIMIT 1").Scan(&version)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check dirty migrations: %w", err)
+	}
+	return fmt.Errorf("миграция %s не завершена (dirty) — восстановите БД из бэкапа или разберитесь вручную", version)
+}
+
+// applyStatements выполняет команды одного миграционного файла
+// в единой транзакции.
+func applyStatements(db *sql.DB, content string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	for _, stmt := range splitStatements(content) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		if _, err := tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%w\nStatement: %s", err, stmt)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// versionPrefix возвращает номер миграции ("013") из имени файла
+// или строки вида "013_task_completions".
+func versionPrefix(version string) string {
+	if i := strings.IndexByte(version, '_'); i >= 0 {
+		return version[:i]
+	}
+	return strings.TrimSuffix(version, ".sql")
+}
+
 // splitStatements разбивает SQL на отдельные команды по точке с запятой,
 // учитывая, что точка с запятой внутри триггеров должна игнорироваться.
 func splitStatements(sql string) []string {
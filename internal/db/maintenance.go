@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Обслуживание долгоживущих SQLite-файлов: сжатие, проверка целостности,
+// обновление статистики планировщика и перестройка FTS-индексов.
+
+// Vacuum сжимает файл БД, возвращая освобождённое место ОС.
+func Vacuum(db *sql.DB) error {
+	if _, err := db.Exec(`VACUUM`); err != nil {
+		return fmt.Errorf("vacuum: %w", err)
+	}
+	return nil
+}
+
+// Backup снимает консистентную копию БД в файл target через VACUUM INTO.
+// Работает на выделенном соединении с busy_timeout, чтобы не падать
+// из-за параллельных записей других обработчиков.
+func Backup(ctx context.Context, db *sql.DB, target string) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("backup conn: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA busy_timeout = 5000`); err != nil {
+		return fmt.Errorf("backup busy_timeout: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `VACUUM INTO ?`, target); err != nil {
+		return fmt.Errorf("vacuum into %s: %w", target, err)
+	}
+	return nil
+}
+
+// IntegrityCheck проверяет целостность БД. Возвращает ошибку,
+// если PRAGMA integrity_check нашла проблемы.
+func IntegrityCheck(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA integrity_check`)
+	if err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("scan integrity check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("integrity check: найдено проблем: %d (%s)", len(problems), problems[0])
+	}
+	return nil
+}
+
+// Analyze обновляет статистику для планировщика запросов.
+func Analyze(db *sql.DB) error {
+	if _, err := db.Exec(`ANALYZE`); err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+	return nil
+}
+
+// RebuildFTS перестраивает полнотекстовые индексы из таблиц-источников.
+func RebuildFTS(db *sql.DB) error {
+	for _, table := range []string{"lessons_fts", "notes_fts"} {
+		if _, err := db.Exec(fmt.Sprintf(`INSERT INTO %s(%s) VALUES('rebuild')`, table, table)); err != nil {
+			return fmt.Errorf("rebuild %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// StartMaintenance запускает фоновое периодическое обслуживание:
+// ANALYZE, PRAGMA optimize и чекпоинт WAL-журнала. VACUUM намеренно
+// не выполняется автоматически — он блокирует БД и запускается вручную
+// через cmd/dbtool.
+func StartMaintenance(ctx context.Context, db *sql.DB, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := maintain(db); err != nil {
+					log.Printf("Ошибка обслуживания БД: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func maintain(db *sql.DB) error {
+	if err := Analyze(db); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`PRAGMA optimize`); err != nil {
+		return fmt.Errorf("optimize: %w", err)
+	}
+	if _, err := db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return fmt.Errorf("wal checkpoint: %w", err)
+	}
+	return nil
+}
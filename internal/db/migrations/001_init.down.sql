@@ -0,0 +1,12 @@
+-- Откат 001: полный снос базовой схемы.
+DROP TRIGGER IF EXISTS lessons_au;
+DROP TRIGGER IF EXISTS lessons_ad;
+DROP TRIGGER IF EXISTS lessons_ai;
+DROP TABLE IF EXISTS lessons_fts;
+DROP TABLE IF EXISTS submissions;
+DROP TABLE IF EXISTS notes;
+DROP TABLE IF EXISTS progress;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS lesson_sections;
+DROP TABLE IF EXISTS lessons;
+DROP TABLE IF EXISTS modules;
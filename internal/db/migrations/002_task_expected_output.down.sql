@@ -0,0 +1,3 @@
+-- Откат 002: убираем поля ожидаемого вывода и паттернов.
+ALTER TABLE tasks DROP COLUMN required_patterns;
+ALTER TABLE tasks DROP COLUMN expected_output;
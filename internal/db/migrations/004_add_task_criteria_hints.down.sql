@@ -0,0 +1,3 @@
+-- Откат 004: убираем поля criteria и hints.
+ALTER TABLE tasks DROP COLUMN hints;
+ALTER TABLE tasks DROP COLUMN criteria;
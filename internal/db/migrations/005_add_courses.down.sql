@@ -0,0 +1,4 @@
+-- Откат 005: убираем курсы и связь модулей с ними.
+DROP INDEX IF EXISTS idx_modules_course;
+ALTER TABLE modules DROP COLUMN course_id;
+DROP TABLE IF EXISTS courses;
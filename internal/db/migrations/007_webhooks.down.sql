@@ -0,0 +1,3 @@
+-- Откат 007: убираем вебхуки и журнал доставки.
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
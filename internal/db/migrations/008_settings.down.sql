@@ -0,0 +1,2 @@
+-- Откат 008: убираем таблицу настроек.
+DROP TABLE IF EXISTS settings;
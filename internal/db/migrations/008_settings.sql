@@ -0,0 +1,7 @@
+-- Настройки приложения (ключ/значение)
+-- Используются для opt-in уведомлений и других переключаемых опций
+CREATE TABLE IF NOT EXISTS settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL DEFAULT '',
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,5 @@
+-- Откат 009: убираем полнотекстовый индекс заметок.
+DROP TRIGGER IF EXISTS notes_au;
+DROP TRIGGER IF EXISTS notes_ad;
+DROP TRIGGER IF EXISTS notes_ai;
+DROP TABLE IF EXISTS notes_fts;
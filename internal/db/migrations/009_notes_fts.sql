@@ -0,0 +1,23 @@
+-- Полнотекстовый поиск по заметкам пользователя
+CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(
+    note_md,
+    content='notes',
+    content_rowid='lesson_id'
+);
+
+-- Триггеры для синхронизации FTS
+CREATE TRIGGER IF NOT EXISTS notes_ai AFTER INSERT ON notes BEGIN
+    INSERT INTO notes_fts(rowid, note_md) VALUES (new.lesson_id, new.note_md);
+END;
+
+CREATE TRIGGER IF NOT EXISTS notes_ad AFTER DELETE ON notes BEGIN
+    INSERT INTO notes_fts(notes_fts, rowid, note_md) VALUES('delete', old.lesson_id, old.note_md);
+END;
+
+CREATE TRIGGER IF NOT EXISTS notes_au AFTER UPDATE ON notes BEGIN
+    INSERT INTO notes_fts(notes_fts, rowid, note_md) VALUES('delete', old.lesson_id, old.note_md);
+    INSERT INTO notes_fts(rowid, note_md) VALUES (new.lesson_id, new.note_md);
+END;
+
+-- Индексируем уже существующие заметки
+INSERT INTO notes_fts(rowid, note_md) SELECT lesson_id, note_md FROM notes;
@@ -0,0 +1,2 @@
+-- Откат 010: убираем историю версий заметок.
+DROP TABLE IF EXISTS note_revisions;
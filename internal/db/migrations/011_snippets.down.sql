@@ -0,0 +1,2 @@
+-- Откат 011: убираем библиотеку фрагментов кода.
+DROP TABLE IF EXISTS snippets;
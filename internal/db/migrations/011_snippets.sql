@@ -0,0 +1,11 @@
+-- Личная библиотека фрагментов кода
+CREATE TABLE IF NOT EXISTS snippets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    code TEXT NOT NULL,
+    -- Теги через запятую ("goroutines,channels")
+    tags TEXT NOT NULL DEFAULT '',
+    lesson_id INTEGER REFERENCES lessons(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Откат 012: убираем практические сессии и очередь повторения.
+DROP TABLE IF EXISTS review_queue;
+DROP TABLE IF EXISTS practice_sessions;
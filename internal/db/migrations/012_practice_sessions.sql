@@ -0,0 +1,19 @@
+-- Практические сессии (N случайных нерешённых заданий на время)
+CREATE TABLE IF NOT EXISTS practice_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- ID заданий сессии через запятую
+    task_ids TEXT NOT NULL,
+    duration_min INTEGER NOT NULL DEFAULT 15,
+    started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    finished_at DATETIME,
+    solved_count INTEGER NOT NULL DEFAULT 0,
+    total_count INTEGER NOT NULL DEFAULT 0
+);
+
+-- Очередь интервального повторения: сюда попадают задания по итогам сессий
+CREATE TABLE IF NOT EXISTS review_queue (
+    task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    due_at DATETIME NOT NULL,
+    interval_days INTEGER NOT NULL DEFAULT 1,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Откат 013: убираем учёт выполненных заданий.
+-- Пересчёт очков в progress, сделанный миграцией вверх, не отменяется.
+DROP TABLE IF EXISTS task_completions;
@@ -0,0 +1,27 @@
+-- Учёт решённых заданий: одна запись на задание.
+-- Очки считаются из completions, поэтому повторные успешные
+-- проверки не дублируют начисления.
+CREATE TABLE IF NOT EXISTS task_completions (
+    task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    points_awarded INTEGER NOT NULL DEFAULT 0,
+    completed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_completions_lesson ON task_completions(lesson_id);
+
+-- Бэкофилл из истории отправок: задание считается решённым,
+-- если есть хотя бы одна успешная отправка
+INSERT OR IGNORE INTO task_completions (task_id, lesson_id, points_awarded, completed_at)
+SELECT s.task_id, t.lesson_id, t.points, MIN(s.created_at)
+FROM submissions s
+JOIN tasks t ON t.id = s.task_id
+WHERE s.status = 'success'
+GROUP BY s.task_id;
+
+-- Пересчитываем заработанные очки по урокам из completions
+UPDATE progress
+SET points_earned = COALESCE(
+    (SELECT SUM(tc.points_awarded) FROM task_completions tc WHERE tc.lesson_id = progress.lesson_id),
+    0
+);
@@ -0,0 +1,2 @@
+-- Откат 014: убираем длительность выполнения решений.
+ALTER TABLE submissions DROP COLUMN duration_ms;
@@ -0,0 +1,3 @@
+-- Длительность выполнения решения в миллисекундах.
+-- Нужна для статистики по заданиям (среднее время работы кода).
+ALTER TABLE submissions ADD COLUMN duration_ms INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Откат 015: убираем мягкое удаление. Содержимое «Корзины»
+-- снова станет видимым — при необходимости очистите её заранее.
+ALTER TABLE lessons DROP COLUMN deleted_at;
+ALTER TABLE modules DROP COLUMN deleted_at;
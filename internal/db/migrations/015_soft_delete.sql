@@ -0,0 +1,5 @@
+-- Мягкое удаление контента: вместо жёсткого DELETE выставляется deleted_at.
+-- Удалённые модули и уроки попадают в «Корзину», откуда их можно
+-- восстановить или удалить окончательно.
+ALTER TABLE modules ADD COLUMN deleted_at DATETIME;
+ALTER TABLE lessons ADD COLUMN deleted_at DATETIME;
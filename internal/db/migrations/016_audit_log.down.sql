@@ -0,0 +1,2 @@
+-- Откат 016: убираем журнал аудита.
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,3 @@
+-- Откат 017: удаление журнала ошибок.
+DROP INDEX IF EXISTS idx_errors_last_seen;
+DROP TABLE IF EXISTS errors;
@@ -0,0 +1,15 @@
+-- Журнал ошибок сервера (паники в обработчиках).
+-- Одинаковые ошибки схлопываются по отпечатку: хранится счётчик
+-- повторений и время первого/последнего появления.
+CREATE TABLE IF NOT EXISTS errors (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fingerprint TEXT NOT NULL UNIQUE,
+    message TEXT NOT NULL,
+    stack TEXT NOT NULL DEFAULT '',
+    path TEXT NOT NULL DEFAULT '',
+    count INTEGER NOT NULL DEFAULT 1,
+    first_seen DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_seen DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_errors_last_seen ON errors(last_seen);
@@ -0,0 +1,2 @@
+-- Откат 018: убираем сохранённое оглавление секций.
+ALTER TABLE lesson_sections DROP COLUMN outline;
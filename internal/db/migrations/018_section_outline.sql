@@ -0,0 +1,4 @@
+-- Сохранённое оглавление секции: JSON-массив заголовков Markdown.
+-- Вычисляется при сохранении секции, чтобы не парсить Markdown
+-- на каждый показ страницы урока.
+ALTER TABLE lesson_sections ADD COLUMN outline TEXT NOT NULL DEFAULT '';
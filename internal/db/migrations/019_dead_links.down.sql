@@ -0,0 +1,3 @@
+-- Откат 019: убираем журнал битых ссылок.
+DROP INDEX IF EXISTS idx_dead_links_lesson;
+DROP TABLE IF EXISTS dead_links;
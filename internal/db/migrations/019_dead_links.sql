@@ -0,0 +1,15 @@
+-- Журнал битых внешних ссылок в контенте уроков.
+-- Заполняется проверкой ссылок (cmd/lint-content --links и фоновая
+-- проверка в сервере); починившиеся ссылки из журнала удаляются.
+CREATE TABLE IF NOT EXISTS dead_links (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    first_seen DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_checked DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(lesson_id, url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_links_lesson ON dead_links(lesson_id);
@@ -0,0 +1,4 @@
+-- Откат 020: убираем атрибуцию источника.
+ALTER TABLE lessons DROP COLUMN source_name;
+ALTER TABLE lessons DROP COLUMN source_author;
+ALTER TABLE lessons DROP COLUMN source_license;
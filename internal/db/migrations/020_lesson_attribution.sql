@@ -0,0 +1,6 @@
+-- Атрибуция источника урока: название сайта, автор и лицензия.
+-- Заполняется при импорте из конфигурации источников
+-- (см. ingest --attribution) и показывается внизу страницы урока.
+ALTER TABLE lessons ADD COLUMN source_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE lessons ADD COLUMN source_author TEXT NOT NULL DEFAULT '';
+ALTER TABLE lessons ADD COLUMN source_license TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Откат 021: убираем дневной роллап аналитики.
+DROP TABLE IF EXISTS analytics_daily;
@@ -0,0 +1,10 @@
+-- Предагрегированная дневная статистика для админской аналитики.
+-- Перестраивается пакетом analytics из сырых таблиц (submissions,
+-- progress, task_completions): страница дашборда читает только роллап.
+CREATE TABLE IF NOT EXISTS analytics_daily (
+    day TEXT PRIMARY KEY,
+    lessons_touched INTEGER NOT NULL DEFAULT 0,
+    submissions INTEGER NOT NULL DEFAULT 0,
+    passes INTEGER NOT NULL DEFAULT 0,
+    tasks_completed INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,2 @@
+-- Откат 022: убираем журнал событий обучения.
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,14 @@
+-- Журнал событий обучения: открытия уроков, попытки и решения заданий,
+-- подсказки и сохранения заметок. Только вставка, без обновлений —
+-- сырьё для аналитики, рекомендаций и стриков, которое не восстановить
+-- из мутабельного прогресса.
+CREATE TABLE IF NOT EXISTS events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK (kind IN ('lesson_viewed', 'task_attempted', 'task_passed', 'hint_used', 'note_saved')),
+    lesson_id INTEGER,
+    task_id INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_kind ON events(kind, created_at);
+CREATE INDEX IF NOT EXISTS idx_events_created ON events(created_at);
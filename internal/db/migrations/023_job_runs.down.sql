@@ -0,0 +1,2 @@
+-- Откат 023: убираем историю запусков фоновых задач.
+DROP TABLE IF EXISTS job_runs;
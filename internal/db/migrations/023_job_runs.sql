@@ -0,0 +1,12 @@
+-- История запусков фоновых задач планировщика (internal/sched).
+-- Показывается на странице /admin/jobs вместе с кнопками ручного запуска.
+CREATE TABLE IF NOT EXISTS job_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job TEXT NOT NULL,
+    status TEXT NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    duration_ms INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_started ON job_runs(started_at);
@@ -0,0 +1,3 @@
+-- Откат 024: убираем план чтения.
+DROP TABLE IF EXISTS plans;
+DELETE FROM settings WHERE key IN ('plan.target_date', 'plan.minutes_per_day');
@@ -0,0 +1,11 @@
+-- План чтения: раскладка непройденных уроков по дням (internal/plan).
+-- Перегенерация заменяет план целиком; целевая дата и минуты в день
+-- хранятся в settings (plan.target_date, plan.minutes_per_day).
+CREATE TABLE IF NOT EXISTS plans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    day TEXT NOT NULL,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    minutes INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_plans_day ON plans(day);
@@ -0,0 +1,2 @@
+-- Откат прогресса по capstone-проектам.
+DROP TABLE IF EXISTS project_progress;
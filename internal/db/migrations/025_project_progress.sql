@@ -0,0 +1,10 @@
+-- Прогресс по capstone-проектам: отмеченные пункты чек-листа.
+-- Пункты идентифицируются порядковым номером "- [ ]" в тексте ТЗ
+-- (lessons_mdx/Проекты), поэтому правка чек-листа сдвигает отметки.
+CREATE TABLE IF NOT EXISTS project_progress (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id TEXT NOT NULL,
+    milestone_index INTEGER NOT NULL,
+    completed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(project_id, milestone_index)
+);
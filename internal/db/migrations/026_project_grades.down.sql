@@ -0,0 +1,2 @@
+-- Откат оценок capstone-проектов.
+DROP TABLE IF EXISTS project_grades;
@@ -0,0 +1,12 @@
+-- Ручная оценка capstone-проектов по рубрике (/admin/grading).
+-- Критерии и максимумы баллов определяются в коде рядом с проектами;
+-- здесь хранятся выставленные баллы и комментарии проверяющего.
+CREATE TABLE IF NOT EXISTS project_grades (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id TEXT NOT NULL,
+    criterion TEXT NOT NULL,
+    points INTEGER NOT NULL DEFAULT 0,
+    comment TEXT NOT NULL DEFAULT '',
+    graded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(project_id, criterion)
+);
@@ -0,0 +1,4 @@
+-- Откат банка вопросов для собеседований.
+DROP INDEX IF EXISTS idx_interview_reviews_question;
+DROP TABLE IF EXISTS interview_reviews;
+DROP TABLE IF EXISTS interview_questions;
@@ -0,0 +1,24 @@
+-- Банк вопросов для подготовки к собеседованиям (/interview).
+-- Вопросы импортируются из Markdown-файлов (content interview --dir),
+-- самооценка ответов в режиме мок-интервью копится в interview_reviews.
+CREATE TABLE IF NOT EXISTS interview_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    topic TEXT NOT NULL,
+    title TEXT NOT NULL,
+    difficulty TEXT NOT NULL DEFAULT 'medium',
+    question_md TEXT NOT NULL DEFAULT '',
+    answer_md TEXT NOT NULL DEFAULT '',
+    lesson_slug TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(topic, title)
+);
+
+-- score: 0 — не знал, 1 — ответил частично, 2 — знал уверенно.
+CREATE TABLE IF NOT EXISTS interview_reviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question_id INTEGER NOT NULL REFERENCES interview_questions(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL,
+    reviewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_reviews_question ON interview_reviews(question_id);
@@ -0,0 +1,2 @@
+-- Откат результатов ката.
+DROP TABLE IF EXISTS kata_results;
@@ -0,0 +1,10 @@
+-- Результаты ката — упражнений со структурными ограничениями (/katas).
+-- Сами ката определяются в коде (internal/practice/kata.go), в БД —
+-- только прогресс: лучший результат и число попыток.
+CREATE TABLE IF NOT EXISTS kata_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kata_slug TEXT NOT NULL UNIQUE,
+    passed INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
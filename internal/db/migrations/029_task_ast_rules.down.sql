@@ -0,0 +1,2 @@
+-- Откат переименования ast_rules.
+ALTER TABLE tasks RENAME COLUMN ast_rules TO required_patterns;
@@ -0,0 +1,5 @@
+-- Текстовые required_patterns (поиск подстроки в коде) заменены на
+-- структурные правила astcheck (internal/practice/astcheck): записи
+-- вида require:switch, require-func:square, forbid-call:fmt.Sprintf,
+-- разделённые |. Подстроку можно было обмануть комментарием — AST нет.
+ALTER TABLE tasks RENAME COLUMN required_patterns TO ast_rules;
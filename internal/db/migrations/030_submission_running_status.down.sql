@@ -0,0 +1,23 @@
+-- Откат: возвращаем CHECK без 'running'; зависшие running-отправки
+-- становятся pending.
+CREATE TABLE submissions_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    status TEXT NOT NULL CHECK(status IN ('pending', 'success', 'error', 'timeout')),
+    stdout TEXT,
+    stderr TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    duration_ms INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO submissions_old (id, task_id, code, status, stdout, stderr, created_at, duration_ms)
+    SELECT id, task_id, code,
+           CASE WHEN status = 'running' THEN 'pending' ELSE status END,
+           stdout, stderr, created_at, duration_ms
+    FROM submissions;
+
+DROP TABLE submissions;
+ALTER TABLE submissions_old RENAME TO submissions;
+
+CREATE INDEX IF NOT EXISTS idx_submissions_task ON submissions(task_id);
@@ -0,0 +1,22 @@
+-- ClaimSubmission переводит отправку из pending в running, но CHECK из
+-- 001_init статус 'running' не допускал — каждая проверка решения
+-- падала на ограничении. Изменить CHECK в SQLite можно только
+-- пересборкой таблицы.
+CREATE TABLE submissions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    status TEXT NOT NULL CHECK(status IN ('pending', 'running', 'success', 'error', 'timeout')),
+    stdout TEXT,
+    stderr TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    duration_ms INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO submissions_new (id, task_id, code, status, stdout, stderr, created_at, duration_ms)
+    SELECT id, task_id, code, status, stdout, stderr, created_at, duration_ms FROM submissions;
+
+DROP TABLE submissions;
+ALTER TABLE submissions_new RENAME TO submissions;
+
+CREATE INDEX IF NOT EXISTS idx_submissions_task ON submissions(task_id);
@@ -0,0 +1,2 @@
+-- Откат настроек сравнения вывода.
+ALTER TABLE tasks DROP COLUMN output_options;
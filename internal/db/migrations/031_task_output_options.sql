@@ -0,0 +1,4 @@
+-- Настройки сравнения вывода для задания: флаги через |, например
+-- case-insensitive|ignore-line-order|float-tolerance=0.001.
+-- Пробелы по краям строк и хвостовой перевод строки игнорируются всегда.
+ALTER TABLE tasks ADD COLUMN output_options TEXT NOT NULL DEFAULT '';
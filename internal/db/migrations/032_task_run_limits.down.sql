@@ -0,0 +1,3 @@
+-- Откат лимитов выполнения заданий.
+ALTER TABLE tasks DROP COLUMN run_timeout_sec;
+ALTER TABLE tasks DROP COLUMN memory_limit_mb;
@@ -0,0 +1,6 @@
+-- Лимиты выполнения для задания: таймаут в секундах и лимит памяти
+-- в мегабайтах. 0 означает значения по умолчанию (RunTimeout и без
+-- ограничения памяти) — тяжёлым задачам (бенчмарки, конкурентность)
+-- можно выдать больший бюджет, не трогая быстрый дефолт для основ.
+ALTER TABLE tasks ADD COLUMN run_timeout_sec INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN memory_limit_mb INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Откат типов заданий.
+ALTER TABLE tasks DROP COLUMN kind;
+ALTER TABLE tasks DROP COLUMN canonical_output;
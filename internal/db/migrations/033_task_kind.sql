@@ -0,0 +1,6 @@
+-- Тип задания: code — обычное задание с кодом, predict_output —
+-- «предскажи вывод»: учащийся читает эталонный сниппет (starter_code)
+-- и вводит ожидаемый вывод текстом. Сниппет выполняется один раз,
+-- его вывод кешируется в canonical_output.
+ALTER TABLE tasks ADD COLUMN kind TEXT NOT NULL DEFAULT 'code';
+ALTER TABLE tasks ADD COLUMN canonical_output TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Откат пошаговых заданий.
+DROP TABLE IF EXISTS guided_progress;
+DROP INDEX IF EXISTS idx_task_steps_task;
+DROP TABLE IF EXISTS task_steps;
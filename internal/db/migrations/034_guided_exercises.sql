@@ -0,0 +1,26 @@
+-- Пошаговые задания (kind = guided): у задания несколько шагов со
+-- своими условиями и проверками. Следующий шаг открывается после
+-- прохождения предыдущего, а код учащегося переносится на него
+-- сервером как стартовый.
+CREATE TABLE IF NOT EXISTS task_steps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    prompt_md TEXT NOT NULL DEFAULT '',
+    expected_output TEXT NOT NULL DEFAULT '',
+    tests_go TEXT NOT NULL DEFAULT '',
+    order_index INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_steps_task ON task_steps(task_id);
+
+-- Прогресс по шагам: последний код учащегося и отметка о прохождении.
+CREATE TABLE IF NOT EXISTS guided_progress (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL,
+    step_index INTEGER NOT NULL,
+    code TEXT NOT NULL DEFAULT '',
+    passed INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(task_id, step_index)
+);
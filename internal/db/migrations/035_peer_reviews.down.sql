@@ -0,0 +1,3 @@
+-- Откат взаимного код-ревью.
+DROP INDEX IF EXISTS idx_peer_reviews_task;
+DROP TABLE IF EXISTS peer_reviews;
@@ -0,0 +1,17 @@
+-- Взаимное код-ревью: после решения задания учащийся получает 1–2
+-- анонимных успешных решения того же задания и рецензирует их по
+-- короткой рубрике. За завершённую рецензию начисляются бонусные очки.
+-- Оценки по критериям: 0 — плохо, 1 — нормально, 2 — хорошо.
+CREATE TABLE IF NOT EXISTS peer_reviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    submission_id INTEGER NOT NULL UNIQUE REFERENCES submissions(id) ON DELETE CASCADE,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    readability INTEGER NOT NULL,
+    correctness INTEGER NOT NULL,
+    idiomatic INTEGER NOT NULL,
+    comment_md TEXT NOT NULL DEFAULT '',
+    points INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_peer_reviews_task ON peer_reviews(task_id);
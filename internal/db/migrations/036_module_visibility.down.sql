@@ -0,0 +1,2 @@
+-- Откат видимости модулей.
+ALTER TABLE modules DROP COLUMN visibility;
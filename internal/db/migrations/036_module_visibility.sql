@@ -0,0 +1,4 @@
+-- Видимость модулей для публичного режима сервера (--public):
+-- public — уроки видны всем, registered — нужен вход по ключу доступа,
+-- cohort — только для участников когорты (ключ когорты).
+ALTER TABLE modules ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
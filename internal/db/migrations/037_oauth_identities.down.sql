@@ -0,0 +1,2 @@
+-- Откат внешних OAuth-аккаунтов.
+DROP TABLE IF EXISTS oauth_identities;
@@ -0,0 +1,14 @@
+-- Внешние OAuth-аккаунты для входа в публичном режиме (GitHub, Google).
+-- Пара (provider, provider_id) уникальна: первый вход создаёт запись,
+-- повторные обновляют профиль и время последнего входа.
+CREATE TABLE IF NOT EXISTS oauth_identities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    provider TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    login TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(provider, provider_id)
+);
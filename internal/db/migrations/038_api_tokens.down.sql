@@ -0,0 +1,2 @@
+-- Откат API-токенов.
+DROP TABLE IF EXISTS api_tokens;
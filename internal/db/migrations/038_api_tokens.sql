@@ -0,0 +1,13 @@
+-- API-токены для REST API: внешний клиент передаёт токен в заголовке
+-- Authorization: Bearer. Хранится только SHA-256 хэш; сам токен
+-- показывается один раз при создании. Счётчик запросов и время
+-- последнего использования обновляются на каждый запрос.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    rate_limit_per_min INTEGER NOT NULL DEFAULT 0, -- 0 — только общий лимит сервера
+    request_count INTEGER NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Откат версий начального кода.
+ALTER TABLE tasks DROP COLUMN starter_version;
+ALTER TABLE submissions DROP COLUMN starter_version;
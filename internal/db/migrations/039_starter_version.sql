@@ -0,0 +1,6 @@
+-- Версия начального кода задания. При реимпорте урока версия
+-- переносится со старого задания (по заголовку) и растёт, если автор
+-- изменил starter_code. Отправка запоминает версию на момент решения:
+-- так страница урока видит, что задание обновилось с последней попытки.
+ALTER TABLE tasks ADD COLUMN starter_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE submissions ADD COLUMN starter_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Откат курса урока.
+DROP INDEX IF EXISTS idx_lessons_course;
+ALTER TABLE lessons DROP COLUMN course_id;
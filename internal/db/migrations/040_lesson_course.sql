@@ -0,0 +1,7 @@
+-- Курс урока: копия course_id модуля для выборок и статистики
+-- в рамках одного курса (несколько независимых курсов в инстансе).
+ALTER TABLE lessons ADD COLUMN course_id INTEGER REFERENCES courses(id);
+
+UPDATE lessons SET course_id = (SELECT course_id FROM modules WHERE modules.id = lessons.module_id);
+
+CREATE INDEX IF NOT EXISTS idx_lessons_course ON lessons(course_id);
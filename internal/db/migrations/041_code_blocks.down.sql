@@ -0,0 +1,5 @@
+-- Откат индекса блоков кода.
+DROP TRIGGER IF EXISTS code_blocks_ai;
+DROP TRIGGER IF EXISTS code_blocks_ad;
+DROP TABLE IF EXISTS code_blocks_fts;
+DROP TABLE IF EXISTS code_blocks;
@@ -0,0 +1,30 @@
+-- Блоки кода, извлечённые из секций уроков: отдельный FTS-индекс
+-- для поиска только по коду (scope=code на странице поиска).
+CREATE TABLE IF NOT EXISTS code_blocks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    section_id INTEGER NOT NULL REFERENCES lesson_sections(id) ON DELETE CASCADE,
+    language TEXT NOT NULL DEFAULT '',
+    code TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_code_blocks_lesson ON code_blocks(lesson_id);
+CREATE INDEX IF NOT EXISTS idx_code_blocks_section ON code_blocks(section_id);
+
+-- Точка и подчёркивание входят в токены, чтобы запросы вида
+-- sync.WaitGroup находились целиком.
+CREATE VIRTUAL TABLE IF NOT EXISTS code_blocks_fts USING fts5(
+    code,
+    content='code_blocks',
+    content_rowid='id',
+    tokenize="unicode61 tokenchars '._'"
+);
+
+-- Триггеры для синхронизации FTS (блоки только создаются и удаляются)
+CREATE TRIGGER IF NOT EXISTS code_blocks_ai AFTER INSERT ON code_blocks BEGIN
+    INSERT INTO code_blocks_fts(rowid, code) VALUES (new.id, new.code);
+END;
+
+CREATE TRIGGER IF NOT EXISTS code_blocks_ad AFTER DELETE ON code_blocks BEGIN
+    INSERT INTO code_blocks_fts(code_blocks_fts, rowid, code) VALUES('delete', old.id, old.code);
+END;
@@ -0,0 +1,3 @@
+-- Откат личного словаря терминов
+DROP INDEX IF EXISTS idx_vocabulary_due;
+DROP TABLE IF EXISTS vocabulary;
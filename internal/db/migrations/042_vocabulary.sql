@@ -0,0 +1,16 @@
+-- Личный словарь терминов Go: сюда попадают слова, с которыми возникли
+-- трудности. Словарь питает колоду интервального повторения, поэтому у
+-- каждого термина те же поля расписания, что у review_queue.
+CREATE TABLE IF NOT EXISTS vocabulary (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    term TEXT NOT NULL UNIQUE,
+    definition TEXT NOT NULL DEFAULT '',
+    -- Урок, из которого добавлен термин (NULL — добавлен вручную)
+    lesson_id INTEGER REFERENCES lessons(id) ON DELETE SET NULL,
+    due_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    reviews INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_vocabulary_due ON vocabulary(due_at);
@@ -0,0 +1,3 @@
+-- Откат жалоб на ошибки в материалах
+DROP INDEX IF EXISTS idx_issues_status;
+DROP TABLE IF EXISTS issues;
@@ -0,0 +1,19 @@
+-- Жалобы на ошибки в материалах: кнопка «Сообщить об ошибке» на
+-- страницах уроков и заданий. Разбираются в админке (/admin/issues)
+-- со статусами open → accepted → fixed.
+CREATE TABLE IF NOT EXISTS issues (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER REFERENCES lessons(id) ON DELETE SET NULL,
+    -- Задание, к которому относится жалоба (NULL — жалоба на урок)
+    task_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
+    -- Вид проблемы: typo, broken_test, wrong_output, other
+    kind TEXT NOT NULL DEFAULT 'other',
+    message TEXT NOT NULL,
+    -- Снимок контекста на момент жалобы (URL, заголовки урока и задания)
+    context TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_issues_status ON issues(status, created_at);
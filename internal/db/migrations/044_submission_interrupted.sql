@@ -0,0 +1,23 @@
+-- Статус interrupted для отправок, чья проверка оборвалась перезапуском
+-- сервера: раньше они навсегда оставались в pending/running и выглядели
+-- как «на проверке». Изменить CHECK в SQLite можно только пересборкой
+-- таблицы.
+CREATE TABLE submissions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    status TEXT NOT NULL CHECK(status IN ('pending', 'running', 'success', 'error', 'timeout', 'interrupted')),
+    stdout TEXT,
+    stderr TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    starter_version INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO submissions_new (id, task_id, code, status, stdout, stderr, created_at, duration_ms, starter_version)
+    SELECT id, task_id, code, status, stdout, stderr, created_at, duration_ms, starter_version FROM submissions;
+
+DROP TABLE submissions;
+ALTER TABLE submissions_new RENAME TO submissions;
+
+CREATE INDEX IF NOT EXISTS idx_submissions_task ON submissions(task_id);
@@ -0,0 +1,2 @@
+-- Откат требования версии Go у уроков.
+ALTER TABLE lessons DROP COLUMN min_go_version;
@@ -0,0 +1,5 @@
+-- Минимальная версия Go, на которую рассчитан урок (например, дженерики
+-- требуют 1.18+). Пустая строка — урок работает на любой версии.
+-- Сервер сверяет версию с установленным toolchain и помечает уроки,
+-- которые локальное окружение не выполнит.
+ALTER TABLE lessons ADD COLUMN min_go_version TEXT NOT NULL DEFAULT '';
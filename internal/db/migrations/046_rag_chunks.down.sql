@@ -0,0 +1,3 @@
+-- Откат векторного индекса «спросить курс».
+DROP INDEX IF EXISTS idx_rag_chunks_lesson;
+DROP TABLE IF EXISTS rag_chunks;
@@ -0,0 +1,14 @@
+-- Векторный индекс для функции «спросить курс»: секции уроков режутся
+-- на фрагменты, каждый хранит эмбеддинг (little-endian float32 BLOB).
+-- Поиск по вопросу идёт косинусной близостью в приложении — объём
+-- курса невелик, отдельный векторный движок не нужен.
+CREATE TABLE IF NOT EXISTS rag_chunks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    embedding BLOB NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rag_chunks_lesson ON rag_chunks(lesson_id);
@@ -0,0 +1,3 @@
+-- Откат LLM-подсказок по отправкам.
+ALTER TABLE submissions DROP COLUMN hint;
+ALTER TABLE submissions DROP COLUMN hint_at;
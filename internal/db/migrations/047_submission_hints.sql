@@ -0,0 +1,4 @@
+-- LLM-подсказки по проваленным проверкам: текст подсказки хранится
+-- рядом с отправкой, hint_at нужен для суточной квоты.
+ALTER TABLE submissions ADD COLUMN hint TEXT NOT NULL DEFAULT '';
+ALTER TABLE submissions ADD COLUMN hint_at DATETIME;
@@ -0,0 +1,23 @@
+-- Откат типа секции 'cheatsheet'
+
+-- 1. Удаляем секции-шпаргалки
+DELETE FROM lesson_sections WHERE kind = 'cheatsheet';
+
+-- 2. Пересоздаём таблицу со старым constraint
+CREATE TABLE lesson_sections_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK(kind IN ('overview', 'theory', 'syntax', 'examples', 'pitfalls', 'links', 'extra')),
+    title TEXT NOT NULL,
+    body_md TEXT NOT NULL DEFAULT '',
+    order_index INTEGER NOT NULL DEFAULT 0,
+    outline TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO lesson_sections_new SELECT * FROM lesson_sections;
+
+DROP TABLE lesson_sections;
+
+ALTER TABLE lesson_sections_new RENAME TO lesson_sections;
+
+CREATE INDEX IF NOT EXISTS idx_lesson_sections_lesson ON lesson_sections(lesson_id);
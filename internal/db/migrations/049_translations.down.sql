@@ -0,0 +1,2 @@
+-- Откат переводов уроков.
+DROP TABLE IF EXISTS translations;
@@ -0,0 +1,17 @@
+-- Переводы уроков (RU↔EN). Строка с section_id = 0 хранит перевод
+-- заголовка урока, остальные — переводы секций. reviewed — флаг
+-- вычитки человеком: вычитанный перевод не перезаписывается машинным.
+CREATE TABLE translations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    section_id INTEGER NOT NULL DEFAULT 0,
+    lang TEXT NOT NULL CHECK(lang IN ('ru', 'en')),
+    title TEXT NOT NULL DEFAULT '',
+    body_md TEXT NOT NULL DEFAULT '',
+    reviewed INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(lesson_id, section_id, lang)
+);
+
+CREATE INDEX IF NOT EXISTS idx_translations_lesson ON translations(lesson_id);
@@ -0,0 +1,3 @@
+-- Откат результатов клавиатурного тренажёра
+DROP INDEX IF EXISTS idx_typing_results_lesson;
+DROP TABLE IF EXISTS typing_results;
@@ -0,0 +1,16 @@
+-- Результаты клавиатурного тренажёра: лучший результат по каждому
+-- примеру кода. Пример идентифицируется хэшем текста (code_key), а не
+-- id блока: индекс code_blocks перестраивается при редактировании
+-- урока, и id меняются.
+CREATE TABLE IF NOT EXISTS typing_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code_key TEXT NOT NULL UNIQUE,
+    lesson_id INTEGER NOT NULL,
+    accuracy REAL NOT NULL DEFAULT 0,
+    wpm REAL NOT NULL DEFAULT 0,
+    points INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_typing_results_lesson ON typing_results(lesson_id);
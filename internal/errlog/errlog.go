@@ -0,0 +1,100 @@
+// Package errlog — журнал ошибок сервера. Паники из обработчиков
+// записываются в таблицу errors; одинаковые ошибки схлопываются по
+// отпечатку, чтобы одна горячая проблема не забивала журнал.
+package errlog
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Entry — запись журнала ошибок.
+type Entry struct {
+	ID          int64
+	Fingerprint string
+	Message     string
+	Stack       string
+	Path        string
+	Count       int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+}
+
+// Log — журнал ошибок поверх общей БД.
+type Log struct {
+	db *sql.DB
+}
+
+// NewLog создаёт журнал ошибок.
+func NewLog(db *sql.DB) *Log {
+	return &Log{db: db}
+}
+
+// Record записывает ошибку и возвращает её отпечаток — короткий код,
+// который показывается пользователю на странице 500 и ищется в админке.
+func (l *Log) Record(message, stack, path string) (string, error) {
+	fingerprint := Fingerprint(message, path)
+	_, err := l.db.Exec(
+		`INSERT INTO errors (fingerprint, message, stack, path)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT(fingerprint) DO UPDATE SET
+		    count = count + 1,
+		    stack = excluded.stack,
+		    last_seen = CURRENT_TIMESTAMP`,
+		fingerprint, message, stack, path,
+	)
+	if err != nil {
+		return fingerprint, fmt.Errorf("record error entry: %w", err)
+	}
+	return fingerprint, nil
+}
+
+// MustRecord записывает ошибку, логируя сбой записи вместо его возврата:
+// журналирование не должно маскировать исходную панику.
+func (l *Log) MustRecord(message, stack, path string) string {
+	fingerprint, err := l.Record(message, stack, path)
+	if err != nil {
+		log.Printf("Ошибка записи в журнал ошибок: %v", err)
+	}
+	return fingerprint
+}
+
+// Recent возвращает последние ошибки, свежие первыми.
+func (l *Log) Recent(limit int) ([]Entry, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := l.db.Query(
+		`SELECT id, fingerprint, message, stack, path, count, first_seen, last_seen
+		 FROM errors
+		 ORDER BY last_seen DESC
+		 LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list error entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.ID, &e.Fingerprint, &e.Message, &e.Stack, &e.Path, &e.Count, &e.FirstSeen, &e.LastSeen); err != nil {
+			return nil, fmt.Errorf("scan error entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// Fingerprint считает короткий отпечаток ошибки по сообщению и пути.
+func Fingerprint(message, path string) string {
+	sum := sha256.Sum256([]byte(message + "\n" + path))
+	return hex.EncodeToString(sum[:4])
+}
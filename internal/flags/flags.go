@@ -0,0 +1,171 @@
+// Package flags — фича-флаги с хранением в БД (таблица settings,
+// ключи flag.*). Позволяют включать рискованные возможности постепенно:
+// сначала на одном стенде, потом везде. Список известных флагов
+// фиксирован в коде, значения переключаются через админку.
+package flags
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Имена известных флагов.
+const (
+	// Playground — запуск произвольного кода из браузера (/api/run).
+	Playground = "playground"
+	// LLMRewriter — LLM-переписывание текста уроков при импорте.
+	LLMRewriter = "llm-rewriter"
+	// Leaderboard — таблица лидеров по очкам.
+	Leaderboard = "leaderboard"
+	// LLMHints — LLM-подсказки по проваленным проверкам задач.
+	LLMHints = "llm-hints"
+)
+
+// Flag — описание фича-флага.
+type Flag struct {
+	Name        string
+	Description string
+	// Default — значение, пока флаг не переключали через админку.
+	Default bool
+}
+
+// known — реестр флагов. Новый флаг добавляется сюда, значение по
+// умолчанию должно сохранять текущее поведение.
+var known = []Flag{
+	{Name: Playground, Description: "Запуск произвольного кода из браузера", Default: true},
+	{Name: LLMRewriter, Description: "LLM-переписывание уроков при импорте", Default: false},
+	{Name: Leaderboard, Description: "Таблица лидеров по очкам", Default: false},
+	{Name: LLMHints, Description: "LLM-подсказки по проваленным проверкам", Default: false},
+}
+
+// DefaultEnabled возвращает значение флага по умолчанию.
+// Используется, когда сервис флагов не подключён.
+func DefaultEnabled(name string) bool {
+	for _, f := range known {
+		if f.Name == name {
+			return f.Default
+		}
+	}
+	return false
+}
+
+// cacheTTL — как долго значения флагов живут в памяти.
+// Флаги читаются на каждый запрос, а меняются редко.
+const cacheTTL = 5 * time.Second
+
+// State — флаг вместе с текущим значением.
+type State struct {
+	Flag
+	Enabled bool
+}
+
+// Service читает и переключает фича-флаги.
+type Service struct {
+	db *sql.DB
+
+	mu       sync.Mutex
+	cache    map[string]bool
+	cachedAt time.Time
+}
+
+// NewService создаёт сервис фича-флагов.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// Enabled сообщает, включён ли флаг. Неизвестный флаг выключен;
+// при ошибке чтения БД возвращается значение по умолчанию.
+func (s *Service) Enabled(ctx context.Context, name string) bool {
+	values := s.values(ctx)
+	if enabled, ok := values[name]; ok {
+		return enabled
+	}
+	return false
+}
+
+// All возвращает все известные флаги с текущими значениями (для админки).
+func (s *Service) All(ctx context.Context) []State {
+	values := s.values(ctx)
+	states := make([]State, 0, len(known))
+	for _, f := range known {
+		states = append(states, State{Flag: f, Enabled: values[f.Name]})
+	}
+	return states
+}
+
+// SetEnabled переключает флаг. Неизвестное имя — ошибка.
+func (s *Service) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	if !isKnown(name) {
+		return fmt.Errorf("неизвестный флаг: %s", name)
+	}
+	value := "0"
+	if enabled {
+		value = "1"
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO settings (key, value) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP`,
+		settingKey(name), value,
+	)
+	if err != nil {
+		return fmt.Errorf("set flag: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cachedAt = time.Time{} // сбрасываем кэш, чтобы переключение подействовало сразу
+	s.mu.Unlock()
+	return nil
+}
+
+// values возвращает значения всех известных флагов с коротким кэшем.
+func (s *Service) values(ctx context.Context) map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache != nil && time.Since(s.cachedAt) < cacheTTL {
+		return s.cache
+	}
+
+	values := make(map[string]bool, len(known))
+	for _, f := range known {
+		values[f.Name] = f.Default
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT key, value FROM settings WHERE key LIKE 'flag.%'`)
+	if err != nil {
+		return values
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			continue
+		}
+		name := key[len("flag."):]
+		if isKnown(name) {
+			values[name] = value == "1"
+		}
+	}
+
+	s.cache = values
+	s.cachedAt = time.Now()
+	return values
+}
+
+func settingKey(name string) string {
+	return "flag." + name
+}
+
+func isKnown(name string) bool {
+	for _, f := range known {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
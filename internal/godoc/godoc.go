@@ -0,0 +1,101 @@
+// Package godoc отдаёт документацию стандартной библиотеки локально,
+// без обращения к pkg.go.dev: текст берётся из установленного Go
+// toolchain командой «go doc -all». Подсистема опциональна и
+// включается флагом --offline-docs — для закрытых сетей, где внешние
+// ссылки из уроков не открываются.
+package godoc
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// docTimeout — предел на один запуск go doc.
+const docTimeout = 10 * time.Second
+
+// Service кеширует отрендеренную документацию пакетов. Документация
+// toolchain не меняется за время жизни процесса, поэтому кеш вечный.
+type Service struct {
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewService создаёт сервис локальной документации.
+func NewService() *Service {
+	return &Service{cache: make(map[string]string)}
+}
+
+// pkgPathRE — допустимые пути пакетов stdlib (без доменов и «..»).
+var pkgPathRE = regexp.MustCompile(`^[a-z0-9_\-]+(/[a-z0-9_\-]+)*$`)
+
+// Package возвращает HTML документации пакета стандартной библиотеки.
+// Пакеты вне stdlib не резолвятся: в пути не допускаются точки.
+func (s *Service) Package(ctx context.Context, path string) (string, error) {
+	if !pkgPathRE.MatchString(path) {
+		return "", fmt.Errorf("некорректный путь пакета: %q", path)
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[path]
+	s.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, docTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "doc", "-all", path)
+	// Вне дерева модуля go doc видит только stdlib
+	cmd.Dir = os.TempDir()
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go doc %s: %w", path, err)
+	}
+
+	rendered := renderDoc(string(out))
+
+	s.mu.Lock()
+	s.cache[path] = rendered
+	s.mu.Unlock()
+	return rendered, nil
+}
+
+var (
+	// Объявления верхнего уровня: func Println, type Builder, var ErrClosed
+	symbolRE = regexp.MustCompile(`^(func|type|var|const)\s+([A-Z][A-Za-z0-9_]*)`)
+	// Методы: func (b *Builder) WriteString(...) → якорь Builder.WriteString
+	methodRE = regexp.MustCompile(`^func \([A-Za-z0-9_]* ?\*?([A-Z][A-Za-z0-9_]*)\) ([A-Z][A-Za-z0-9_]*)`)
+)
+
+// renderDoc экранирует вывод go doc и расставляет якоря на
+// экспортируемых символах, чтобы работали ссылки вида /godoc/fmt#Println
+// и /godoc/sync#WaitGroup.Wait из уроков.
+func renderDoc(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		id := ""
+		if m := methodRE.FindStringSubmatch(line); m != nil {
+			id = m[1] + "." + m[2]
+		} else if m := symbolRE.FindStringSubmatch(line); m != nil {
+			id = m[2]
+		}
+
+		if id != "" {
+			b.WriteString(`<span id="` + html.EscapeString(id) + `">`)
+			b.WriteString(html.EscapeString(line))
+			b.WriteString("</span>\n")
+			continue
+		}
+		b.WriteString(html.EscapeString(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"golearning/internal/content"
+)
+
+// Attribution — данные об источнике контента для корректной атрибуции.
+type Attribution struct {
+	Name    string `json:"name"`    // Название источника (например "Metanit")
+	Author  string `json:"author"`  // Автор материалов
+	License string `json:"license"` // Лицензия (например "CC BY-NC-SA 4.0")
+}
+
+// Attributions — конфигурация источников, ключ — хост источника.
+// Загружается из JSON-файла вида:
+//
+//	{
+//	  "metanit.com": {"name": "Metanit", "author": "...", "license": "..."}
+//	}
+type Attributions map[string]Attribution
+
+// LoadAttributions читает конфигурацию источников из JSON-файла.
+func LoadAttributions(path string) (Attributions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read attributions: %w", err)
+	}
+	var attrs Attributions
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, fmt.Errorf("parse attributions: %w", err)
+	}
+	return attrs, nil
+}
+
+// ForURL возвращает атрибуцию для ссылки по её хосту.
+// Префикс www. игнорируется.
+func (a Attributions) ForURL(rawURL string) (Attribution, bool) {
+	if len(a) == 0 || rawURL == "" {
+		return Attribution{}, false
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Attribution{}, false
+	}
+	host := strings.TrimPrefix(parsed.Hostname(), "www.")
+	attr, ok := a[host]
+	return attr, ok
+}
+
+// Apply заполняет атрибуцию урока по его SourceURL,
+// не перетирая уже заданные значения.
+func (a Attributions) Apply(lesson *content.Lesson) {
+	attr, ok := a.ForURL(lesson.SourceURL)
+	if !ok {
+		return
+	}
+	if lesson.SourceName == "" {
+		lesson.SourceName = attr.Name
+	}
+	if lesson.SourceAuthor == "" {
+		lesson.SourceAuthor = attr.Author
+	}
+	if lesson.SourceLicense == "" {
+		lesson.SourceLicense = attr.License
+	}
+}
@@ -0,0 +1,136 @@
+package ingest
+
+// Формат «файл-урок»: Markdown с YAML front matter, один файл — один
+// урок. Этим форматом пользуются чанковая загрузка через админ-API и
+// команда content sync, поэтому разбор и сборка живут здесь в одном
+// месте.
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golearning/internal/content"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LessonFile — метаданные урока из YAML front matter.
+type LessonFile struct {
+	Module      string `yaml:"module"` // slug существующего модуля
+	Slug        string `yaml:"slug"`
+	Title       string `yaml:"title"`
+	Order       int    `yaml:"order"`
+	ReadingTime int    `yaml:"reading_time"`
+	SourceURL   string `yaml:"source_url,omitempty"`
+	MinGo       string `yaml:"min_go,omitempty"` // минимальная версия Go, например "1.18"
+}
+
+// minGoRE — формат требования версии Go: "1.18" или "1.21.3".
+var minGoRE = regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`)
+
+// ParseLessonFile отделяет YAML front matter от тела урока
+// и валидирует обязательные поля. Незнакомые поля — ошибка: опечатка
+// в метаданных не должна молча теряться.
+func ParseLessonFile(doc string) (*LessonFile, string, error) {
+	rest, ok := strings.CutPrefix(doc, "---\n")
+	if !ok {
+		return nil, "", fmt.Errorf("документ должен начинаться с YAML front matter (---)")
+	}
+	meta, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return nil, "", fmt.Errorf("front matter не закрыт строкой ---")
+	}
+
+	fm := &LessonFile{}
+	dec := yaml.NewDecoder(strings.NewReader(meta))
+	dec.KnownFields(true)
+	if err := dec.Decode(fm); err != nil {
+		return nil, "", fmt.Errorf("некорректный front matter: %v", err)
+	}
+
+	if fm.Module == "" || fm.Slug == "" || fm.Title == "" {
+		return nil, "", fmt.Errorf("front matter должен содержать module, slug и title")
+	}
+	if fm.MinGo != "" && !minGoRE.MatchString(fm.MinGo) {
+		return nil, "", fmt.Errorf("некорректный min_go %q: ожидается версия вида 1.18", fm.MinGo)
+	}
+	return fm, strings.TrimLeft(body, "\n"), nil
+}
+
+// RenderLessonFile собирает документ обратно: front matter плюс тело.
+// Результат разбирается ParseLessonFile без потерь.
+func RenderLessonFile(fm *LessonFile, body string) (string, error) {
+	meta, err := yaml.Marshal(fm)
+	if err != nil {
+		return "", fmt.Errorf("сборка front matter: %w", err)
+	}
+	return "---\n" + string(meta) + "---\n\n" + strings.TrimSpace(body) + "\n", nil
+}
+
+// SplitLessonSections режет тело урока на секции по заголовкам
+// второго уровня. Текст до первого заголовка становится обзором;
+// вид секции угадывается по заголовку.
+func SplitLessonSections(lessonID int64, body string) []content.Section {
+	var sections []content.Section
+	add := func(kind content.SectionKind, title, text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		sections = append(sections, content.Section{
+			LessonID:   lessonID,
+			Kind:       kind,
+			Title:      title,
+			BodyMD:     text,
+			OrderIndex: len(sections),
+		})
+	}
+
+	lines := strings.Split(body, "\n")
+	currentKind := content.SectionOverview
+	currentTitle := "Обзор"
+	var buf []string
+	for _, line := range lines {
+		if title, ok := strings.CutPrefix(line, "## "); ok {
+			add(currentKind, currentTitle, strings.Join(buf, "\n"))
+			currentTitle = strings.TrimSpace(title)
+			currentKind = lessonSectionKind(currentTitle)
+			buf = buf[:0]
+			continue
+		}
+		buf = append(buf, line)
+	}
+	add(currentKind, currentTitle, strings.Join(buf, "\n"))
+	return sections
+}
+
+// lessonSectionKind подбирает вид секции по её заголовку.
+func lessonSectionKind(title string) content.SectionKind {
+	lower := strings.ToLower(title)
+	switch {
+	case strings.Contains(lower, "пример"):
+		return content.SectionExamples
+	case strings.Contains(lower, "ошибк"), strings.Contains(lower, "подводн"):
+		return content.SectionPitfalls
+	case strings.Contains(lower, "ссылк"), strings.Contains(lower, "литератур"):
+		return content.SectionLinks
+	case strings.Contains(lower, "синтакс"):
+		return content.SectionSyntax
+	default:
+		return content.SectionTheory
+	}
+}
+
+// EstimateReadingTime оценивает время чтения: ~200 слов в минуту
+// технического текста, от 3 до 30 минут.
+func EstimateReadingTime(body string) int {
+	minutes := len(strings.Fields(body)) / 200
+	if minutes < 3 {
+		minutes = 3
+	}
+	if minutes > 30 {
+		minutes = 30
+	}
+	return minutes
+}
@@ -12,18 +12,19 @@ import (
 	"strings"
 
 	"golearning/internal/content"
+	"golearning/internal/practice/astcheck"
 
 	"gopkg.in/yaml.v3"
 )
 
 // MDXImporter импортирует уроки из MDX файлов.
 type MDXImporter struct {
-	repo    *content.Repository
+	repo    content.Store
 	baseDir string
 }
 
 // NewMDXImporter создаёт новый MDX импортёр.
-func NewMDXImporter(repo *content.Repository, baseDir string) *MDXImporter {
+func NewMDXImporter(repo content.Store, baseDir string) *MDXImporter {
 	return &MDXImporter{
 		repo:    repo,
 		baseDir: baseDir,
@@ -71,7 +72,7 @@ func (m *MDXImporter) Import(ctx context.Context) error {
 			OrderIndex:  guide.Order,
 		}
 
-		if err := m.repo.CreateCourse(course); err != nil {
+		if err := m.repo.CreateCourse(ctx, course); err != nil {
 			log.Printf("  ⚠️ Ошибка создания курса: %v", err)
 			continue
 		}
@@ -85,15 +86,16 @@ func (m *MDXImporter) Import(ctx context.Context) error {
 		}
 
 		for _, chapter := range chapters {
-			// Создаём модуль для главы
+			// Создаём модуль для главы. Slug получает префикс курса,
+			// чтобы одинаковые главы разных курсов не склеивались.
 			module := &content.Module{
 				CourseID:   course.ID,
-				Slug:       m.slugify(chapter.Title),
+				Slug:       course.Slug + "-" + m.slugify(chapter.Title),
 				Title:      chapter.Title,
 				OrderIndex: moduleIndex,
 			}
 
-			if err := m.repo.CreateModule(module); err != nil {
+			if err := m.repo.CreateModule(ctx, module); err != nil {
 				log.Printf("  ⚠️ Ошибка создания модуля: %v", err)
 				continue
 			}
@@ -108,7 +110,7 @@ func (m *MDXImporter) Import(ctx context.Context) error {
 			}
 
 			for _, lessonFile := range lessons {
-				if err := m.importLesson(ctx, module.ID, lessonFile); err != nil {
+				if err := m.importLesson(ctx, module.ID, course.Slug, lessonFile); err != nil {
 					log.Printf("    ⚠️ Ошибка импорта урока %s: %v", lessonFile.Name, err)
 				}
 			}
@@ -118,8 +120,9 @@ func (m *MDXImporter) Import(ctx context.Context) error {
 	return nil
 }
 
-// importLesson импортирует один урок из MDX файла.
-func (m *MDXImporter) importLesson(ctx context.Context, moduleID int64, lessonFile DirEntry) error {
+// importLesson импортирует один урок из MDX файла. courseSlug идёт
+// префиксом slug урока — пространства имён курсов не пересекаются.
+func (m *MDXImporter) importLesson(ctx context.Context, moduleID int64, courseSlug string, lessonFile DirEntry) error {
 	data, err := os.ReadFile(lessonFile.Path)
 	if err != nil {
 		return fmt.Errorf("read file: %w", err)
@@ -137,7 +140,7 @@ func (m *MDXImporter) importLesson(ctx context.Context, moduleID int64, lessonFi
 	meta := m.parseMeta(mdxContent)
 
 	// Создаём slug
-	slug := m.slugify(title) + "-" + strconv.Itoa(lessonFile.Order)
+	slug := courseSlug + "-" + m.slugify(title) + "-" + strconv.Itoa(lessonFile.Order)
 
 	// Время чтения
 	readingTime := meta.ReadingTime
@@ -160,15 +163,6 @@ func (m *MDXImporter) importLesson(ctx context.Context, moduleID int64, lessonFi
 		ReadingTimeMin: readingTime,
 	}
 
-	if err := m.repo.CreateLesson(lesson); err != nil {
-		return fmt.Errorf("create lesson: %w", err)
-	}
-	log.Printf("    📄 Урок: %s (ID=%d, ~%d мин)", title, lesson.ID, readingTime)
-
-	// Удаляем старые секции и задания
-	m.repo.DeleteSectionsByLessonID(lesson.ID)
-	m.repo.DeleteTasksByLessonID(lesson.ID)
-
 	// Парсим секции из MDX тегов
 	sections := m.parseMDXSections(mdxContent)
 
@@ -193,39 +187,62 @@ func (m *MDXImporter) importLesson(ctx context.Context, moduleID int64, lessonFi
 		}
 	}
 
-	for i, sec := range sections {
-		section := &content.Section{
-			LessonID:   lesson.ID,
-			Kind:       sec.Kind,
-			Title:      sec.Title,
-			BodyMD:     sec.Body,
-			OrderIndex: i,
+	// Парсим задания из MDX тегов
+	tasks := m.parseMDXTasks(mdxContent)
+
+	// Урок с секциями и заданиями сохраняется атомарно
+	err = m.repo.WithTx(ctx, func(repo content.Store) error {
+		if err := repo.CreateLesson(ctx, lesson); err != nil {
+			return fmt.Errorf("create lesson: %w", err)
 		}
-		if err := m.repo.CreateSection(section); err != nil {
-			log.Printf("      ⚠️ Ошибка создания секции: %v", err)
+
+		// Удаляем старые секции
+		repo.DeleteSectionsByLessonID(ctx, lesson.ID)
+
+		batch := make([]content.Section, 0, len(sections))
+		for i, sec := range sections {
+			batch = append(batch, content.Section{
+				LessonID:   lesson.ID,
+				Kind:       sec.Kind,
+				Title:      sec.Title,
+				BodyMD:     sec.Body,
+				OrderIndex: i,
+			})
+		}
+		if err := repo.CreateSections(ctx, batch); err != nil {
+			return fmt.Errorf("create sections: %w", err)
 		}
-	}
 
-	// Парсим задания из MDX тегов
-	tasks := m.parseMDXTasks(mdxContent)
-	for i, task := range tasks {
-		t := &content.Task{
-			LessonID:         lesson.ID,
-			Title:            task.Title,
-			PromptMD:         task.Prompt,
-			Criteria:         task.Criteria,
-			Hints:            task.Hints,
-			StarterCode:      task.StarterCode,
-			TestsGo:          task.Tests,
-			ExpectedOutput:   task.ExpectedOutput,
-			RequiredPatterns: task.RequiredPatterns,
-			Points:           task.Points,
-			OrderIndex:       i,
+		taskBatch := make([]content.Task, 0, len(tasks))
+		for i, task := range tasks {
+			taskBatch = append(taskBatch, content.Task{
+				LessonID:       lesson.ID,
+				Title:          task.Title,
+				PromptMD:       task.Prompt,
+				Criteria:       task.Criteria,
+				Hints:          task.Hints,
+				StarterCode:    task.StarterCode,
+				TestsGo:        task.Tests,
+				ExpectedOutput: task.ExpectedOutput,
+				ASTRules:       astcheck.RulesFromKeywords(task.RequiredPatterns),
+				Points:         task.Points,
+				OrderIndex:     i,
+			})
+		}
+		// Переносим версии начального кода и пересоздаём задания
+		if err := carryStarterVersions(ctx, repo, lesson.ID, taskBatch); err != nil {
+			return err
 		}
-		if err := m.repo.CreateTask(t); err != nil {
-			log.Printf("      ⚠️ Ошибка создания задания: %v", err)
+		repo.DeleteTasksByLessonID(ctx, lesson.ID)
+		if err := repo.CreateTasks(ctx, taskBatch); err != nil {
+			return fmt.Errorf("create tasks: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	log.Printf("    📄 Урок: %s (ID=%d, ~%d мин)", title, lesson.ID, readingTime)
 
 	if len(tasks) > 0 {
 		log.Printf("      ✅ %d заданий создано", len(tasks))
@@ -488,8 +505,9 @@ func (m *MDXImporter) findGuides() ([]DirEntry, error) {
 
 		name := entry.Name()
 		// Служебные директории/метаданные — не считаем отдельными курсами.
-		// Например, lessons_mdx/Проекты содержит ТЗ capstone-проектов для страницы /projects.
-		if name == "Проекты" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		// Например, lessons_mdx/Проекты содержит ТЗ capstone-проектов для страницы /projects,
+		// а lessons_mdx/Интервью — банк вопросов для собеседований (content interview).
+		if name == "Проекты" || name == "Интервью" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
 			continue
 		}
 		order, title := m.parseNumberedName(name)
@@ -631,3 +649,37 @@ func (m *MDXImporter) slugify(s string) string {
 
 	return slug
 }
+
+// ImportFile переимпортирует один файл урока из дерева импортёра.
+// Модуль определяется по директории главы; если он ещё не создан,
+// возвращается ошибка — точечный импорт не подменяет полный.
+func (m *MDXImporter) ImportFile(ctx context.Context, path string) error {
+	rel, err := filepath.Rel(m.baseDir, path)
+	if err != nil {
+		return fmt.Errorf("путь вне дерева импорта: %w", err)
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) != 3 {
+		return fmt.Errorf("ожидается <руководство>/<глава>/<урок>, получено %s", rel)
+	}
+
+	_, guideTitle := m.parseNumberedName(parts[0])
+	courseSlug := m.slugify(guideTitle)
+	_, chapterTitle := m.parseNumberedName(parts[1])
+	module, err := m.repo.GetModuleBySlug(ctx, courseSlug+"-"+m.slugify(chapterTitle))
+	if err != nil {
+		return fmt.Errorf("поиск модуля: %w", err)
+	}
+	if module == nil {
+		return fmt.Errorf("модуль «%s» ещё не импортирован — выполните полный импорт", chapterTitle)
+	}
+
+	ext := filepath.Ext(parts[2])
+	order, title := m.parseNumberedName(strings.TrimSuffix(parts[2], ext))
+	return m.importLesson(ctx, module.ID, courseSlug, DirEntry{
+		Name:  parts[2],
+		Title: title,
+		Path:  path,
+		Order: order,
+	})
+}
@@ -8,11 +8,11 @@ import (
 
 // ParsedContent — распарсенный контент страницы.
 type ParsedContent struct {
-	Title       string
-	Paragraphs  []string
-	CodeBlocks  []CodeBlock
-	Lists       []string
-	RawHTML     string
+	Title      string
+	Paragraphs []string
+	CodeBlocks []CodeBlock
+	Lists      []string
+	RawHTML    string
 }
 
 // CodeBlock — блок кода из страницы.
@@ -148,7 +148,7 @@ func (p *Parser) extractContent(n *html.Node, content *ParsedContent) {
 			// Извлекаем блок кода
 			code := getTextContent(n)
 			if code != "" && len(code) > 5 {
-				lang := detectLanguage(code, getAttr(n, "class"))
+				lang := DetectLanguage(code, getAttr(n, "class"))
 				content.CodeBlocks = append(content.CodeBlocks, CodeBlock{
 					Code:     code,
 					Language: lang,
@@ -201,8 +201,10 @@ func isAdvertisement(text string) bool {
 	return false
 }
 
-// detectLanguage определяет язык блока кода.
-func detectLanguage(code, class string) string {
+// DetectLanguage определяет язык блока кода по классу элемента и
+// содержимому. Экспортируется: веб-слой использует её же для подсветки
+// фенсов без указанного языка.
+func DetectLanguage(code, class string) string {
 	// По классу
 	if strings.Contains(class, "go") || strings.Contains(class, "golang") {
 		return "go"
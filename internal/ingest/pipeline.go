@@ -12,14 +12,21 @@ import (
 
 // Pipeline — конвейер импорта контента.
 type Pipeline struct {
-	crawler  *Crawler
-	parser   *Parser
-	rewriter Rewriter
-	repo     *content.Repository
+	crawler      *Crawler
+	parser       *Parser
+	rewriter     Rewriter
+	repo         content.Store
+	attributions Attributions
+}
+
+// SetAttributions подключает конфигурацию атрибуции источников:
+// импортируемые уроки получают название источника, автора и лицензию.
+func (p *Pipeline) SetAttributions(a Attributions) {
+	p.attributions = a
 }
 
 // NewPipeline создаёт новый pipeline.
-func NewPipeline(crawler *Crawler, parser *Parser, rewriter Rewriter, repo *content.Repository) *Pipeline {
+func NewPipeline(crawler *Crawler, parser *Parser, rewriter Rewriter, repo content.Store) *Pipeline {
 	return &Pipeline{
 		crawler:  crawler,
 		parser:   parser,
@@ -49,7 +56,7 @@ func (p *Pipeline) Run(ctx context.Context, limit int) error {
 
 	for _, mod := range modules {
 		// Создаём или обновляем модуль
-		if err := p.repo.CreateModule(mod.Module); err != nil {
+		if err := p.repo.CreateModule(ctx, mod.Module); err != nil {
 			return fmt.Errorf("create module %s: %w", mod.Module.Slug, err)
 		}
 		log.Printf("Модуль: %s (ID=%d)", mod.Module.Title, mod.Module.ID)
@@ -117,22 +124,22 @@ func (p *Pipeline) groupByModules(entries []TOCEntry) []ModuleGroup {
 // moduleSlugToTitle преобразует slug модуля в заголовок.
 func (p *Pipeline) moduleSlugToTitle(slug string) string {
 	titles := map[string]string{
-		"osnovy":           "Основы Go",
-		"osnovy-yazyka":    "Основы языка",
-		"peremennye":       "Переменные и типы данных",
-		"operatory":        "Операторы",
-		"uslovnye":         "Условные конструкции",
-		"tsikly":           "Циклы",
-		"funktsii":         "Функции",
-		"massivy":          "Массивы и срезы",
-		"map":              "Отображения (map)",
-		"struktury":        "Структуры",
-		"interfeysy":       "Интерфейсы",
+		"osnovy":            "Основы Go",
+		"osnovy-yazyka":     "Основы языка",
+		"peremennye":        "Переменные и типы данных",
+		"operatory":         "Операторы",
+		"uslovnye":          "Условные конструкции",
+		"tsikly":            "Циклы",
+		"funktsii":          "Функции",
+		"massivy":           "Массивы и срезы",
+		"map":               "Отображения (map)",
+		"struktury":         "Структуры",
+		"interfeysy":        "Интерфейсы",
 		"obrabotka-oshibok": "Обработка ошибок",
-		"goroutiny":        "Горутины и каналы",
-		"pakety":           "Пакеты и модули",
-		"rabota-s-faylami": "Работа с файлами",
-		"":                 "Основы Go",
+		"goroutiny":         "Горутины и каналы",
+		"pakety":            "Пакеты и модули",
+		"rabota-s-faylami":  "Работа с файлами",
+		"":                  "Основы Go",
 	}
 
 	if title, ok := titles[slug]; ok {
@@ -187,33 +194,45 @@ func (p *Pipeline) processLesson(ctx context.Context, entry TOCEntry, moduleID i
 		BodyMD:         structured.BodyMD,
 		ReadingTimeMin: structured.ReadingTimeMin,
 	}
+	p.attributions.Apply(lesson)
 
-	if err := p.repo.CreateLesson(lesson); err != nil {
-		return fmt.Errorf("create lesson: %w", err)
-	}
-
-	log.Printf("    -> Урок сохранён: %s (ID=%d)", lesson.Slug, lesson.ID)
+	// Урок с секциями и заданиями сохраняется атомарно: при ошибке
+	// в базе не остаётся урока без содержимого
+	err = p.repo.WithTx(ctx, func(repo content.Store) error {
+		if err := repo.CreateLesson(ctx, lesson); err != nil {
+			return fmt.Errorf("create lesson: %w", err)
+		}
 
-	// Удаляем старые секции и задания
-	p.repo.DeleteSectionsByLessonID(lesson.ID)
-	p.repo.DeleteTasksByLessonID(lesson.ID)
+		// Удаляем старые секции
+		repo.DeleteSectionsByLessonID(ctx, lesson.ID)
 
-	// Сохраняем секции
-	for i := range structured.Sections {
-		structured.Sections[i].LessonID = lesson.ID
-		if err := p.repo.CreateSection(&structured.Sections[i]); err != nil {
-			log.Printf("    Ошибка сохранения секции: %v", err)
+		// Сохраняем секции одним батчем
+		for i := range structured.Sections {
+			structured.Sections[i].LessonID = lesson.ID
+		}
+		if err := repo.CreateSections(ctx, structured.Sections); err != nil {
+			return fmt.Errorf("create sections: %w", err)
 		}
-	}
-	log.Printf("    -> Секций: %d", len(structured.Sections))
 
-	// Сохраняем задания
-	for i := range structured.Tasks {
-		structured.Tasks[i].LessonID = lesson.ID
-		if err := p.repo.CreateTask(&structured.Tasks[i]); err != nil {
-			log.Printf("    Ошибка сохранения задания: %v", err)
+		// Переносим версии начального кода и пересоздаём задания
+		for i := range structured.Tasks {
+			structured.Tasks[i].LessonID = lesson.ID
+		}
+		if err := carryStarterVersions(ctx, repo, lesson.ID, structured.Tasks); err != nil {
+			return err
 		}
+		repo.DeleteTasksByLessonID(ctx, lesson.ID)
+		if err := repo.CreateTasks(ctx, structured.Tasks); err != nil {
+			return fmt.Errorf("create tasks: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
+	log.Printf("    -> Урок сохранён: %s (ID=%d)", lesson.Slug, lesson.ID)
+	log.Printf("    -> Секций: %d", len(structured.Sections))
 	log.Printf("    -> Заданий: %d", len(structured.Tasks))
 
 	return nil
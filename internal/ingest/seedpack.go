@@ -0,0 +1,282 @@
+package ingest
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"golearning/internal/content"
+)
+
+//go:embed seedpacks/*.yaml
+var seedPacksFS embed.FS
+
+// SeedPack — набор демонстрационного контента (модули и уроки),
+// описанный в YAML-файле внутри seedpacks/.
+type SeedPack struct {
+	Name        string       `yaml:"name"`
+	Title       string       `yaml:"title"`
+	Description string       `yaml:"description"`
+	Modules     []PackModule `yaml:"modules"`
+	Lessons     []PackLesson `yaml:"lessons"`
+}
+
+// PackModule — модуль внутри seed-пака.
+type PackModule struct {
+	Slug       string `yaml:"slug"`
+	Title      string `yaml:"title"`
+	OrderIndex int    `yaml:"order"`
+}
+
+// PackLesson — урок внутри seed-пака. Поле Module ссылается на slug модуля.
+type PackLesson struct {
+	Module         string        `yaml:"module"`
+	Slug           string        `yaml:"slug"`
+	Title          string        `yaml:"title"`
+	OrderIndex     int           `yaml:"order"`
+	SourceURL      string        `yaml:"source_url"`
+	ReadingTimeMin int           `yaml:"reading_time"`
+	BodyMD         string        `yaml:"body_md"`
+	Sections       []PackSection `yaml:"sections"`
+	Tasks          []PackTask    `yaml:"tasks"`
+}
+
+// PackSection — секция урока внутри seed-пака.
+type PackSection struct {
+	Kind       string `yaml:"kind"`
+	Title      string `yaml:"title"`
+	OrderIndex int    `yaml:"order"`
+	BodyMD     string `yaml:"body_md"`
+}
+
+// PackTask — задание внутри seed-пака. Kind пуст для обычных заданий
+// с кодом; predict_output — «предскажи вывод».
+type PackTask struct {
+	Kind           string `yaml:"kind,omitempty"`
+	Title          string `yaml:"title"`
+	OrderIndex     int    `yaml:"order"`
+	Points         int    `yaml:"points"`
+	PromptMD       string `yaml:"prompt_md"`
+	StarterCode    string `yaml:"starter_code"`
+	TestsGo        string `yaml:"tests_go"`
+	ExpectedOutput string `yaml:"expected_output"`
+	ASTRules       string `yaml:"ast_rules"`
+	OutputOptions  string `yaml:"output_options,omitempty"`
+	RunTimeoutSec  int    `yaml:"run_timeout_sec,omitempty"`
+	MemoryLimitMB  int    `yaml:"memory_limit_mb,omitempty"`
+
+	// Шаги пошагового задания (kind = guided)
+	Steps []PackStep `yaml:"steps,omitempty"`
+}
+
+// PackStep — шаг пошагового задания внутри seed-пака.
+type PackStep struct {
+	Title          string `yaml:"title"`
+	OrderIndex     int    `yaml:"order"`
+	PromptMD       string `yaml:"prompt_md"`
+	ExpectedOutput string `yaml:"expected_output,omitempty"`
+	TestsGo        string `yaml:"tests_go,omitempty"`
+}
+
+// ListSeedPacks возвращает доступные seed-паки, отсортированные по имени.
+func ListSeedPacks() ([]SeedPack, error) {
+	entries, err := seedPacksFS.ReadDir("seedpacks")
+	if err != nil {
+		return nil, fmt.Errorf("read seedpacks dir: %w", err)
+	}
+
+	var packs []SeedPack
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		pack, err := loadSeedPackFile("seedpacks/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, *pack)
+	}
+
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// LoadSeedPack загружает seed-пак по имени.
+func LoadSeedPack(name string) (*SeedPack, error) {
+	pack, err := loadSeedPackFile("seedpacks/" + name + ".yaml")
+	if err != nil {
+		available, _ := ListSeedPacks()
+		names := make([]string, 0, len(available))
+		for _, p := range available {
+			names = append(names, p.Name)
+		}
+		return nil, fmt.Errorf("seed-пак %q не найден (доступны: %s)", name, strings.Join(names, ", "))
+	}
+	return pack, nil
+}
+
+func loadSeedPackFile(path string) (*SeedPack, error) {
+	data, err := seedPacksFS.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read seed pack %s: %w", path, err)
+	}
+
+	pack := &SeedPack{}
+	if err := yaml.Unmarshal(data, pack); err != nil {
+		return nil, fmt.Errorf("parse seed pack %s: %w", path, err)
+	}
+	return pack, nil
+}
+
+// Seeder заполняет БД контентом из seed-паков.
+type Seeder struct {
+	repo content.Store
+}
+
+// NewSeeder создаёт новый seeder.
+func NewSeeder(repo content.Store) *Seeder {
+	return &Seeder{repo: repo}
+}
+
+// Seed загружает seed-пак и сохраняет его контент в БД.
+func (s *Seeder) Seed(ctx context.Context, packName string) error {
+	pack, err := LoadSeedPack(packName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Создание демонстрационных данных (пак %q: %s)...", pack.Name, pack.Title)
+
+	// Создаём модули и запоминаем их ID по slug
+	moduleIDs := make(map[string]int64, len(pack.Modules))
+	for i := range pack.Modules {
+		m := content.Module{
+			Slug:       pack.Modules[i].Slug,
+			Title:      pack.Modules[i].Title,
+			OrderIndex: pack.Modules[i].OrderIndex,
+		}
+		if err := s.repo.CreateModule(ctx, &m); err != nil {
+			return err
+		}
+		moduleIDs[m.Slug] = m.ID
+	}
+
+	for _, pl := range pack.Lessons {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		moduleID, ok := moduleIDs[pl.Module]
+		if !ok {
+			return fmt.Errorf("урок %q ссылается на неизвестный модуль %q", pl.Slug, pl.Module)
+		}
+
+		if err := s.saveLesson(ctx, moduleID, pl); err != nil {
+			return err
+		}
+	}
+
+	log.Println("Демонстрационные данные созданы!")
+	return nil
+}
+
+func (s *Seeder) saveLesson(ctx context.Context, moduleID int64, pl PackLesson) error {
+	lesson := content.Lesson{
+		ModuleID:       moduleID,
+		Slug:           pl.Slug,
+		Title:          pl.Title,
+		OrderIndex:     pl.OrderIndex,
+		SourceURL:      pl.SourceURL,
+		ReadingTimeMin: pl.ReadingTimeMin,
+		BodyMD:         pl.BodyMD,
+	}
+	// Урок с секциями и заданиями сохраняется атомарно
+	err := s.repo.WithTx(ctx, func(repo content.Store) error {
+		if err := repo.CreateLesson(ctx, &lesson); err != nil {
+			return err
+		}
+
+		repo.DeleteSectionsByLessonID(ctx, lesson.ID)
+
+		sections := make([]content.Section, 0, len(pl.Sections))
+		for _, ps := range pl.Sections {
+			sections = append(sections, content.Section{
+				LessonID:   lesson.ID,
+				Kind:       content.SectionKind(ps.Kind),
+				Title:      ps.Title,
+				BodyMD:     ps.BodyMD,
+				OrderIndex: ps.OrderIndex,
+			})
+		}
+		if err := repo.CreateSections(ctx, sections); err != nil {
+			return fmt.Errorf("create sections: %w", err)
+		}
+
+		tasks := make([]content.Task, 0, len(pl.Tasks))
+		for _, pt := range pl.Tasks {
+			tasks = append(tasks, content.Task{
+				LessonID:       lesson.ID,
+				Kind:           content.TaskKind(pt.Kind),
+				Title:          pt.Title,
+				OrderIndex:     pt.OrderIndex,
+				Points:         pt.Points,
+				PromptMD:       pt.PromptMD,
+				StarterCode:    pt.StarterCode,
+				TestsGo:        pt.TestsGo,
+				ExpectedOutput: pt.ExpectedOutput,
+				ASTRules:       pt.ASTRules,
+				OutputOptions:  pt.OutputOptions,
+				RunTimeoutSec:  pt.RunTimeoutSec,
+				MemoryLimitMB:  pt.MemoryLimitMB,
+			})
+		}
+		// Переносим версии начального кода и пересоздаём задания
+		if err := carryStarterVersions(ctx, repo, lesson.ID, tasks); err != nil {
+			return err
+		}
+		repo.DeleteTasksByLessonID(ctx, lesson.ID)
+
+		batch := make([]content.Task, 0, len(tasks))
+		for i, pt := range pl.Tasks {
+			t := tasks[i]
+			if len(pt.Steps) == 0 {
+				batch = append(batch, t)
+				continue
+			}
+
+			// Заданию с шагами нужен ID — создаём его отдельно
+			if err := repo.CreateTask(ctx, &t); err != nil {
+				return fmt.Errorf("create task: %w", err)
+			}
+			steps := make([]content.TaskStep, 0, len(pt.Steps))
+			for _, ps := range pt.Steps {
+				steps = append(steps, content.TaskStep{
+					TaskID:         t.ID,
+					Title:          ps.Title,
+					PromptMD:       ps.PromptMD,
+					ExpectedOutput: ps.ExpectedOutput,
+					TestsGo:        ps.TestsGo,
+					OrderIndex:     ps.OrderIndex,
+				})
+			}
+			if err := repo.CreateTaskSteps(ctx, steps); err != nil {
+				return fmt.Errorf("create task steps: %w", err)
+			}
+		}
+		if err := repo.CreateTasks(ctx, batch); err != nil {
+			return fmt.Errorf("create tasks: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("  Урок: %s (ID=%d)", lesson.Title, lesson.ID)
+	return nil
+}
@@ -0,0 +1,41 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"golearning/internal/content"
+)
+
+// carryStarterVersions переносит версии начального кода со старых
+// заданий урока на новые перед реимпортом. Задания сопоставляются по
+// заголовку; если автор изменил starter_code, версия растёт — страница
+// урока покажет учащемуся, что задание обновилось с его последней
+// попытки. Вызывается до DeleteTasksByLessonID: после удаления старых
+// заданий сравнивать уже не с чем.
+func carryStarterVersions(ctx context.Context, repo content.Store, lessonID int64, tasks []content.Task) error {
+	old, err := repo.GetTasksByLessonID(ctx, lessonID)
+	if err != nil {
+		return fmt.Errorf("get old tasks: %w", err)
+	}
+	byTitle := make(map[string]content.Task, len(old))
+	for _, t := range old {
+		byTitle[t.Title] = t
+	}
+
+	for i := range tasks {
+		prev, ok := byTitle[tasks[i].Title]
+		if !ok {
+			tasks[i].StarterVersion = 1
+			continue
+		}
+		tasks[i].StarterVersion = prev.StarterVersion
+		if tasks[i].StarterVersion < 1 {
+			tasks[i].StarterVersion = 1
+		}
+		if prev.StarterCode != tasks[i].StarterCode {
+			tasks[i].StarterVersion++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package ingest
+
+// Режим наблюдения для авторинга уроков: правки в файлах подхватываются
+// через fsnotify и переимпортируются на лету. Редакторы сохраняют файл
+// серией событий (временный файл, запись, переименование), поэтому
+// импорт стартует только после паузы watchDebounce с последней правки.
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce — пауза после последней правки перед импортом:
+// серия сохранений подряд схлопывается в один переимпорт.
+const watchDebounce = 2 * time.Second
+
+// FileImporter умеет переимпортировать один файл урока.
+// Реализуется Markdown- и MDX-импортёрами.
+type FileImporter interface {
+	ImportFile(ctx context.Context, path string) error
+}
+
+// Watch следит за *.md и *.mdx файлами в dir (включая поддиректории)
+// и переимпортирует изменившиеся. Блокирует до отмены контекста.
+func Watch(ctx context.Context, dir string, importer FileImporter) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	count, err := watchTree(watcher, dir)
+	if err != nil {
+		return fmt.Errorf("обход %s: %w", dir, err)
+	}
+	log.Printf("👀 Наблюдение за %s (файлов: %d). Ctrl+C — выход.", dir, count)
+
+	pending := make(map[string]bool)
+	debounce := time.NewTimer(watchDebounce)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("⚠️ Ошибка наблюдения: %v", err)
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// Новые поддиректории включаются в наблюдение на лету
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if _, err := watchTree(watcher, event.Name); err != nil {
+						log.Printf("⚠️ Ошибка обхода %s: %v", event.Name, err)
+					}
+					continue
+				}
+			}
+			if !lessonFile(event.Name) || !(event.Has(fsnotify.Create) || event.Has(fsnotify.Write)) {
+				continue
+			}
+			pending[event.Name] = true
+			debounce.Reset(watchDebounce)
+
+		case <-debounce.C:
+			if len(pending) == 0 {
+				continue
+			}
+			started := time.Now()
+			reimported := 0
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				// Файл могли удалить или переименовать после правки
+				if _, err := os.Stat(path); err != nil {
+					continue
+				}
+				if err := importer.ImportFile(ctx, path); err != nil {
+					log.Printf("⚠️ %s: %v", path, err)
+					continue
+				}
+				reimported++
+			}
+			log.Printf("🔄 Переимпортировано файлов: %d из %d за %s",
+				reimported, len(pending), time.Since(started).Round(time.Millisecond))
+			pending = make(map[string]bool)
+		}
+	}
+}
+
+// watchTree добавляет dir и все её поддиректории в наблюдение и
+// возвращает число файлов уроков в дереве (для стартового лога).
+func watchTree(watcher *fsnotify.Watcher, dir string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		if lessonFile(path) {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// lessonFile сообщает, является ли путь файлом урока.
+func lessonFile(path string) bool {
+	return strings.HasSuffix(path, ".md") || strings.HasSuffix(path, ".mdx")
+}
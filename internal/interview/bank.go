@@ -0,0 +1,138 @@
+package interview
+
+// Импорт банка вопросов из Markdown-файлов. Один файл — одна тема:
+//
+//	# Горутины и каналы
+//
+//	## Чем горутина отличается от потока ОС?
+//	difficulty: easy
+//	lesson: gorutiny
+//
+//	Текст вопроса (Markdown, необязателен — заголовка может быть достаточно).
+//
+//	### Ответ
+//
+//	Эталонный ответ (Markdown).
+//
+// Строки difficulty/lesson сразу после заголовка вопроса необязательны;
+// по умолчанию difficulty = medium, без привязки к уроку.
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// допустимые значения difficulty в файлах банка.
+var validDifficulties = map[string]bool{"easy": true, "medium": true, "hard": true}
+
+// ParseBank разбирает один файл банка вопросов.
+func ParseBank(data []byte) (topic string, questions []Question, err error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	var current *Question
+	inAnswer := false
+	metaDone := false
+	var body []string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if inAnswer {
+			current.AnswerMD = strings.TrimSpace(strings.Join(body, "\n"))
+		} else {
+			current.QuestionMD = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+		questions = append(questions, *current)
+		current = nil
+	}
+
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "# ") && topic == "":
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		case strings.HasPrefix(line, "## "):
+			flush()
+			current = &Question{
+				Title:      strings.TrimSpace(strings.TrimPrefix(line, "## ")),
+				Difficulty: "medium",
+			}
+			inAnswer = false
+			metaDone = false
+			body = body[:0]
+		case current != nil && strings.HasPrefix(line, "### Ответ"):
+			current.QuestionMD = strings.TrimSpace(strings.Join(body, "\n"))
+			inAnswer = true
+			body = body[:0]
+		case current != nil && !metaDone && !inAnswer:
+			// Метаданные — строки key: value сразу после заголовка вопроса.
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" && len(body) == 0 {
+				continue
+			}
+			if key, value, ok := strings.Cut(trimmed, ":"); ok && len(body) == 0 {
+				switch strings.TrimSpace(key) {
+				case "difficulty":
+					d := strings.TrimSpace(value)
+					if !validDifficulties[d] {
+						return "", nil, fmt.Errorf("вопрос %q: неизвестная сложность %q", current.Title, d)
+					}
+					current.Difficulty = d
+					continue
+				case "lesson":
+					current.LessonSlug = strings.TrimSpace(value)
+					continue
+				}
+			}
+			metaDone = true
+			body = append(body, line)
+		case current != nil:
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	if topic == "" {
+		return "", nil, fmt.Errorf("файл не содержит заголовка темы (# ...)")
+	}
+	for i := range questions {
+		questions[i].Topic = topic
+	}
+	return topic, questions, nil
+}
+
+// ImportDir импортирует все *.md из каталога в банк вопросов.
+// Возвращает число импортированных вопросов.
+func ImportDir(dir string, repo *Repository) (int, error) {
+	imported := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("чтение %s: %w", path, err)
+		}
+		_, questions, err := ParseBank(data)
+		if err != nil {
+			return fmt.Errorf("разбор %s: %w", path, err)
+		}
+		for i := range questions {
+			if err := repo.Upsert(&questions[i]); err != nil {
+				return fmt.Errorf("импорт %s: %w", path, err)
+			}
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return imported, err
+	}
+	return imported, nil
+}
@@ -0,0 +1,166 @@
+package interview
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Question — вопрос из банка для подготовки к собеседованиям.
+type Question struct {
+	ID         int64
+	Topic      string
+	Title      string
+	Difficulty string // easy, medium или hard
+	QuestionMD string
+	AnswerMD   string
+	LessonSlug string // slug связанного урока; "" — без привязки
+	CreatedAt  time.Time
+}
+
+// TopicStat — сводка по теме: размер банка и средняя самооценка.
+type TopicStat struct {
+	Topic    string
+	Count    int
+	Reviews  int
+	AvgScore float64 // 0..2; осмысленна только при Reviews > 0
+}
+
+// Repository — репозиторий банка вопросов.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создаёт новый репозиторий.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Upsert сохраняет вопрос; пара (topic, title) — ключ для повторного импорта.
+func (r *Repository) Upsert(q *Question) error {
+	result, err := r.db.Exec(
+		`INSERT INTO interview_questions (topic, title, difficulty, question_md, answer_md, lesson_slug)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(topic, title) DO UPDATE SET
+		     difficulty = excluded.difficulty,
+		     question_md = excluded.question_md,
+		     answer_md = excluded.answer_md,
+		     lesson_slug = excluded.lesson_slug`,
+		q.Topic, q.Title, q.Difficulty, q.QuestionMD, q.AnswerMD, q.LessonSlug,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert question: %w", err)
+	}
+	if id, err := result.LastInsertId(); err == nil && id > 0 {
+		q.ID = id
+	}
+	return nil
+}
+
+// Topics возвращает сводку по темам банка в алфавитном порядке.
+func (r *Repository) Topics() ([]TopicStat, error) {
+	rows, err := r.db.Query(
+		`SELECT q.topic, COUNT(DISTINCT q.id), COUNT(rv.id), COALESCE(AVG(rv.score), 0)
+		 FROM interview_questions q
+		 LEFT JOIN interview_reviews rv ON rv.question_id = q.id
+		 GROUP BY q.topic
+		 ORDER BY q.topic`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list topics: %w", err)
+	}
+	defer rows.Close()
+
+	var result []TopicStat
+	for rows.Next() {
+		var t TopicStat
+		if err := rows.Scan(&t.Topic, &t.Count, &t.Reviews, &t.AvgScore); err != nil {
+			return nil, fmt.Errorf("scan topic: %w", err)
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+// Random возвращает count случайных вопросов, опционально отфильтрованных
+// по теме и сложности.
+func (r *Repository) Random(count int, topic, difficulty string) ([]Question, error) {
+	q := `SELECT id, topic, title, difficulty, question_md, answer_md, lesson_slug, created_at
+	      FROM interview_questions`
+	var conds []string
+	var args []interface{}
+	if topic != "" {
+		conds = append(conds, "topic = ?")
+		args = append(args, topic)
+	}
+	if difficulty != "" {
+		conds = append(conds, "difficulty = ?")
+		args = append(args, difficulty)
+	}
+	for i, c := range conds {
+		if i == 0 {
+			q += " WHERE " + c
+		} else {
+			q += " AND " + c
+		}
+	}
+	q += " ORDER BY RANDOM() LIMIT ?"
+	args = append(args, count)
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("random questions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Question
+	for rows.Next() {
+		var qu Question
+		if err := rows.Scan(&qu.ID, &qu.Topic, &qu.Title, &qu.Difficulty, &qu.QuestionMD, &qu.AnswerMD, &qu.LessonSlug, &qu.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan question: %w", err)
+		}
+		result = append(result, qu)
+	}
+	return result, rows.Err()
+}
+
+// Get возвращает вопрос по ID.
+func (r *Repository) Get(id int64) (*Question, error) {
+	qu := &Question{}
+	err := r.db.QueryRow(
+		`SELECT id, topic, title, difficulty, question_md, answer_md, lesson_slug, created_at
+		 FROM interview_questions WHERE id = ?`,
+		id,
+	).Scan(&qu.ID, &qu.Topic, &qu.Title, &qu.Difficulty, &qu.QuestionMD, &qu.AnswerMD, &qu.LessonSlug, &qu.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get question: %w", err)
+	}
+	return qu, nil
+}
+
+// RecordReview записывает самооценку ответа (0 — не знал, 1 — частично, 2 — знал).
+func (r *Repository) RecordReview(questionID int64, score int) error {
+	if score < 0 || score > 2 {
+		return fmt.Errorf("недопустимая оценка: %d", score)
+	}
+	_, err := r.db.Exec(
+		`INSERT INTO interview_reviews (question_id, score) VALUES (?, ?)`,
+		questionID, score,
+	)
+	if err != nil {
+		return fmt.Errorf("record review: %w", err)
+	}
+	return nil
+}
+
+// Count возвращает размер банка вопросов.
+func (r *Repository) Count() (int, error) {
+	var n int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM interview_questions`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count questions: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,141 @@
+// Package issues — жалобы на ошибки в материалах. Кнопка «Сообщить об
+// ошибке» на страницах уроков складывает структурированные жалобы в
+// таблицу issues; админка разбирает их со статусами open → accepted →
+// fixed.
+package issues
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Виды проблем с русскими подписями для форм и списков.
+var Kinds = map[string]string{
+	"typo":         "Опечатка или неточность",
+	"broken_test":  "Задание не проверяется",
+	"wrong_output": "Неверный ожидаемый вывод",
+	"other":        "Другое",
+}
+
+// Статусы жалобы в порядке разбора.
+var Statuses = map[string]string{
+	"open":     "Новая",
+	"accepted": "Принята",
+	"fixed":    "Исправлена",
+}
+
+// Issue — жалоба на ошибку в материалах.
+type Issue struct {
+	ID        int64
+	LessonID  int64 // 0 — урок уже удалён
+	TaskID    int64 // 0 — жалоба на урок целиком
+	Kind      string
+	Message   string
+	Context   string // снимок контекста на момент жалобы
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Контекст для списков (подтягивается при чтении)
+	LessonSlug  string
+	LessonTitle string
+	TaskTitle   string
+}
+
+// Repository — репозиторий жалоб.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создаёт новый репозиторий.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет новую жалобу со статусом open.
+func (r *Repository) Create(issue *Issue) error {
+	if _, ok := Kinds[issue.Kind]; !ok {
+		return fmt.Errorf("неизвестный вид проблемы: %q", issue.Kind)
+	}
+
+	var lessonID, taskID interface{}
+	if issue.LessonID > 0 {
+		lessonID = issue.LessonID
+	}
+	if issue.TaskID > 0 {
+		taskID = issue.TaskID
+	}
+	result, err := r.db.Exec(
+		`INSERT INTO issues (lesson_id, task_id, kind, message, context) VALUES (?, ?, ?, ?, ?)`,
+		lessonID, taskID, issue.Kind, issue.Message, issue.Context,
+	)
+	if err != nil {
+		return fmt.Errorf("create issue: %w", err)
+	}
+	issue.ID, _ = result.LastInsertId()
+	return nil
+}
+
+// List возвращает жалобы, новые первыми; status сужает выборку
+// ("" — все статусы).
+func (r *Repository) List(status string, limit int) ([]Issue, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	q := `SELECT i.id, COALESCE(i.lesson_id, 0), COALESCE(i.task_id, 0), i.kind, i.message,
+	             i.context, i.status, i.created_at, i.updated_at,
+	             COALESCE(l.slug, ''), COALESCE(l.title, ''), COALESCE(t.title, '')
+	      FROM issues i
+	      LEFT JOIN lessons l ON l.id = i.lesson_id
+	      LEFT JOIN tasks t ON t.id = i.task_id`
+	var args []interface{}
+	if status != "" {
+		q += ` WHERE i.status = ?`
+		args = append(args, status)
+	}
+	q += ` ORDER BY i.created_at DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list issues: %w", err)
+	}
+	defer rows.Close()
+
+	var list []Issue
+	for rows.Next() {
+		var i Issue
+		if err := rows.Scan(&i.ID, &i.LessonID, &i.TaskID, &i.Kind, &i.Message,
+			&i.Context, &i.Status, &i.CreatedAt, &i.UpdatedAt,
+			&i.LessonSlug, &i.LessonTitle, &i.TaskTitle); err != nil {
+			return nil, fmt.Errorf("scan issue: %w", err)
+		}
+		list = append(list, i)
+	}
+	return list, rows.Err()
+}
+
+// SetStatus переводит жалобу в новый статус.
+func (r *Repository) SetStatus(id int64, status string) error {
+	if _, ok := Statuses[status]; !ok {
+		return fmt.Errorf("неизвестный статус: %q", status)
+	}
+	_, err := r.db.Exec(
+		`UPDATE issues SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		status, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set issue status: %w", err)
+	}
+	return nil
+}
+
+// CountOpen возвращает количество неразобранных жалоб.
+func (r *Repository) CountOpen() (int, error) {
+	var n int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM issues WHERE status = 'open'`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count open issues: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,103 @@
+// Package kv — общее key/value-хранилище с TTL для кэша, rate-лимитера
+// и сессий. Реализации: in-memory (по умолчанию, один процесс) и Redis
+// (--redis-addr, общее состояние для нескольких реплик за балансировщиком).
+package kv
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store — интерфейс key/value-хранилища с TTL.
+type Store interface {
+	// Get возвращает значение и признак его наличия.
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set сохраняет значение. ttl <= 0 — без истечения.
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	// Incr атомарно увеличивает счётчик и возвращает новое значение.
+	// TTL выставляется только при создании счётчика (окно rate-лимита).
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Delete удаляет ключ.
+	Delete(ctx context.Context, key string) error
+	// Close освобождает ресурсы хранилища.
+	Close() error
+}
+
+// memoryEntry — запись in-memory хранилища.
+type memoryEntry struct {
+	value     string
+	counter   int64
+	expiresAt time.Time // нулевое время — без истечения
+}
+
+func (e *memoryEntry) expired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+// Memory — in-memory реализация Store. Подходит для одного процесса;
+// при нескольких репликах лимиты и сессии у каждой свои.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// NewMemory создаёт пустое in-memory хранилище.
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]*memoryEntry)}
+}
+
+var _ Store = (*Memory)(nil)
+
+// Get возвращает значение по ключу.
+func (m *Memory) Get(ctx context.Context, key string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok || entry.expired() {
+		delete(m.entries, key)
+		return "", false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set сохраняет значение по ключу.
+func (m *Memory) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := &memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	m.entries[key] = entry
+	return nil
+}
+
+// Incr увеличивает счётчик по ключу.
+func (m *Memory) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok || entry.expired() {
+		entry = &memoryEntry{}
+		if ttl > 0 {
+			entry.expiresAt = time.Now().Add(ttl)
+		}
+		m.entries[key] = entry
+	}
+	entry.counter++
+	return entry.counter, nil
+}
+
+// Delete удаляет ключ.
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// Close ничего не делает для in-memory хранилища.
+func (m *Memory) Close() error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package kv
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisTimeout — таймаут одной операции с Redis.
+const redisTimeout = 3 * time.Second
+
+// Redis — реализация Store поверх Redis. Клиент самописный и нарочно
+// минимальный: нам нужны только GET/SET/INCR/EXPIRE/DEL по протоколу
+// RESP2, тянуть ради этого внешнюю зависимость не хочется. Одно
+// соединение под мьютексом; при ошибке ввода-вывода соединение
+// закрывается и открывается заново на следующей операции.
+type Redis struct {
+	addr string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedis создаёт клиент к Redis по адресу host:port.
+// Соединение открывается лениво при первой операции.
+func NewRedis(addr string) *Redis {
+	return &Redis{addr: addr}
+}
+
+var _ Store = (*Redis)(nil)
+
+// Ping проверяет доступность Redis.
+func (r *Redis) Ping(ctx context.Context) error {
+	reply, err := r.do(ctx, "PING")
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("неожиданный ответ на PING: %q", reply)
+	}
+	return nil
+}
+
+// Get возвращает значение по ключу.
+func (r *Redis) Get(ctx context.Context, key string) (string, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return "", false, err
+	}
+	if reply == nil {
+		return "", false, nil
+	}
+	return reply.(string), true, nil
+}
+
+// Set сохраняет значение по ключу.
+func (r *Redis) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+// Incr увеличивает счётчик по ключу.
+func (r *Redis) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	reply, err := r.do(ctx, "INCR", key)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("неожиданный ответ на INCR: %v", reply)
+	}
+	// TTL только на свежесозданный счётчик — это начало окна лимита
+	if count == 1 && ttl > 0 {
+		if _, err := r.do(ctx, "PEXPIRE", key, strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// Delete удаляет ключ.
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	_, err := r.do(ctx, "DEL", key)
+	return err
+}
+
+// Close закрывает соединение с Redis.
+func (r *Redis) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn != nil {
+		err := r.conn.Close()
+		r.conn = nil
+		r.reader = nil
+		return err
+	}
+	return nil
+}
+
+// do выполняет одну команду и возвращает разобранный ответ:
+// string, int64 или nil (bulk nil).
+func (r *Redis) do(ctx context.Context, args ...string) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(redisTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = r.conn.SetDeadline(deadline)
+
+	if err := r.write(args); err != nil {
+		r.drop()
+		return nil, fmt.Errorf("redis write: %w", err)
+	}
+	reply, err := r.read()
+	if err != nil {
+		r.drop()
+		return nil, fmt.Errorf("redis read: %w", err)
+	}
+	return reply, nil
+}
+
+// connect открывает соединение, если его ещё нет.
+func (r *Redis) connect(ctx context.Context) error {
+	if r.conn != nil {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: redisTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", r.addr)
+	if err != nil {
+		return fmt.Errorf("redis connect %s: %w", r.addr, err)
+	}
+	r.conn = conn
+	r.reader = bufio.NewReader(conn)
+	return nil
+}
+
+// drop закрывает сломанное соединение; следующая операция откроет новое.
+func (r *Redis) drop() {
+	if r.conn != nil {
+		_ = r.conn.Close()
+		r.conn = nil
+		r.reader = nil
+	}
+}
+
+// write отправляет команду в формате RESP-массива bulk-строк.
+func (r *Redis) write(args []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := r.conn.Write([]byte(b.String()))
+	return err
+}
+
+// read разбирает один ответ сервера.
+func (r *Redis) read() (interface{}, error) {
+	line, err := r.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("пустой ответ")
+	}
+
+	payload := line[1:]
+	switch line[0] {
+	case '+': // простая строка
+		return payload, nil
+	case '-': // ошибка сервера
+		return nil, fmt.Errorf("redis: %s", payload)
+	case ':': // целое число
+		return strconv.ParseInt(payload, 10, 64)
+	case '$': // bulk-строка
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil // ключа нет
+		}
+		buf := make([]byte, size+2) // + CRLF
+		if _, err := io.ReadFull(r.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	default:
+		return nil, fmt.Errorf("неожиданный ответ: %q", line)
+	}
+}
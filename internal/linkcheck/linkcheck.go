@@ -0,0 +1,232 @@
+// Package linkcheck проверяет внешние ссылки в контенте уроков.
+// Уроки импортируются со сторонних сайтов, и ссылки в них со временем
+// умирают: проверка обходит SourceURL уроков и ссылки внутри Markdown,
+// записывает битые в таблицу dead_links и убирает оттуда починившиеся.
+package linkcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"golearning/internal/content"
+)
+
+// checkTimeout — максимум на проверку одной ссылки.
+const checkTimeout = 10 * time.Second
+
+// markdownLinkRE находит ссылки вида [текст](http://...) и
+// автоссылки <http://...> в Markdown.
+var markdownLinkRE = regexp.MustCompile(`\]\((https?://[^)\s]+)\)|<(https?://[^>\s]+)>`)
+
+// DeadLink — запись о битой ссылке.
+type DeadLink struct {
+	ID          int64
+	LessonID    int64
+	LessonTitle string
+	LessonSlug  string
+	URL         string
+	StatusCode  int
+	Error       string
+	FirstSeen   time.Time
+	LastChecked time.Time
+}
+
+// Report — итог одной проверки.
+type Report struct {
+	Checked int // всего проверено ссылок
+	Dead    int // из них битых
+	Fixed   int // убрано из журнала как починившиеся
+}
+
+// Checker проверяет ссылки и ведёт журнал битых.
+type Checker struct {
+	db      *sql.DB
+	content content.Store
+	client  *http.Client
+}
+
+// NewChecker создаёт проверку ссылок.
+func NewChecker(db *sql.DB, contentRepo content.Store) *Checker {
+	return &Checker{
+		db:      db,
+		content: contentRepo,
+		client: &http.Client{
+			Timeout: checkTimeout,
+		},
+	}
+}
+
+// Run обходит все уроки, проверяет их ссылки и обновляет журнал.
+func (c *Checker) Run(ctx context.Context) (*Report, error) {
+	lessons, err := c.content.ListAllLessons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list lessons: %w", err)
+	}
+
+	report := &Report{}
+	// Результаты кэшируются на время прохода: одна и та же ссылка
+	// встречается в нескольких уроках
+	verdicts := map[string]linkVerdict{}
+
+	for _, lesson := range lessons {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		urls, err := c.lessonURLs(ctx, &lesson)
+		if err != nil {
+			return report, err
+		}
+		for _, url := range urls {
+			verdict, ok := verdicts[url]
+			if !ok {
+				verdict = c.check(ctx, url)
+				verdicts[url] = verdict
+				report.Checked++
+			}
+			if verdict.dead {
+				report.Dead++
+				if err := c.record(ctx, lesson.ID, url, verdict); err != nil {
+					return report, err
+				}
+			} else {
+				fixed, err := c.clear(ctx, lesson.ID, url)
+				if err != nil {
+					return report, err
+				}
+				if fixed {
+					report.Fixed++
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+// DeadLinks возвращает журнал битых ссылок для админки.
+func (c *Checker) DeadLinks(ctx context.Context, limit int) ([]DeadLink, error) {
+	rows, err := c.db.QueryContext(ctx,
+		`SELECT d.id, d.lesson_id, l.title, l.slug, d.url, d.status_code, d.error,
+		        d.first_seen, d.last_checked
+		 FROM dead_links d
+		 JOIN lessons l ON l.id = d.lesson_id
+		 ORDER BY d.last_checked DESC
+		 LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list dead links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []DeadLink
+	for rows.Next() {
+		var d DeadLink
+		if err := rows.Scan(&d.ID, &d.LessonID, &d.LessonTitle, &d.LessonSlug,
+			&d.URL, &d.StatusCode, &d.Error, &d.FirstSeen, &d.LastChecked); err != nil {
+			return nil, fmt.Errorf("scan dead link: %w", err)
+		}
+		links = append(links, d)
+	}
+	return links, rows.Err()
+}
+
+// lessonURLs собирает внешние ссылки урока: SourceURL и ссылки
+// из Markdown всех секций.
+func (c *Checker) lessonURLs(ctx context.Context, lesson *content.Lesson) ([]string, error) {
+	seen := map[string]bool{}
+	var urls []string
+	add := func(url string) {
+		url = strings.TrimSpace(url)
+		if url == "" || seen[url] {
+			return
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+
+	add(lesson.SourceURL)
+	sections, err := c.content.GetSectionsByLessonID(ctx, lesson.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get sections: %w", err)
+	}
+	for _, sec := range sections {
+		for _, match := range markdownLinkRE.FindAllStringSubmatch(sec.BodyMD, -1) {
+			if match[1] != "" {
+				add(match[1])
+			} else {
+				add(match[2])
+			}
+		}
+	}
+	return urls, nil
+}
+
+// linkVerdict — результат проверки одной ссылки.
+type linkVerdict struct {
+	dead       bool
+	statusCode int
+	err        string
+}
+
+// check выполняет HTTP-запрос к ссылке. Сначала HEAD; на 405 и прочие
+// отказы метода — повтор обычным GET, часть сайтов HEAD не отдаёт.
+func (c *Checker) check(ctx context.Context, url string) linkVerdict {
+	verdict := c.request(ctx, http.MethodHead, url)
+	if verdict.dead && verdict.statusCode == http.StatusMethodNotAllowed {
+		verdict = c.request(ctx, http.MethodGet, url)
+	}
+	return verdict
+}
+
+func (c *Checker) request(ctx context.Context, method, url string) linkVerdict {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return linkVerdict{dead: true, err: err.Error()}
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return linkVerdict{dead: true, err: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return linkVerdict{dead: true, statusCode: resp.StatusCode}
+	}
+	return linkVerdict{statusCode: resp.StatusCode}
+}
+
+// record сохраняет битую ссылку; повторная проверка обновляет
+// статус и время.
+func (c *Checker) record(ctx context.Context, lessonID int64, url string, verdict linkVerdict) error {
+	_, err := c.db.ExecContext(ctx,
+		`INSERT INTO dead_links (lesson_id, url, status_code, error)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT(lesson_id, url) DO UPDATE SET
+		    status_code = excluded.status_code,
+		    error = excluded.error,
+		    last_checked = CURRENT_TIMESTAMP`,
+		lessonID, url, verdict.statusCode, verdict.err,
+	)
+	if err != nil {
+		return fmt.Errorf("record dead link: %w", err)
+	}
+	return nil
+}
+
+// clear убирает ссылку из журнала, если она снова отвечает.
+func (c *Checker) clear(ctx context.Context, lessonID int64, url string) (bool, error) {
+	result, err := c.db.ExecContext(ctx,
+		`DELETE FROM dead_links WHERE lesson_id = ? AND url = ?`,
+		lessonID, url,
+	)
+	if err != nil {
+		return false, fmt.Errorf("clear dead link: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	return affected > 0, nil
+}
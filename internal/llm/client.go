@@ -0,0 +1,125 @@
+// Package llm — минимальный клиент OpenAI-совместимого API: эмбеддинги
+// и chat completions. Подходит и для локальных серверов (Ollama,
+// llama.cpp) с тем же протоколом. На нём построены «спросить курс»
+// и LLM-подсказки по проваленным проверкам.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golearning/internal/config"
+)
+
+// Client — клиент одного LLM-провайдера с настроенными моделями
+// ответа и эмбеддингов.
+type Client struct {
+	endpoint       string
+	apiKey         string
+	model          string
+	embeddingModel string
+	http           *http.Client
+}
+
+// NewClient создаёт клиент LLM из конфигурации.
+func NewClient(cfg config.LLM) *Client {
+	return &Client{
+		endpoint:       strings.TrimRight(cfg.Endpoint, "/"),
+		apiKey:         cfg.APIKey,
+		model:          cfg.Model,
+		embeddingModel: cfg.EmbeddingModel,
+		http:           &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// Embed возвращает векторы эмбеддингов для текстов, по одному на текст.
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var resp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	err := c.post(ctx, "/embeddings", map[string]interface{}{
+		"model": c.embeddingModel,
+		"input": texts,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("эмбеддингов %d вместо %d", len(resp.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(vectors) {
+			return nil, fmt.Errorf("эмбеддинг с индексом %d вне диапазона", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// Complete возвращает ответ модели на системный и пользовательский
+// промпты.
+func (c *Client) Complete(ctx context.Context, system, user string) (string, error) {
+	var resp struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	err := c.post(ctx, "/chat/completions", map[string]interface{}{
+		"model": c.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": user},
+		},
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("модель не вернула ни одного ответа")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+// post отправляет JSON-запрос и разбирает JSON-ответ.
+func (c *Client) post(ctx context.Context, path string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("сборка запроса: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("запрос %s: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("запрос %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: HTTP %d: %s", path, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("разбор ответа %s: %w", path, err)
+	}
+	return nil
+}
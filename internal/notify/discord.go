@@ -0,0 +1,19 @@
+package notify
+
+// DiscordSender отправляет сообщения в канал Discord через webhook.
+type DiscordSender struct {
+	webhookURL string
+}
+
+// NewDiscordSender создаёт отправителя для Discord.
+func NewDiscordSender(webhookURL string) *DiscordSender {
+	return &DiscordSender{webhookURL: webhookURL}
+}
+
+// Name возвращает имя канала.
+func (d *DiscordSender) Name() string { return "discord" }
+
+// Send отправляет текстовое сообщение в Discord.
+func (d *DiscordSender) Send(text string) error {
+	return postJSON(d.webhookURL, map[string]string{"content": text})
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Messenger — канал доставки коротких уведомлений о достижениях.
+type Messenger interface {
+	// Name возвращает имя канала для логов.
+	Name() string
+	// Send отправляет текстовое сообщение.
+	Send(text string) error
+}
+
+// messengerClient — общий HTTP-клиент каналов уведомлений.
+var messengerClient = &http.Client{Timeout: 10 * time.Second}
+
+// postJSON отправляет JSON-пейлоад на webhook-URL мессенджера.
+func postJSON(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := messengerClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"bytes"
+	"database/sql"
+	"log"
+	"text/template"
+)
+
+// Ключи настроек уведомлений о достижениях (таблица settings).
+const (
+	// SettingMilestonesEnabled — opt-in: "1" включает уведомления.
+	SettingMilestonesEnabled = "notify.milestones.enabled"
+	// SettingDiscordWebhookURL — webhook-URL канала Discord.
+	SettingDiscordWebhookURL = "notify.discord.webhook_url"
+	// SettingSlackWebhookURL — webhook-URL канала Slack.
+	SettingSlackWebhookURL = "notify.slack.webhook_url"
+)
+
+// Шаблоны сообщений о достижениях.
+var milestoneTemplates = map[string]*template.Template{
+	"module_completed": template.Must(template.New("module_completed").Parse(
+		"🎉 Модуль «{{.Title}}» завершён — {{.Lessons}} уроков пройдено!")),
+	"streak_achieved": template.Must(template.New("streak_achieved").Parse(
+		"🔥 Серия занятий: {{.Days}} дней подряд. Так держать!")),
+	"digest": template.Must(template.New("digest").Parse(
+		"📬 Дайджест: пройдено {{.Completed}} из {{.Total}} уроков, набрано {{.Points}} очков." +
+			"{{if .Stale}} Зависло в чтении: {{.Stale}} — загляните на главную.{{end}}")),
+}
+
+// Milestones — отправка сообщений о достижениях в настроенные мессенджеры.
+// Каналы и opt-in читаются из таблицы settings при каждой отправке,
+// поэтому изменения настроек применяются без перезапуска.
+type Milestones struct {
+	db *sql.DB
+}
+
+// NewMilestones создаёт сервис уведомлений о достижениях.
+func NewMilestones(db *sql.DB) *Milestones {
+	return &Milestones{db: db}
+}
+
+// ModuleCompleted сообщает о завершении модуля.
+func (m *Milestones) ModuleCompleted(moduleTitle string, lessonCount int) {
+	m.send("module_completed", map[string]interface{}{
+		"Title":   moduleTitle,
+		"Lessons": lessonCount,
+	})
+}
+
+// StreakAchieved сообщает о серии дней занятий.
+func (m *Milestones) StreakAchieved(days int) {
+	m.send("streak_achieved", map[string]interface{}{
+		"Days": days,
+	})
+}
+
+// Digest отправляет сводку прогресса (задача notify.digest планировщика).
+// stale — число уроков, зависших в статусе «читаю».
+func (m *Milestones) Digest(completed, total, points, stale int) {
+	m.send("digest", map[string]interface{}{
+		"Completed": completed,
+		"Total":     total,
+		"Points":    points,
+		"Stale":     stale,
+	})
+}
+
+// send рендерит шаблон и асинхронно рассылает сообщение по каналам.
+func (m *Milestones) send(templateName string, data map[string]interface{}) {
+	if m.getSetting(SettingMilestonesEnabled) != "1" {
+		return
+	}
+
+	tmpl, ok := milestoneTemplates[templateName]
+	if !ok {
+		log.Printf("Milestones: неизвестный шаблон %q", templateName)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Printf("Milestones: ошибка рендеринга %q: %v", templateName, err)
+		return
+	}
+	text := buf.String()
+
+	for _, messenger := range m.messengers() {
+		go func(msn Messenger) {
+			if err := msn.Send(text); err != nil {
+				log.Printf("Milestones: ошибка отправки в %s: %v", msn.Name(), err)
+			}
+		}(messenger)
+	}
+}
+
+// messengers собирает настроенные каналы доставки.
+func (m *Milestones) messengers() []Messenger {
+	var result []Messenger
+	if url := m.getSetting(SettingDiscordWebhookURL); url != "" {
+		result = append(result, NewDiscordSender(url))
+	}
+	if url := m.getSetting(SettingSlackWebhookURL); url != "" {
+		result = append(result, NewSlackSender(url))
+	}
+	return result
+}
+
+// getSetting возвращает значение настройки или пустую строку.
+func (m *Milestones) getSetting(key string) string {
+	var value string
+	err := m.db.QueryRow(`SELECT value FROM settings WHERE key = ?`, key).Scan(&value)
+	if err != nil {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,19 @@
+package notify
+
+// SlackSender отправляет сообщения в канал Slack через incoming webhook.
+type SlackSender struct {
+	webhookURL string
+}
+
+// NewSlackSender создаёт отправителя для Slack.
+func NewSlackSender(webhookURL string) *SlackSender {
+	return &SlackSender{webhookURL: webhookURL}
+}
+
+// Name возвращает имя канала.
+func (s *SlackSender) Name() string { return "slack" }
+
+// Send отправляет текстовое сообщение в Slack.
+func (s *SlackSender) Send(text string) error {
+	return postJSON(s.webhookURL, map[string]string{"text": text})
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// События, по которым отправляются вебхуки.
+const (
+	EventSubmissionPassed = "submission.passed"
+	EventLessonCompleted  = "lesson.completed"
+	EventModuleCompleted  = "module.completed"
+)
+
+// maxDeliveryAttempts — количество попыток доставки одного вебхука.
+const maxDeliveryAttempts = 3
+
+// retryBackoff — пауза перед каждой повторной попыткой.
+var retryBackoff = []time.Duration{0, 2 * time.Second, 10 * time.Second}
+
+// Webhook — настроенный получатель событий.
+type Webhook struct {
+	ID     int64
+	URL    string
+	Secret string
+	Events string
+	Active bool
+}
+
+// Webhooks — сервис отправки исходящих вебхуков.
+// Доставка происходит асинхронно с повторами и журналируется в webhook_deliveries.
+type Webhooks struct {
+	db     *sql.DB
+	client *http.Client
+}
+
+// NewWebhooks создаёт сервис вебхуков.
+func NewWebhooks(db *sql.DB) *Webhooks {
+	return &Webhooks{
+		db:     db,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify отправляет событие всем подходящим вебхукам.
+// Ошибки доставки не влияют на основной поток — они журналируются.
+func (w *Webhooks) Notify(event string, data map[string]interface{}) {
+	hooks, err := w.listActive()
+	if err != nil {
+		log.Printf("Webhooks: ошибка загрузки списка: %v", err)
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":      event,
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"data":       data,
+	})
+	if err != nil {
+		log.Printf("Webhooks: ошибка сериализации события %s: %v", event, err)
+		return
+	}
+
+	for _, hook := range hooks {
+		if !hook.matches(event) {
+			continue
+		}
+		go w.deliver(hook, event, payload)
+	}
+}
+
+// matches проверяет, подписан ли вебхук на событие.
+func (h Webhook) matches(event string) bool {
+	if h.Events == "*" || h.Events == "" {
+		return true
+	}
+	for _, e := range strings.Split(h.Events, ",") {
+		if strings.TrimSpace(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// listActive возвращает активные вебхуки.
+func (w *Webhooks) listActive() ([]Webhook, error) {
+	rows, err := w.db.Query(`SELECT id, url, secret, events, active FROM webhooks WHERE active = 1`)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []Webhook
+	for rows.Next() {
+		var h Webhook
+		if err := rows.Scan(&h.ID, &h.URL, &h.Secret, &h.Events, &h.Active); err != nil {
+			return nil, fmt.Errorf("scan webhook: %w", err)
+		}
+		hooks = append(hooks, h)
+	}
+
+	return hooks, rows.Err()
+}
+
+// deliver пытается доставить событие с повторами и записывает результат в журнал.
+func (w *Webhooks) deliver(hook Webhook, event string, payload []byte) {
+	deliveryID, err := w.createDelivery(hook.ID, event, payload)
+	if err != nil {
+		log.Printf("Webhooks: ошибка записи доставки: %v", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxDeliveryAttempts; attempt++ {
+		time.Sleep(retryBackoff[attempt])
+
+		if lastErr = w.post(hook, payload); lastErr == nil {
+			w.finishDelivery(deliveryID, "delivered", attempt+1, "")
+			return
+		}
+	}
+
+	log.Printf("Webhooks: доставка на %s не удалась: %v", hook.URL, lastErr)
+	w.finishDelivery(deliveryID, "failed", maxDeliveryAttempts, lastErr.Error())
+}
+
+// post выполняет один HTTP-запрос к получателю.
+func (w *Webhooks) post(hook Webhook, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hook.Secret != "" {
+		req.Header.Set("X-GoLearn-Signature", sign(payload, hook.Secret))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign возвращает HMAC-SHA256 подпись тела запроса.
+func sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// createDelivery создаёт запись журнала доставки.
+func (w *Webhooks) createDelivery(webhookID int64, event string, payload []byte) (int64, error) {
+	result, err := w.db.Exec(
+		`INSERT INTO webhook_deliveries (webhook_id, event, payload) VALUES (?, ?, ?)`,
+		webhookID, event, string(payload),
+	)
+	if err != nil {
+		return 0, err
+	}
+	id, _ := result.LastInsertId()
+	return id, nil
+}
+
+// finishDelivery фиксирует итог доставки.
+func (w *Webhooks) finishDelivery(id int64, status string, attempts int, lastError string) {
+	var deliveredAt interface{}
+	if status == "delivered" {
+		deliveredAt = time.Now().UTC()
+	}
+	_, err := w.db.Exec(
+		`UPDATE webhook_deliveries SET status = ?, attempts = ?, last_error = ?, delivered_at = ? WHERE id = ?`,
+		status, attempts, lastError, deliveredAt, id,
+	)
+	if err != nil {
+		log.Printf("Webhooks: ошибка обновления журнала доставки: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Ключи настроек xAPI (таблица settings).
+const (
+	// SettingXAPIEndpoint — базовый URL LRS (например https://lrs.example.com/xapi).
+	SettingXAPIEndpoint = "xapi.endpoint"
+	// SettingXAPIUsername — имя пользователя Basic-авторизации LRS.
+	SettingXAPIUsername = "xapi.username"
+	// SettingXAPIPassword — пароль Basic-авторизации LRS.
+	SettingXAPIPassword = "xapi.password"
+	// SettingXAPIActor — email учащегося для поля actor (mbox).
+	SettingXAPIActor = "xapi.actor"
+)
+
+// Идентификаторы глаголов xAPI (реестр ADL).
+const (
+	verbExperienced = "http://adlnet.gov/expapi/verbs/experienced"
+	verbAttempted   = "http://adlnet.gov/expapi/verbs/attempted"
+	verbPassed      = "http://adlnet.gov/expapi/verbs/passed"
+)
+
+// XAPI отправляет xAPI-стейтменты об учебной активности в настроенный LRS.
+// Если endpoint не задан в настройках, отправка молча пропускается.
+type XAPI struct {
+	db     *sql.DB
+	client *http.Client
+}
+
+// NewXAPI создаёт отправителя xAPI-стейтментов.
+func NewXAPI(db *sql.DB) *XAPI {
+	return &XAPI{
+		db:     db,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// LessonExperienced фиксирует просмотр урока.
+func (x *XAPI) LessonExperienced(slug, title string) {
+	x.send(verbExperienced, "experienced", "lesson/"+slug, title)
+}
+
+// TaskAttempted фиксирует попытку решения задания.
+func (x *XAPI) TaskAttempted(taskID int64, title string) {
+	x.send(verbAttempted, "attempted", fmt.Sprintf("task/%d", taskID), title)
+}
+
+// TaskPassed фиксирует успешное решение задания.
+func (x *XAPI) TaskPassed(taskID int64, title string) {
+	x.send(verbPassed, "passed", fmt.Sprintf("task/%d", taskID), title)
+}
+
+// send собирает и асинхронно отправляет один стейтмент.
+func (x *XAPI) send(verbID, verbName, activityPath, activityName string) {
+	endpoint := x.getSetting(SettingXAPIEndpoint)
+	if endpoint == "" {
+		return
+	}
+
+	actor := x.getSetting(SettingXAPIActor)
+	if actor == "" {
+		actor = "learner@localhost"
+	}
+
+	statement := map[string]interface{}{
+		"actor": map[string]interface{}{
+			"objectType": "Agent",
+			"mbox":       "mailto:" + actor,
+		},
+		"verb": map[string]interface{}{
+			"id":      verbID,
+			"display": map[string]string{"en-US": verbName},
+		},
+		"object": map[string]interface{}{
+			"objectType": "Activity",
+			"id":         "https://golearning.local/" + activityPath,
+			"definition": map[string]interface{}{
+				"name": map[string]string{"ru-RU": activityName},
+			},
+		},
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	go x.post(endpoint, statement)
+}
+
+// post выполняет HTTP-запрос к LRS.
+func (x *XAPI) post(endpoint string, statement map[string]interface{}) {
+	body, err := json.Marshal(statement)
+	if err != nil {
+		log.Printf("xAPI: ошибка сериализации: %v", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint+"/statements", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("xAPI: ошибка запроса: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Experience-API-Version", "1.0.3")
+	if user := x.getSetting(SettingXAPIUsername); user != "" {
+		req.SetBasicAuth(user, x.getSetting(SettingXAPIPassword))
+	}
+
+	resp, err := x.client.Do(req)
+	if err != nil {
+		log.Printf("xAPI: ошибка отправки в LRS: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Printf("xAPI: LRS вернул статус %d", resp.StatusCode)
+	}
+}
+
+// getSetting возвращает значение настройки или пустую строку.
+func (x *XAPI) getSetting(key string) string {
+	var value string
+	if err := x.db.QueryRow(`SELECT value FROM settings WHERE key = ?`, key).Scan(&value); err != nil {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,254 @@
+// Package plan — генератор плана чтения. Непройденные уроки
+// раскладываются по дням до целевой даты с учётом оценки времени
+// чтения и доступных минут в день; план хранится в таблице plans
+// и показывается чеклистом на главной странице.
+package plan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Ключи настроек плана (таблица settings).
+const (
+	SettingTargetDate    = "plan.target_date"
+	SettingMinutesPerDay = "plan.minutes_per_day"
+)
+
+// defaultLessonMinutes подставляется урокам без оценки времени чтения.
+const defaultLessonMinutes = 5
+
+// Item — урок в плане на день.
+type Item struct {
+	LessonID    int64
+	LessonTitle string
+	LessonSlug  string
+	Minutes     int
+	Done        bool
+}
+
+// Day — план на один день.
+type Day struct {
+	Date    string // YYYY-MM-DD
+	Minutes int
+	Items   []Item
+}
+
+// Plan — текущий план чтения с прогрессом выполнения.
+type Plan struct {
+	TargetDate    string
+	MinutesPerDay int
+	Days          []Day
+	TotalLessons  int
+	DoneLessons   int
+}
+
+// Planner строит и читает план чтения поверх общей БД.
+type Planner struct {
+	db *sql.DB
+}
+
+// NewPlanner создаёт планировщик чтения.
+func NewPlanner(db *sql.DB) *Planner {
+	return &Planner{db: db}
+}
+
+// Generate строит план заново: жадно раскладывает непройденные уроки
+// в порядке курса по дням, не превышая minutesPerDay. Урок длиннее
+// дневного лимита занимает день целиком. Если план не умещается до
+// целевой даты, возвращается ошибка с подсказкой по минутам.
+func (p *Planner) Generate(ctx context.Context, targetDate time.Time, minutesPerDay int) error {
+	if minutesPerDay <= 0 {
+		return fmt.Errorf("минут в день должно быть больше нуля")
+	}
+
+	today := startOfDay(time.Now())
+	target := startOfDay(targetDate)
+	if target.Before(today) {
+		return fmt.Errorf("целевая дата уже прошла")
+	}
+
+	lessons, err := p.remainingLessons(ctx)
+	if err != nil {
+		return err
+	}
+	if len(lessons) == 0 {
+		return fmt.Errorf("все уроки уже пройдены — планировать нечего")
+	}
+
+	// Проверяем, что план умещается до целевой даты
+	total := 0
+	for _, l := range lessons {
+		total += l.minutes
+	}
+	daysAvailable := int(target.Sub(today).Hours()/24) + 1
+	if total > daysAvailable*minutesPerDay {
+		need := (total + daysAvailable - 1) / daysAvailable
+		return fmt.Errorf("не укладывается до %s: осталось ~%d мин чтения, нужно ~%d мин в день",
+			target.Format("02.01.2006"), total, need)
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin plan tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM plans`); err != nil {
+		return fmt.Errorf("clear plans: %w", err)
+	}
+
+	day := today
+	used := 0
+	for _, l := range lessons {
+		if used > 0 && used+l.minutes > minutesPerDay {
+			day = day.AddDate(0, 0, 1)
+			used = 0
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO plans (day, lesson_id, minutes) VALUES (?, ?, ?)`,
+			day.Format("2006-01-02"), l.id, l.minutes,
+		); err != nil {
+			return fmt.Errorf("insert plan item: %w", err)
+		}
+		used += l.minutes
+	}
+
+	for key, value := range map[string]string{
+		SettingTargetDate:    target.Format("2006-01-02"),
+		SettingMinutesPerDay: fmt.Sprintf("%d", minutesPerDay),
+	} {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO settings (key, value, updated_at)
+			 VALUES (?, ?, CURRENT_TIMESTAMP)
+			 ON CONFLICT(key) DO UPDATE SET
+			   value = excluded.value,
+			   updated_at = CURRENT_TIMESTAMP`,
+			key, value,
+		); err != nil {
+			return fmt.Errorf("save plan setting: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Current возвращает текущий план или (nil, nil), если плана нет.
+// Выполненность пунктов считается по статусу урока в прогрессе.
+func (p *Planner) Current(ctx context.Context) (*Plan, error) {
+	var targetDate, minutesPerDay string
+	err := p.db.QueryRowContext(ctx,
+		`SELECT COALESCE((SELECT value FROM settings WHERE key = ?), ''),
+		        COALESCE((SELECT value FROM settings WHERE key = ?), '')`,
+		SettingTargetDate, SettingMinutesPerDay,
+	).Scan(&targetDate, &minutesPerDay)
+	if err != nil {
+		return nil, fmt.Errorf("plan settings: %w", err)
+	}
+	if targetDate == "" {
+		return nil, nil
+	}
+
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT p.day, p.minutes, l.id, l.title, l.slug,
+		        COALESCE((SELECT status FROM progress WHERE lesson_id = l.id), 'new')
+		 FROM plans p
+		 JOIN lessons l ON l.id = p.lesson_id
+		 ORDER BY p.day, p.id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("plan items: %w", err)
+	}
+	defer rows.Close()
+
+	result := &Plan{TargetDate: targetDate}
+	fmt.Sscanf(minutesPerDay, "%d", &result.MinutesPerDay)
+
+	for rows.Next() {
+		var (
+			day    string
+			item   Item
+			status string
+		)
+		if err := rows.Scan(&day, &item.Minutes, &item.LessonID, &item.LessonTitle, &item.LessonSlug, &status); err != nil {
+			return nil, fmt.Errorf("scan plan item: %w", err)
+		}
+		item.Done = status == "done"
+
+		if len(result.Days) == 0 || result.Days[len(result.Days)-1].Date != day {
+			result.Days = append(result.Days, Day{Date: day})
+		}
+		d := &result.Days[len(result.Days)-1]
+		d.Items = append(d.Items, item)
+		d.Minutes += item.Minutes
+
+		result.TotalLessons++
+		if item.Done {
+			result.DoneLessons++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if result.TotalLessons == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// Clear удаляет текущий план.
+func (p *Planner) Clear(ctx context.Context) error {
+	if _, err := p.db.ExecContext(ctx, `DELETE FROM plans`); err != nil {
+		return fmt.Errorf("clear plans: %w", err)
+	}
+	_, err := p.db.ExecContext(ctx,
+		`DELETE FROM settings WHERE key IN (?, ?)`,
+		SettingTargetDate, SettingMinutesPerDay,
+	)
+	if err != nil {
+		return fmt.Errorf("clear plan settings: %w", err)
+	}
+	return nil
+}
+
+// plannedLesson — урок с оценкой времени чтения.
+type plannedLesson struct {
+	id      int64
+	minutes int
+}
+
+// remainingLessons возвращает непройденные уроки в порядке курса.
+func (p *Planner) remainingLessons(ctx context.Context) ([]plannedLesson, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT l.id, l.reading_time_min
+		 FROM lessons l
+		 JOIN modules m ON m.id = l.module_id
+		 WHERE l.deleted_at IS NULL AND m.deleted_at IS NULL
+		   AND l.id NOT IN (SELECT lesson_id FROM progress WHERE status = 'done')
+		 ORDER BY m.order_index, l.order_index, l.id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("remaining lessons: %w", err)
+	}
+	defer rows.Close()
+
+	var lessons []plannedLesson
+	for rows.Next() {
+		var l plannedLesson
+		if err := rows.Scan(&l.id, &l.minutes); err != nil {
+			return nil, fmt.Errorf("scan remaining lesson: %w", err)
+		}
+		if l.minutes <= 0 {
+			l.minutes = defaultLessonMinutes
+		}
+		lessons = append(lessons, l)
+	}
+	return lessons, rows.Err()
+}
+
+// startOfDay обнуляет время, оставляя дату.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,236 @@
+// Package astcheck проверяет структурные ограничения решения по его
+// AST: «циклы запрещены», «обязательно использовать горутины»,
+// «нельзя вызывать fmt.Sprintf». В отличие от текстового поиска
+// подстрок, разбор через go/ast не обмануть комментарием или строковым
+// литералом.
+package astcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// Виды правил.
+const (
+	// KindRequire — в решении должна встречаться конструкция (Arg —
+	// имя конструкции, см. constructNames).
+	KindRequire = "require"
+	// KindForbid — конструкция запрещена.
+	KindForbid = "forbid"
+	// KindRequireFunc — должна быть объявлена функция с именем Arg.
+	KindRequireFunc = "require-func"
+	// KindRequireCall — должен быть вызов функции Arg (например strings.Map).
+	KindRequireCall = "require-call"
+	// KindForbidCall — вызов функции Arg запрещён (например fmt.Sprintf).
+	KindForbidCall = "forbid-call"
+)
+
+// Rule — одно структурное правило.
+type Rule struct {
+	Kind string
+	Arg  string
+}
+
+// constructNames — конструкции, понятные require/forbid, и их русские
+// названия для сообщений об ошибках.
+var constructNames = map[string]string{
+	"loop":      "цикл",
+	"switch":    "switch",
+	"select":    "select",
+	"goroutine": "горутина",
+	"channel":   "канал",
+	"defer":     "defer",
+	"map":       "map",
+	"closure":   "функция-литерал",
+}
+
+// ParseRule разбирает запись правила вида "kind:arg" ("forbid:loop",
+// "require-func:square", "forbid-call:fmt.Sprintf").
+func ParseRule(spec string) (Rule, error) {
+	kind, arg, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok || arg == "" {
+		return Rule{}, fmt.Errorf("правило %q: ожидается формат kind:arg", spec)
+	}
+	r := Rule{Kind: strings.TrimSpace(kind), Arg: strings.TrimSpace(arg)}
+
+	switch r.Kind {
+	case KindRequire, KindForbid:
+		if _, ok := constructNames[r.Arg]; !ok {
+			return Rule{}, fmt.Errorf("правило %q: неизвестная конструкция %q", spec, r.Arg)
+		}
+	case KindRequireFunc, KindRequireCall, KindForbidCall:
+		// имя функции не ограничиваем
+	default:
+		return Rule{}, fmt.Errorf("правило %q: неизвестный вид %q", spec, r.Kind)
+	}
+	return r, nil
+}
+
+// ParseRules разбирает список записей, пропуская пустые строки.
+func ParseRules(specs []string) ([]Rule, error) {
+	var rules []Rule
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		r, err := ParseRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// Check применяет правила к исходному коду решения и возвращает список
+// нарушений (пустой — все правила соблюдены). Ошибка возвращается
+// только если код не разбирается; синтаксис при этом проверит компилятор,
+// поэтому вызывающий может трактовать её как «правила не применимы».
+func Check(code string, rules []Rule) ([]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", code, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse code: %w", err)
+	}
+
+	found := map[string]token.Pos{} // конструкция -> первое вхождение
+	calls := map[string]token.Pos{} // вызов pkg.Func или Func -> первое вхождение
+	funcs := map[string]bool{}      // объявленные функции
+
+	mark := func(m map[string]token.Pos, key string, pos token.Pos) {
+		if _, ok := m[key]; !ok {
+			m[key] = pos
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ForStmt:
+			mark(found, "loop", node.Pos())
+		case *ast.RangeStmt:
+			mark(found, "loop", node.Pos())
+		case *ast.SwitchStmt:
+			mark(found, "switch", node.Pos())
+		case *ast.TypeSwitchStmt:
+			mark(found, "switch", node.Pos())
+		case *ast.SelectStmt:
+			mark(found, "select", node.Pos())
+		case *ast.GoStmt:
+			mark(found, "goroutine", node.Pos())
+		case *ast.DeferStmt:
+			mark(found, "defer", node.Pos())
+		case *ast.ChanType:
+			mark(found, "channel", node.Pos())
+		case *ast.SendStmt:
+			mark(found, "channel", node.Pos())
+		case *ast.UnaryExpr:
+			if node.Op == token.ARROW {
+				mark(found, "channel", node.Pos())
+			}
+		case *ast.MapType:
+			mark(found, "map", node.Pos())
+		case *ast.FuncLit:
+			mark(found, "closure", node.Pos())
+		case *ast.FuncDecl:
+			funcs[node.Name.Name] = true
+		case *ast.CallExpr:
+			switch fn := node.Fun.(type) {
+			case *ast.Ident:
+				mark(calls, fn.Name, node.Pos())
+			case *ast.SelectorExpr:
+				if pkg, ok := fn.X.(*ast.Ident); ok {
+					mark(calls, pkg.Name+"."+fn.Sel.Name, node.Pos())
+				}
+			}
+		}
+		return true
+	})
+
+	line := func(pos token.Pos) int {
+		return fset.Position(pos).Line
+	}
+
+	var violations []string
+	for _, rule := range rules {
+		switch rule.Kind {
+		case KindRequire:
+			if _, ok := found[rule.Arg]; !ok {
+				violations = append(violations,
+					fmt.Sprintf("в решении должна использоваться конструкция «%s»", constructNames[rule.Arg]))
+			}
+		case KindForbid:
+			if pos, ok := found[rule.Arg]; ok {
+				violations = append(violations,
+					fmt.Sprintf("конструкция «%s» запрещена (строка %d)", constructNames[rule.Arg], line(pos)))
+			}
+		case KindRequireFunc:
+			if !funcs[rule.Arg] {
+				violations = append(violations,
+					fmt.Sprintf("в решении должна быть объявлена функция %s", rule.Arg))
+			}
+		case KindRequireCall:
+			if _, ok := calls[rule.Arg]; !ok {
+				violations = append(violations,
+					fmt.Sprintf("в решении должен быть вызов %s", rule.Arg))
+			}
+		case KindForbidCall:
+			if pos, ok := calls[rule.Arg]; ok {
+				violations = append(violations,
+					fmt.Sprintf("вызов %s запрещён (строка %d)", rule.Arg, line(pos)))
+			}
+		}
+	}
+	return violations, nil
+}
+
+// pkgCallRE — вызов вида pkg.Func (или метод на переменной: wg.Wait).
+var pkgCallRE = regexp.MustCompile(`^[A-Za-z_]\w*\.[A-Za-z_]\w*$`)
+
+// RuleFromKeyword переводит ключевое слово из текста урока
+// («**Используйте:** `for`») в запись правила. Второй результат —
+// false, если ключевому слову не соответствует никакое правило.
+func RuleFromKeyword(keyword string) (string, bool) {
+	kw := strings.TrimSpace(keyword)
+	switch kw {
+	case "for", "range":
+		return KindRequire + ":loop", true
+	case "switch":
+		return KindRequire + ":switch", true
+	case "select":
+		return KindRequire + ":select", true
+	case "go", "goroutine":
+		return KindRequire + ":goroutine", true
+	case "chan", "<-":
+		return KindRequire + ":channel", true
+	case "defer":
+		return KindRequire + ":defer", true
+	case "map":
+		return KindRequire + ":map", true
+	}
+	if name, ok := strings.CutPrefix(kw, "func "); ok && name != "" {
+		return KindRequireFunc + ":" + strings.TrimSpace(name), true
+	}
+	if pkgCallRE.MatchString(kw) {
+		return KindRequireCall + ":" + kw, true
+	}
+	return "", false
+}
+
+// RulesFromKeywords переводит список ключевых слов, разделённых |,
+// в список правил (тоже через |). Непереводимые слова отбрасываются.
+func RulesFromKeywords(keywords string) string {
+	var rules []string
+	for _, kw := range strings.Split(keywords, "|") {
+		if strings.TrimSpace(kw) == "" {
+			continue
+		}
+		if rule, ok := RuleFromKeyword(kw); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return strings.Join(rules, "|")
+}
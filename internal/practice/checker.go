@@ -3,21 +3,31 @@ package practice
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
+	"golearning/internal/analytics"
 	"golearning/internal/content"
+	"golearning/internal/notify"
+	"golearning/internal/practice/astcheck"
 	"golearning/internal/progress"
+	"golearning/internal/storage"
 )
 
 // Checker — сервис проверки решений.
 type Checker struct {
 	runner       Runner
-	contentRepo  *content.Repository
-	progressRepo *progress.Repository
+	contentRepo  content.Store
+	progressRepo progress.Store
+	notifier     *notify.Webhooks
+	xapi         *notify.XAPI
+	tracker      *analytics.Tracker
+	artifacts    storage.Store
 }
 
 // NewChecker создаёт новый checker.
-func NewChecker(runner Runner, contentRepo *content.Repository, progressRepo *progress.Repository) *Checker {
+func NewChecker(runner Runner, contentRepo content.Store, progressRepo progress.Store) *Checker {
 	return &Checker{
 		runner:       runner,
 		contentRepo:  contentRepo,
@@ -25,6 +35,26 @@ func NewChecker(runner Runner, contentRepo *content.Repository, progressRepo *pr
 	}
 }
 
+// SetNotifier подключает отправку вебхуков о событиях проверки.
+func (c *Checker) SetNotifier(n *notify.Webhooks) {
+	c.notifier = n
+}
+
+// SetXAPI подключает отправку xAPI-стейтментов в LRS.
+func (c *Checker) SetXAPI(x *notify.XAPI) {
+	c.xapi = x
+}
+
+// SetTracker подключает журнал событий обучения.
+func (c *Checker) SetTracker(t *analytics.Tracker) {
+	c.tracker = t
+}
+
+// SetArtifacts подключает хранилище артефактов для полных логов решений.
+func (c *Checker) SetArtifacts(st storage.Store) {
+	c.artifacts = st
+}
+
 // CheckResult — результат проверки задания.
 type CheckResult struct {
 	Success       bool
@@ -33,12 +63,17 @@ type CheckResult struct {
 	Error         string
 	Hints         []string
 	PointsAwarded int
+	// SubmissionID — запись об отправке; 0, если отправка не создавалась
+	// (синтаксическая ошибка, неизвестное задание).
+	SubmissionID int64 `json:",omitempty"`
+	// SyntaxErrors — позиции синтаксических ошибок для подсветки в редакторе.
+	SyntaxErrors []SyntaxError `json:",omitempty"`
 }
 
 // Check проверяет решение задания.
 func (c *Checker) Check(ctx context.Context, taskID int64, code string) (*CheckResult, error) {
 	// Получаем задание
-	task, err := c.contentRepo.GetTaskByID(taskID)
+	task, err := c.contentRepo.GetTaskByID(ctx, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("get task: %w", err)
 	}
@@ -49,46 +84,87 @@ func (c *Checker) Check(ctx context.Context, taskID int64, code string) (*CheckR
 		}, nil
 	}
 
+	// Быстрая синтаксическая проверка go/parser-ом до постановки в
+	// очередь: на опечатке учащийся получает точные позиции ошибок
+	// за миллисекунды, без песочницы и без записи отправки. Прогнозы
+	// вывода — не Go-код, их пропускаем.
+	if task.Kind != content.TaskKindPredictOutput {
+		if syntaxErrs := CheckSyntax(code); len(syntaxErrs) > 0 {
+			result := &CheckResult{
+				Error:        "Синтаксические ошибки в коде",
+				Hints:        []string{},
+				SyntaxErrors: syntaxErrs,
+			}
+			for _, e := range syntaxErrs {
+				result.Hints = append(result.Hints, fmt.Sprintf("Строка %d:%d — %s", e.Line, e.Col, e.Message))
+			}
+			return result, nil
+		}
+	}
+
 	// Создаём запись о submissions
 	submission := &progress.Submission{
-		TaskID: taskID,
-		Code:   code,
-		Status: "pending",
+		TaskID:         taskID,
+		Code:           code,
+		Status:         "pending",
+		StarterVersion: task.StarterVersion,
 	}
-	if err := c.progressRepo.CreateSubmission(submission); err != nil {
+	if err := c.progressRepo.CreateSubmission(ctx, submission); err != nil {
 		return nil, fmt.Errorf("create submission: %w", err)
 	}
 
+	// Забираем отправку в обработку. При нескольких репликах за
+	// балансировщиком это гарантирует, что решение проверится ровно раз.
+	claimed, err := c.progressRepo.ClaimSubmission(ctx, submission.ID)
+	if err != nil {
+		return nil, fmt.Errorf("claim submission: %w", err)
+	}
+	if !claimed {
+		return &CheckResult{
+			Success: false,
+			Error:   "Решение уже проверяется",
+		}, nil
+	}
+	submission.Status = "running"
+
+	if c.xapi != nil {
+		c.xapi.TaskAttempted(task.ID, task.Title)
+	}
+	if c.tracker != nil {
+		c.tracker.Track(ctx, analytics.EventTaskAttempted, task.LessonID, task.ID)
+	}
+
 	checkResult := &CheckResult{
-		Hints: []string{},
+		Hints:        []string{},
+		SubmissionID: submission.ID,
 	}
 
-	// Шаг 1: Проверяем обязательные паттерны в коде
-	if task.RequiredPatterns != "" {
-		patterns := strings.Split(task.RequiredPatterns, "|")
-		missingPatterns := []string{}
-		for _, pattern := range patterns {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" && !strings.Contains(code, pattern) {
-				missingPatterns = append(missingPatterns, pattern)
-			}
-		}
-		if len(missingPatterns) > 0 {
+	// Задание «предскажи вывод»: код учащегося не выполняется —
+	// его текстовый прогноз сверяется с выводом эталонного сниппета.
+	if task.Kind == content.TaskKindPredictOutput {
+		return c.checkPrediction(ctx, task, submission, code, checkResult)
+	}
+
+	// Шаг 1: Проверяем структурные правила по AST кода
+	if task.ASTRules != "" {
+		if violations := c.checkASTRules(task.ASTRules, code); len(violations) > 0 {
 			submission.Status = "error"
 			checkResult.Success = false
-			checkResult.Error = "В коде отсутствуют необходимые конструкции"
-			checkResult.Hints = append(checkResult.Hints, fmt.Sprintf("Используйте: %s", strings.Join(missingPatterns, ", ")))
-			c.progressRepo.UpdateSubmission(submission)
+			checkResult.Error = "Решение нарушает структурные требования задания"
+			checkResult.Hints = append(checkResult.Hints, violations...)
+			c.progressRepo.UpdateSubmission(ctx, submission)
 			return checkResult, nil
 		}
 	}
 
 	// Шаг 2: Запускаем код
-	runResult, err := c.runner.Run(ctx, code)
+	runStarted := time.Now()
+	runResult, err := c.runner.Run(ctx, code, taskLimits(task))
+	submission.DurationMS = time.Since(runStarted).Milliseconds()
 	if err != nil {
 		submission.Status = "error"
 		submission.Stderr = err.Error()
-		c.progressRepo.UpdateSubmission(submission)
+		c.progressRepo.UpdateSubmission(ctx, submission)
 		return nil, fmt.Errorf("run code: %w", err)
 	}
 
@@ -99,36 +175,42 @@ func (c *Checker) Check(ctx context.Context, taskID int64, code string) (*CheckR
 		checkResult.Success = false
 		checkResult.Output = runResult.Stdout
 		checkResult.Error = runResult.Error
-		c.progressRepo.UpdateSubmission(submission)
+		// Позиции ошибок компиляции — для подчёркивания строк в редакторе
+		checkResult.SyntaxErrors = ParseCompilerErrors(runResult.Error)
+		c.progressRepo.UpdateSubmission(ctx, submission)
 		return checkResult, nil
 	}
 
 	submission.Stdout = runResult.Stdout
 	checkResult.Output = runResult.Stdout
 
+	// Полный вывод больших программ уходит в хранилище артефактов,
+	// в БД остаётся усечённая копия
+	c.archiveOutput(ctx, submission)
+
 	// Шаг 3: Проверяем ожидаемый вывод
 	if task.ExpectedOutput != "" {
 		actualOutput := strings.TrimSpace(runResult.Stdout)
 		expectedOutput := strings.TrimSpace(task.ExpectedOutput)
 		checkResult.Expected = expectedOutput
 
-		if !c.compareOutput(actualOutput, expectedOutput) {
+		if !c.compareOutputWith(actualOutput, expectedOutput, parseOutputOptions(task.OutputOptions)) {
 			submission.Status = "error"
 			checkResult.Success = false
 			checkResult.Error = "Вывод программы не соответствует ожидаемому"
 			checkResult.Hints = append(checkResult.Hints, fmt.Sprintf("Ожидалось:\n%s", expectedOutput))
-			c.progressRepo.UpdateSubmission(submission)
+			c.progressRepo.UpdateSubmission(ctx, submission)
 			return checkResult, nil
 		}
 	}
 
 	// Шаг 4: Если есть тесты — запускаем их
 	if task.TestsGo != "" {
-		testResult, err := c.runner.Check(ctx, code, task.TestsGo)
+		testResult, err := c.runner.Check(ctx, code, task.TestsGo, taskLimits(task))
 		if err != nil {
 			submission.Status = "error"
 			submission.Stderr = err.Error()
-			c.progressRepo.UpdateSubmission(submission)
+			c.progressRepo.UpdateSubmission(ctx, submission)
 			return nil, fmt.Errorf("run tests: %w", err)
 		}
 
@@ -140,57 +222,214 @@ func (c *Checker) Check(ctx context.Context, taskID int64, code string) (*CheckR
 			if testResult.Error != "" {
 				checkResult.Hints = append(checkResult.Hints, testResult.Error)
 			}
-			c.progressRepo.UpdateSubmission(submission)
+			// Тесты могли не собраться — тогда в выводе есть позиции
+			checkResult.SyntaxErrors = ParseCompilerErrors(testResult.Error)
+			c.progressRepo.UpdateSubmission(ctx, submission)
 			return checkResult, nil
 		}
 	}
 
-	// Все проверки пройдены!
+	return c.finishSuccess(ctx, task, submission, checkResult)
+}
+
+// finishSuccess фиксирует успешную проверку: статус отправки, очки
+// с учётом политики курса, уведомления и события аналитики. Общий
+// хвост для обычных заданий и заданий «предскажи вывод».
+func (c *Checker) finishSuccess(ctx context.Context, task *content.Task, submission *progress.Submission, checkResult *CheckResult) (*CheckResult, error) {
 	checkResult.Success = true
 	submission.Status = "success"
 
-	// Проверяем, было ли задание уже решено ранее
-	alreadySolved, _ := c.progressRepo.IsTaskSolvedSuccessfully(taskID)
+	// Проверяем, было ли задание уже засчитано ранее
+	alreadyCompleted, _ := c.progressRepo.IsTaskCompleted(ctx, task.ID)
 
-	if !alreadySolved {
-		// Начисляем очки только при первом успешном решении
-		checkResult.PointsAwarded = task.Points
-		if err := c.progressRepo.SetPracticeDone(task.LessonID, task.Points); err != nil {
-			// Не критично, продолжаем
+	if !alreadyCompleted {
+		// Размер награды зависит от числа попыток и политики курса.
+		attempts, err := c.progressRepo.CountSubmissionsByTask(ctx, task.ID)
+		if err != nil || attempts < 1 {
+			attempts = 1
 		}
+		policy := c.pointsPolicyFor(ctx, task)
+		points := policy.Award(task.Points, attempts)
+		// CompleteTask идемпотентен: при гонке очки не задвоятся
+		if awarded, err := c.progressRepo.CompleteTask(ctx, task.ID, task.LessonID, points); err == nil && awarded {
+			checkResult.PointsAwarded = points
+		}
+	}
+
+	c.progressRepo.UpdateSubmission(ctx, submission)
+
+	if c.xapi != nil {
+		c.xapi.TaskPassed(task.ID, task.Title)
+	}
+	if c.tracker != nil {
+		c.tracker.Track(ctx, analytics.EventTaskPassed, task.LessonID, task.ID)
+	}
+	if c.notifier != nil {
+		c.notifier.Notify(notify.EventSubmissionPassed, map[string]interface{}{
+			"task_id":        task.ID,
+			"task_title":     task.Title,
+			"lesson_id":      task.LessonID,
+			"points_awarded": checkResult.PointsAwarded,
+		})
 	}
 
-	c.progressRepo.UpdateSubmission(submission)
 	return checkResult, nil
 }
 
-// compareOutput сравнивает фактический и ожидаемый вывод.
-// Поддерживает гибкое сравнение (игнорирует лишние пробелы, пустые строки).
-func (c *Checker) compareOutput(actual, expected string) bool {
-	// Нормализуем строки
-	actual = c.normalizeOutput(actual)
-	expected = c.normalizeOutput(expected)
+// checkPrediction проверяет задание «предскажи вывод»: эталонный
+// сниппет (StarterCode) выполняется один раз, его вывод кешируется в
+// БД, а прогноз учащегося сравнивается с ним по тем же правилам, что
+// и вывод обычной программы.
+func (c *Checker) checkPrediction(ctx context.Context, task *content.Task, submission *progress.Submission, prediction string, checkResult *CheckResult) (*CheckResult, error) {
+	canonical := task.CanonicalOutput
+	if canonical == "" {
+		runStarted := time.Now()
+		runResult, err := c.runner.Run(ctx, task.StarterCode, taskLimits(task))
+		submission.DurationMS = time.Since(runStarted).Milliseconds()
+		if err != nil {
+			submission.Status = "error"
+			submission.Stderr = err.Error()
+			c.progressRepo.UpdateSubmission(ctx, submission)
+			return nil, fmt.Errorf("run canonical snippet: %w", err)
+		}
+		if !runResult.Success {
+			// Сломан эталонный сниппет — это ошибка контента, а не учащегося
+			submission.Status = "error"
+			submission.Stderr = runResult.Error
+			c.progressRepo.UpdateSubmission(ctx, submission)
+			return nil, fmt.Errorf("canonical snippet failed: %s", runResult.Error)
+		}
+		canonical = runResult.Stdout
+		if err := c.contentRepo.SetTaskCanonicalOutput(ctx, task.ID, canonical); err != nil {
+			log.Printf("Ошибка кеширования вывода задания %d: %v", task.ID, err)
+		}
+	}
 
-	// Точное совпадение
-	if actual == expected {
-		return true
+	expectedOutput := strings.TrimSpace(canonical)
+	if !c.compareOutputWith(strings.TrimSpace(prediction), expectedOutput, parseOutputOptions(task.OutputOptions)) {
+		submission.Status = "error"
+		checkResult.Success = false
+		checkResult.Expected = expectedOutput
+		checkResult.Error = "Прогноз не совпал с выводом программы"
+		checkResult.Hints = append(checkResult.Hints, fmt.Sprintf("Программа выводит:\n%s", expectedOutput))
+		c.progressRepo.UpdateSubmission(ctx, submission)
+		return checkResult, nil
 	}
 
-	// Сравнение построчно (игнорируя пустые строки)
-	actualLines := c.nonEmptyLines(actual)
-	expectedLines := c.nonEmptyLines(expected)
+	return c.finishSuccess(ctx, task, submission, checkResult)
+}
 
-	if len(actualLines) != len(expectedLines) {
-		return false
+// Preview проверяет решение против временного задания — режим
+// «попробовать как студент» при редактировании. Задание берётся из
+// запроса, а не из БД; ни отправка, ни прогресс, ни очки не
+// записываются, так что автор может итерировать условие и тесты,
+// не замусоривая историю.
+func (c *Checker) Preview(ctx context.Context, task *content.Task, code string) (*CheckResult, error) {
+	checkResult := &CheckResult{
+		Hints: []string{},
 	}
 
-	for i := range actualLines {
-		if strings.TrimSpace(actualLines[i]) != strings.TrimSpace(expectedLines[i]) {
-			return false
+	if task.ASTRules != "" {
+		if violations := c.checkASTRules(task.ASTRules, code); len(violations) > 0 {
+			checkResult.Success = false
+			checkResult.Error = "Решение нарушает структурные требования задания"
+			checkResult.Hints = append(checkResult.Hints, violations...)
+			return checkResult, nil
 		}
 	}
 
-	return true
+	runResult, err := c.runner.Run(ctx, code, taskLimits(task))
+	if err != nil {
+		return nil, fmt.Errorf("run code: %w", err)
+	}
+	if !runResult.Success {
+		checkResult.Success = false
+		checkResult.Output = runResult.Stdout
+		checkResult.Error = runResult.Error
+		checkResult.SyntaxErrors = ParseCompilerErrors(runResult.Error)
+		return checkResult, nil
+	}
+	checkResult.Output = runResult.Stdout
+
+	if task.ExpectedOutput != "" {
+		actualOutput := strings.TrimSpace(runResult.Stdout)
+		expectedOutput := strings.TrimSpace(task.ExpectedOutput)
+		checkResult.Expected = expectedOutput
+
+		if !c.compareOutputWith(actualOutput, expectedOutput, parseOutputOptions(task.OutputOptions)) {
+			checkResult.Success = false
+			checkResult.Error = "Вывод программы не соответствует ожидаемому"
+			checkResult.Hints = append(checkResult.Hints, fmt.Sprintf("Ожидалось:\n%s", expectedOutput))
+			return checkResult, nil
+		}
+	}
+
+	if task.TestsGo != "" {
+		testResult, err := c.runner.Check(ctx, code, task.TestsGo, taskLimits(task))
+		if err != nil {
+			return nil, fmt.Errorf("run tests: %w", err)
+		}
+		if !testResult.Success {
+			checkResult.Success = false
+			checkResult.Error = "Тесты не пройдены"
+			if testResult.Error != "" {
+				checkResult.Hints = append(checkResult.Hints, testResult.Error)
+			}
+			return checkResult, nil
+		}
+	}
+
+	checkResult.Success = true
+	return checkResult, nil
+}
+
+// checkASTRules применяет правила astcheck задания к коду решения.
+// Неразборчивые правила (опечатка автора контента) пропускаются с
+// записью в лог — решение студента не должно падать из-за ошибки в
+// контенте; неразборчивый код тоже пропускается — синтаксическую
+// ошибку покажет компилятор на следующем шаге.
+func (c *Checker) checkASTRules(specs, code string) []string {
+	rules, err := astcheck.ParseRules(strings.Split(specs, "|"))
+	if err != nil {
+		log.Printf("Ошибка разбора правил задания (%q): %v", specs, err)
+		return nil
+	}
+	violations, err := astcheck.Check(code, rules)
+	if err != nil {
+		return nil
+	}
+	return violations
+}
+
+// maxInlineOutput — порог, после которого полный вывод отправки
+// уходит в хранилище артефактов вместо БД.
+const maxInlineOutput = 64 * 1024
+
+// archiveOutput сохраняет полный вывод большой отправки артефактом
+// и усекает копию в БД. Без подключённого хранилища вывод просто
+// усекается; сбой сохранения не ломает проверку.
+func (c *Checker) archiveOutput(ctx context.Context, submission *progress.Submission) {
+	if len(submission.Stdout) <= maxInlineOutput {
+		return
+	}
+
+	note := "\n… вывод усечён"
+	if c.artifacts != nil {
+		key := fmt.Sprintf("submissions/%d/output.log", submission.ID)
+		if err := c.artifacts.Put(ctx, key, strings.NewReader(submission.Stdout)); err != nil {
+			log.Printf("Ошибка сохранения артефакта %s: %v", key, err)
+		} else {
+			note = "\n… полный вывод сохранён: " + key
+		}
+	}
+	submission.Stdout = submission.Stdout[:maxInlineOutput] + note
+}
+
+// compareOutput сравнивает фактический и ожидаемый вывод с базовой
+// нормализацией (лишние пробелы, пустые строки); дополнительные
+// ослабления задаются флагами задания, см. compareOutputWith.
+func (c *Checker) compareOutput(actual, expected string) bool {
+	return c.compareOutputWith(actual, expected, outputOptions{})
 }
 
 // normalizeOutput нормализует вывод для сравнения.
@@ -219,5 +458,14 @@ func (c *Checker) nonEmptyLines(s string) []string {
 
 // Run просто выполняет код без проверки.
 func (c *Checker) Run(ctx context.Context, code string) (*RunResult, error) {
-	return c.runner.Run(ctx, code)
+	return c.runner.Run(ctx, code, RunLimits{})
+}
+
+// taskLimits собирает лимиты выполнения из настроек задания.
+// Нулевые колонки дают нулевые лимиты — runner подставит умолчания.
+func taskLimits(task *content.Task) RunLimits {
+	return RunLimits{
+		Timeout:  time.Duration(task.RunTimeoutSec) * time.Second,
+		MemoryMB: task.MemoryLimitMB,
+	}
 }
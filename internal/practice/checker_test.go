@@ -0,0 +1,178 @@
+package practice
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golearning/internal/content"
+	"golearning/internal/progress"
+)
+
+// helloCode — решение с литеральным выводом: FakeRunner восстановит
+// его из AST без запуска.
+const helloCode = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Hello, Go!")
+}
+`
+
+// seedTask наполняет фейковое хранилище минимальной иерархией
+// курс → модуль → урок и кладёт в урок задание.
+func seedTask(t *testing.T, store *content.FakeStore, task content.Task) *content.Task {
+	t.Helper()
+	ctx := context.Background()
+
+	course := content.Course{Slug: "go-basics", Title: "Основы Go"}
+	if err := store.CreateCourse(ctx, &course); err != nil {
+		t.Fatalf("create course: %v", err)
+	}
+	module := content.Module{CourseID: course.ID, Slug: "osnovy", Title: "Основы"}
+	if err := store.CreateModule(ctx, &module); err != nil {
+		t.Fatalf("create module: %v", err)
+	}
+	lesson := content.Lesson{ModuleID: module.ID, Slug: "vvedenie", Title: "Введение"}
+	if err := store.CreateLesson(ctx, &lesson); err != nil {
+		t.Fatalf("create lesson: %v", err)
+	}
+
+	task.LessonID = lesson.ID
+	if err := store.CreateTask(ctx, &task); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	return &task
+}
+
+func TestCheckerAwardsPointsOnce(t *testing.T) {
+	contentStore := content.NewFakeStore()
+	progressStore := progress.NewFakeStore()
+	task := seedTask(t, contentStore, content.Task{
+		Kind:           content.TaskKindCode,
+		Title:          "Hello, World!",
+		ExpectedOutput: "Hello, Go!",
+		Points:         10,
+	})
+
+	checker := NewChecker(NewFakeRunner(), contentStore, progressStore)
+	ctx := context.Background()
+
+	result, err := checker.Check(ctx, task.ID, helloCode)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("ожидался успех, получено: %+v", result)
+	}
+	if result.PointsAwarded != task.Points {
+		t.Errorf("PointsAwarded = %d, ожидалось %d", result.PointsAwarded, task.Points)
+	}
+	if result.SubmissionID == 0 {
+		t.Fatal("SubmissionID не заполнен")
+	}
+
+	sub, err := progressStore.GetSubmissionByID(ctx, result.SubmissionID)
+	if err != nil || sub == nil {
+		t.Fatalf("get submission: %v (%v)", sub, err)
+	}
+	if sub.Status != "success" {
+		t.Errorf("статус отправки = %q, ожидался success", sub.Status)
+	}
+	if done, _ := progressStore.IsTaskCompleted(ctx, task.ID); !done {
+		t.Error("задание не отмечено выполненным")
+	}
+
+	// Повторное решение засчитывается, но очки не задваиваются
+	again, err := checker.Check(ctx, task.ID, helloCode)
+	if err != nil {
+		t.Fatalf("повторный check: %v", err)
+	}
+	if !again.Success {
+		t.Fatalf("ожидался успех повторной проверки, получено: %+v", again)
+	}
+	if again.PointsAwarded != 0 {
+		t.Errorf("повторные очки = %d, ожидалось 0", again.PointsAwarded)
+	}
+}
+
+func TestCheckerWrongOutput(t *testing.T) {
+	contentStore := content.NewFakeStore()
+	progressStore := progress.NewFakeStore()
+	task := seedTask(t, contentStore, content.Task{
+		Kind:           content.TaskKindCode,
+		Title:          "Hello, World!",
+		ExpectedOutput: "Hello, Go!",
+		Points:         10,
+	})
+
+	checker := NewChecker(NewFakeRunner(), contentStore, progressStore)
+	ctx := context.Background()
+
+	code := strings.Replace(helloCode, "Hello, Go!", "Bye", 1)
+	result, err := checker.Check(ctx, task.ID, code)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if result.Success {
+		t.Fatalf("ожидался провал, получено: %+v", result)
+	}
+	if result.Expected != task.ExpectedOutput {
+		t.Errorf("Expected = %q, ожидалось %q", result.Expected, task.ExpectedOutput)
+	}
+
+	sub, err := progressStore.GetSubmissionByID(ctx, result.SubmissionID)
+	if err != nil || sub == nil {
+		t.Fatalf("get submission: %v (%v)", sub, err)
+	}
+	if sub.Status != "error" {
+		t.Errorf("статус отправки = %q, ожидался error", sub.Status)
+	}
+	if done, _ := progressStore.IsTaskCompleted(ctx, task.ID); done {
+		t.Error("провальное решение засчитано выполненным")
+	}
+}
+
+func TestCheckerUnknownTask(t *testing.T) {
+	checker := NewChecker(NewFakeRunner(), content.NewFakeStore(), progress.NewFakeStore())
+
+	result, err := checker.Check(context.Background(), 42, helloCode)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if result.Success || result.Error != "Задание не найдено" {
+		t.Errorf("неожиданный результат: %+v", result)
+	}
+}
+
+func TestCheckerSyntaxErrorSkipsSubmission(t *testing.T) {
+	contentStore := content.NewFakeStore()
+	progressStore := progress.NewFakeStore()
+	task := seedTask(t, contentStore, content.Task{
+		Kind:   content.TaskKindCode,
+		Title:  "Hello, World!",
+		Points: 10,
+	})
+
+	checker := NewChecker(NewFakeRunner(), contentStore, progressStore)
+	ctx := context.Background()
+
+	result, err := checker.Check(ctx, task.ID, "package main\n\nfunc main() {\n")
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if result.Success {
+		t.Fatalf("ожидался провал, получено: %+v", result)
+	}
+	if len(result.SyntaxErrors) == 0 {
+		t.Error("нет позиций синтаксических ошибок")
+	}
+	// Опечатка отбивается до песочницы — отправка не создаётся
+	if result.SubmissionID != 0 {
+		t.Errorf("SubmissionID = %d, ожидалось 0", result.SubmissionID)
+	}
+	if attempts, _ := progressStore.CountSubmissionsByTask(ctx, task.ID); attempts != 0 {
+		t.Errorf("отправок = %d, ожидалось 0", attempts)
+	}
+}
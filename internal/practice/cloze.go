@@ -0,0 +1,63 @@
+package practice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ClozePlaceholder — маркер пропуска в шаблоне задания с пропусками
+// (kind = cloze). Шаблон хранится в StarterCode задания.
+const ClozePlaceholder = "/*___*/"
+
+// CountClozeBlanks возвращает число пропусков в шаблоне.
+func CountClozeBlanks(template string) int {
+	return strings.Count(template, ClozePlaceholder)
+}
+
+// MergeCloze подставляет ответы учащегося в шаблон по порядку
+// пропусков. Число ответов должно совпадать с числом пропусков.
+func MergeCloze(template string, blanks []string) (string, error) {
+	total := CountClozeBlanks(template)
+	if len(blanks) != total {
+		return "", fmt.Errorf("получено %d ответов при %d пропусках", len(blanks), total)
+	}
+
+	var merged strings.Builder
+	rest := template
+	for _, blank := range blanks {
+		idx := strings.Index(rest, ClozePlaceholder)
+		merged.WriteString(rest[:idx])
+		merged.WriteString(blank)
+		rest = rest[idx+len(ClozePlaceholder):]
+	}
+	merged.WriteString(rest)
+	return merged.String(), nil
+}
+
+// CheckCloze проверяет задание с пропусками: ответы подставляются в
+// шаблон из StarterCode, и собранное решение проходит обычную
+// проверку — с записью отправки и начислением очков.
+func (c *Checker) CheckCloze(ctx context.Context, taskID int64, blanks []string) (*CheckResult, error) {
+	task, err := c.contentRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if task == nil {
+		return &CheckResult{
+			Success: false,
+			Error:   "Задание не найдено",
+		}, nil
+	}
+
+	merged, err := MergeCloze(task.StarterCode, blanks)
+	if err != nil {
+		return &CheckResult{
+			Success: false,
+			Error:   "Число ответов не совпадает с числом пропусков",
+			Hints:   []string{err.Error()},
+		}, nil
+	}
+
+	return c.Check(ctx, taskID, merged)
+}
@@ -0,0 +1,96 @@
+package practice
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"golearning/internal/content"
+)
+
+// GuidedStepResult — результат проверки одного шага пошагового
+// задания поверх обычного CheckResult.
+type GuidedStepResult struct {
+	*CheckResult
+	// StepIndex — проверенный шаг.
+	StepIndex int
+	// Completed — пройден последний шаг, задание засчитано.
+	Completed bool
+}
+
+// CheckGuidedStep проверяет шаг пошагового задания. Шаги «запираются»:
+// шаг доступен, только когда пройдены все предыдущие. Код учащегося
+// сохраняется при каждой попытке, чтобы перенести его на следующий
+// шаг; после прохождения последнего шага задание засчитывается с
+// полной наградой.
+func (c *Checker) CheckGuidedStep(ctx context.Context, taskID int64, stepIndex int, code string) (*GuidedStepResult, error) {
+	task, err := c.contentRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if task == nil || task.Kind != content.TaskKindGuided {
+		return &GuidedStepResult{
+			CheckResult: &CheckResult{Success: false, Error: "Задание не найдено"},
+			StepIndex:   stepIndex,
+		}, nil
+	}
+
+	steps, err := c.contentRepo.GetTaskStepsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task steps: %w", err)
+	}
+	if stepIndex < 0 || stepIndex >= len(steps) {
+		return &GuidedStepResult{
+			CheckResult: &CheckResult{Success: false, Error: "Шаг не найден"},
+			StepIndex:   stepIndex,
+		}, nil
+	}
+
+	state, err := c.progressRepo.GetGuidedSteps(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get guided progress: %w", err)
+	}
+	for i := 0; i < stepIndex; i++ {
+		if !state[i].Passed {
+			return &GuidedStepResult{
+				CheckResult: &CheckResult{Success: false, Error: "Сначала пройдите предыдущие шаги"},
+				StepIndex:   stepIndex,
+			}, nil
+		}
+	}
+
+	// Шаг проверяется как временное задание: условия свои, а правила
+	// сравнения вывода и лимиты наследуются от родительского задания
+	step := steps[stepIndex]
+	stepTask := &content.Task{
+		ExpectedOutput: step.ExpectedOutput,
+		TestsGo:        step.TestsGo,
+		OutputOptions:  task.OutputOptions,
+		RunTimeoutSec:  task.RunTimeoutSec,
+		MemoryLimitMB:  task.MemoryLimitMB,
+	}
+	checkResult, err := c.Preview(ctx, stepTask, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Код сохраняется даже при неудаче — чтобы не потерять работу
+	if err := c.progressRepo.SaveGuidedStep(ctx, taskID, stepIndex, code, checkResult.Success); err != nil {
+		log.Printf("Ошибка сохранения шага %d задания %d: %v", stepIndex, taskID, err)
+	}
+
+	result := &GuidedStepResult{
+		CheckResult: checkResult,
+		StepIndex:   stepIndex,
+	}
+
+	// Последний шаг пройден — задание засчитывается целиком
+	if checkResult.Success && stepIndex == len(steps)-1 {
+		result.Completed = true
+		if awarded, err := c.progressRepo.CompleteTask(ctx, task.ID, task.LessonID, task.Points); err == nil && awarded {
+			checkResult.PointsAwarded = task.Points
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package practice
+
+// Ката — небольшие упражнения, где проверяется не только правильность
+// вывода, но и способ решения: структурные ограничения («без циклов»,
+// «обязательно горутины») контролирует internal/practice/astcheck.
+// Список ката определяется в коде, как и capstone-проекты; прогресс
+// хранится в таблице kata_results.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golearning/internal/practice/astcheck"
+)
+
+// Kata — упражнение со структурными ограничениями.
+type Kata struct {
+	Slug           string
+	Title          string
+	PromptMD       string
+	StarterCode    string
+	RuleSpecs      []string // правила astcheck в записи kind:arg
+	ExpectedOutput string
+}
+
+// Katas возвращает трек ката в порядке прохождения.
+func Katas() []Kata {
+	return []Kata{
+		{
+			Slug:  "summa-bez-tsiklov",
+			Title: "Сумма без циклов",
+			PromptMD: `Напечатайте сумму чисел от 1 до 100 — **без единого цикла**.
+
+Подойдёт формула арифметической прогрессии или рекурсия. Смысл ката —
+вспомнить, что не каждая задача «перебора» требует перебора.`,
+			StarterCode: `package main
+
+import "fmt"
+
+func main() {
+	// Выведите сумму 1..100, не используя for
+	fmt.Println(0)
+}`,
+			RuleSpecs:      []string{"forbid:loop"},
+			ExpectedOutput: "5050",
+		},
+		{
+			Slug:  "fizzbuzz-na-switch",
+			Title: "FizzBuzz на switch",
+			PromptMD: `Классический FizzBuzz для чисел от 1 до 15, но ветвление —
+**только через switch**: числа, кратные 3 — ` + "`Fizz`" + `, кратные 5 — ` + "`Buzz`" + `,
+кратные 15 — ` + "`FizzBuzz`" + `, остальные печатаются как есть.`,
+			StarterCode: `package main
+
+import "fmt"
+
+func main() {
+	for i := 1; i <= 15; i++ {
+		// Замените if-цепочку на switch
+		fmt.Println(i)
+	}
+}`,
+			RuleSpecs:      []string{"require:switch"},
+			ExpectedOutput: "1\n2\nFizz\n4\nBuzz\nFizz\n7\n8\nFizz\nBuzz\n11\nFizz\n13\n14\nFizzBuzz",
+		},
+		{
+			Slug:  "parallelnaya-summa",
+			Title: "Параллельная сумма",
+			PromptMD: `Посчитайте сумму чисел от 1 до 100, разделив работу между
+**несколькими горутинами**. Частичные суммы передайте в main через канал
+и сложите.`,
+			StarterCode: `package main
+
+import "fmt"
+
+func main() {
+	// Разбейте диапазон на части, посчитайте частичные суммы
+	// в горутинах и соберите результат через канал
+	fmt.Println(0)
+}`,
+			RuleSpecs:      []string{"require:goroutine", "require:channel"},
+			ExpectedOutput: "5050",
+		},
+		{
+			Slug:  "format-bez-sprintf",
+			Title: "Форматирование без Sprintf",
+			PromptMD: `Соберите строку ` + "`x = 42`" + ` и напечатайте её — но вызывать
+` + "`fmt.Sprintf`" + ` **нельзя**. Вспомните пакет ` + "`strconv`" + ` и конкатенацию.`,
+			StarterCode: `package main
+
+import "fmt"
+
+func main() {
+	x := 42
+	// Соберите строку "x = 42" без fmt.Sprintf
+	_ = x
+	fmt.Println("")
+}`,
+			RuleSpecs:      []string{"forbid-call:fmt.Sprintf", "require-call:strconv.Itoa"},
+			ExpectedOutput: "x = 42",
+		},
+	}
+}
+
+// KataBySlug возвращает ката по slug'у. (nil, nil)-семантика не нужна:
+// списка нет в БД, поэтому просто nil для неизвестного slug'а.
+func KataBySlug(slug string) *Kata {
+	for _, k := range Katas() {
+		if k.Slug == slug {
+			return &k
+		}
+	}
+	return nil
+}
+
+// CheckKata проверяет решение ката: сначала структурные ограничения по
+// AST, затем запуск и сравнение вывода. Попытка записывается в
+// kata_results независимо от результата.
+func (c *Checker) CheckKata(ctx context.Context, kata *Kata, code string) (*CheckResult, error) {
+	rules, err := astcheck.ParseRules(kata.RuleSpecs)
+	if err != nil {
+		return nil, fmt.Errorf("parse kata rules: %w", err)
+	}
+
+	checkResult := &CheckResult{
+		Hints: []string{},
+	}
+	passed := false
+	defer func() {
+		_ = c.progressRepo.RecordKataAttempt(ctx, kata.Slug, passed)
+	}()
+
+	// Шаг 1: структурные ограничения. Если код не разбирается,
+	// пропускаем — синтаксическую ошибку покажет компилятор на шаге 2.
+	violations, err := astcheck.Check(code, rules)
+	if err == nil && len(violations) > 0 {
+		checkResult.Success = false
+		checkResult.Error = "Нарушены ограничения ката"
+		checkResult.Hints = append(checkResult.Hints, violations...)
+		return checkResult, nil
+	}
+
+	// Шаг 2: запускаем код
+	runResult, err := c.runner.Run(ctx, code, RunLimits{})
+	if err != nil {
+		return nil, fmt.Errorf("run code: %w", err)
+	}
+	if !runResult.Success {
+		checkResult.Success = false
+		checkResult.Output = runResult.Stdout
+		checkResult.Error = runResult.Error
+		return checkResult, nil
+	}
+	checkResult.Output = runResult.Stdout
+
+	// Шаг 3: сравниваем вывод
+	checkResult.Expected = kata.ExpectedOutput
+	if !c.compareOutput(strings.TrimSpace(runResult.Stdout), strings.TrimSpace(kata.ExpectedOutput)) {
+		checkResult.Success = false
+		checkResult.Error = "Вывод программы не соответствует ожидаемому"
+		checkResult.Hints = append(checkResult.Hints, fmt.Sprintf("Ожидалось:\n%s", kata.ExpectedOutput))
+		return checkResult, nil
+	}
+
+	checkResult.Success = true
+	passed = true
+	return checkResult, nil
+}
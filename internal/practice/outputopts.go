@@ -0,0 +1,115 @@
+package practice
+
+// Настройки сравнения вывода программы с ожидаемым. Базовая
+// нормализация (Windows-переносы, пробелы по краям строк, пустые
+// строки) применяется всегда; флаги задания ослабляют сравнение
+// дальше — чтобы задача про площадь круга не падала из-за
+// «3.14» против «3.140000».
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// outputOptions — разобранные флаги сравнения вывода.
+type outputOptions struct {
+	// CaseInsensitive — сравнивать без учёта регистра.
+	CaseInsensitive bool
+	// IgnoreLineOrder — порядок строк не важен (вывод из горутин).
+	IgnoreLineOrder bool
+	// FloatTolerance — числа с плавающей точкой считаются равными
+	// при разнице не больше этого значения (0 — точное сравнение).
+	FloatTolerance float64
+}
+
+// parseOutputOptions разбирает флаги из колонки output_options
+// (разделённые |). Неизвестные флаги молча пропускаются — сравнение
+// просто остаётся строже, решение студента от этого не падает.
+func parseOutputOptions(spec string) outputOptions {
+	var opts outputOptions
+	for _, flag := range strings.Split(spec, "|") {
+		flag = strings.TrimSpace(flag)
+		switch {
+		case flag == "case-insensitive":
+			opts.CaseInsensitive = true
+		case flag == "ignore-line-order":
+			opts.IgnoreLineOrder = true
+		case strings.HasPrefix(flag, "float-tolerance="):
+			if tol, err := strconv.ParseFloat(strings.TrimPrefix(flag, "float-tolerance="), 64); err == nil && tol > 0 {
+				opts.FloatTolerance = tol
+			}
+		}
+	}
+	return opts
+}
+
+// compareOutputWith сравнивает вывод с учётом флагов задания.
+func (c *Checker) compareOutputWith(actual, expected string, opts outputOptions) bool {
+	actual = c.normalizeOutput(actual)
+	expected = c.normalizeOutput(expected)
+
+	actualLines := c.nonEmptyLines(actual)
+	expectedLines := c.nonEmptyLines(expected)
+
+	if len(actualLines) != len(expectedLines) {
+		return false
+	}
+
+	if opts.IgnoreLineOrder {
+		sort.Strings(actualLines)
+		sort.Strings(expectedLines)
+	}
+
+	for i := range actualLines {
+		if !linesEqual(actualLines[i], expectedLines[i], opts) {
+			return false
+		}
+	}
+	return true
+}
+
+// linesEqual сравнивает одну строку вывода с ожидаемой.
+func linesEqual(actual, expected string, opts outputOptions) bool {
+	actual = strings.TrimSpace(actual)
+	expected = strings.TrimSpace(expected)
+
+	if opts.CaseInsensitive {
+		actual = strings.ToLower(actual)
+		expected = strings.ToLower(expected)
+	}
+	if actual == expected {
+		return true
+	}
+
+	if opts.FloatTolerance > 0 {
+		return fieldsEqualWithTolerance(actual, expected, opts.FloatTolerance)
+	}
+	return false
+}
+
+// fieldsEqualWithTolerance сравнивает строки по полям: числовые поля —
+// с допуском, остальные — точно.
+func fieldsEqualWithTolerance(actual, expected string, tol float64) bool {
+	actualFields := strings.Fields(actual)
+	expectedFields := strings.Fields(expected)
+	if len(actualFields) != len(expectedFields) {
+		return false
+	}
+
+	for i := range actualFields {
+		a, errA := strconv.ParseFloat(actualFields[i], 64)
+		e, errE := strconv.ParseFloat(expectedFields[i], 64)
+		if errA == nil && errE == nil {
+			if math.Abs(a-e) > tol {
+				return false
+			}
+			continue
+		}
+		if actualFields[i] != expectedFields[i] {
+			return false
+		}
+	}
+	return true
+}
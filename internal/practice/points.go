@@ -0,0 +1,75 @@
+package practice
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+
+	"golearning/internal/content"
+)
+
+// PointsPolicy — политика начисления очков за решённые задания.
+// Политика хранится в настройках в JSON-виде: общая под ключом
+// "points.policy.default", per-course — "points.policy.<course_slug>".
+type PointsPolicy struct {
+	// FullAttempts — сколько первых попыток дают полные очки.
+	FullAttempts int `json:"full_attempts"`
+	// RetryFactor — множитель очков за каждую попытку сверх FullAttempts.
+	RetryFactor float64 `json:"retry_factor"`
+	// MinFactor — нижняя граница доли очков.
+	MinFactor float64 `json:"min_factor"`
+}
+
+// defaultPointsPolicy — политика по умолчанию: полные очки с первой попытки,
+// каждая следующая уменьшает награду на 20%, но не ниже 30%.
+var defaultPointsPolicy = PointsPolicy{
+	FullAttempts: 1,
+	RetryFactor:  0.8,
+	MinFactor:    0.3,
+}
+
+// Award вычисляет очки за решение с учётом количества попыток.
+func (p PointsPolicy) Award(basePoints, attempts int) int {
+	if basePoints <= 0 {
+		return 0
+	}
+
+	factor := 1.0
+	for i := p.FullAttempts; i < attempts; i++ {
+		factor *= p.RetryFactor
+	}
+	if factor < p.MinFactor {
+		factor = p.MinFactor
+	}
+
+	return int(math.Round(float64(basePoints) * factor))
+}
+
+// pointsPolicyFor возвращает политику начисления для задания:
+// сначала ищется политика курса, затем общая, иначе — политика по умолчанию.
+func (c *Checker) pointsPolicyFor(ctx context.Context, task *content.Task) PointsPolicy {
+	keys := []string{"points.policy.default"}
+
+	// Определяем курс через урок задания
+	if lesson, err := c.contentRepo.GetLessonByID(ctx, task.LessonID); err == nil && lesson != nil && lesson.Module != nil {
+		if course, err := c.contentRepo.GetCourseByID(ctx, lesson.Module.CourseID); err == nil && course != nil {
+			keys = append([]string{"points.policy." + course.Slug}, keys...)
+		}
+	}
+
+	for _, key := range keys {
+		raw, err := c.progressRepo.GetSetting(ctx, key)
+		if err != nil || raw == "" {
+			continue
+		}
+		policy := defaultPointsPolicy
+		if err := json.Unmarshal([]byte(raw), &policy); err != nil {
+			log.Printf("Некорректная политика очков %q: %v", key, err)
+			continue
+		}
+		return policy
+	}
+
+	return defaultPointsPolicy
+}
@@ -0,0 +1,182 @@
+package practice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"golearning/internal/runnerapi"
+)
+
+// RemoteRunner — клиент к выделенному сервису выполнения кода
+// (cmd/runnerd): код отправляется по gRPC и выполняется на другой
+// машине, изолированно от веб-сервера. Сервис стримит вывод программы
+// по мере выполнения (порции уходят в SetLogWriter) и завершает поток
+// финальным результатом. Авторизация — общий токен в метаданных
+// authorization: Bearer.
+type RemoteRunner struct {
+	target string
+	token  string
+
+	mu        sync.Mutex
+	tlsConfig *tls.Config // nil — соединение без TLS
+	conn      *grpc.ClientConn
+	logs      io.Writer
+}
+
+// NewRemoteRunner создаёт клиент к runnerd по адресу host:port.
+// Префиксы http:// и https:// принимаются для совместимости; https
+// включает TLS с системными CA (уточняется через SetTLS).
+func NewRemoteRunner(target, token string) *RemoteRunner {
+	r := &RemoteRunner{token: token, logs: io.Discard}
+	if strings.HasPrefix(target, "https://") {
+		r.tlsConfig = &tls.Config{}
+	}
+	r.target = strings.TrimRight(strings.TrimPrefix(strings.TrimPrefix(target, "https://"), "http://"), "/")
+	return r
+}
+
+// SetTLS настраивает mTLS: клиентский сертификат и CA, которым подписан
+// сертификат runnerd. Любой аргумент может быть пустым.
+func (r *RemoteRunner) SetTLS(certFile, keyFile, caFile string) error {
+	tlsConfig := &tls.Config{}
+
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("load client cert: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("в %s нет валидных сертификатов", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tlsConfig = tlsConfig
+	return nil
+}
+
+// SetLogWriter направляет стримимый вывод программы в w — например в
+// лог или терминал. По умолчанию поток отбрасывается: чекеру достаточно
+// финального результата.
+func (r *RemoteRunner) SetLogWriter(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = w
+}
+
+// client возвращает gRPC-клиент, лениво открывая соединение: к моменту
+// первого вызова SetTLS уже настроен.
+func (r *RemoteRunner) client() (runnerapi.RunnerClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		creds := insecure.NewCredentials()
+		if r.tlsConfig != nil {
+			creds = credentials.NewTLS(r.tlsConfig)
+		}
+		conn, err := grpc.NewClient(r.target, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("dial runnerd: %w", err)
+		}
+		r.conn = conn
+	}
+	return runnerapi.NewRunnerClient(r.conn), nil
+}
+
+// Run выполняет код на удалённом runnerd.
+func (r *RemoteRunner) Run(ctx context.Context, code string, limits RunLimits) (*RunResult, error) {
+	return r.call(ctx, runnerapi.RunnerClient.Run, runnerapi.RunRequest{
+		Code:       code,
+		TimeoutSec: int(limits.Timeout / time.Second),
+		MemoryMB:   limits.MemoryMB,
+	})
+}
+
+// Check запускает тесты на удалённом runnerd.
+func (r *RemoteRunner) Check(ctx context.Context, code string, testsGo string, limits RunLimits) (*RunResult, error) {
+	return r.call(ctx, runnerapi.RunnerClient.Check, runnerapi.RunRequest{
+		Code:       code,
+		TestsGo:    testsGo,
+		TimeoutSec: int(limits.Timeout / time.Second),
+		MemoryMB:   limits.MemoryMB,
+	})
+}
+
+// call открывает стрим, пишет события лога в logs и возвращает
+// финальный результат — последнее сообщение потока.
+func (r *RemoteRunner) call(
+	ctx context.Context,
+	method func(runnerapi.RunnerClient, context.Context, *runnerapi.RunRequest, ...grpc.CallOption) (grpc.ServerStreamingClient[runnerapi.Event], error),
+	req runnerapi.RunRequest,
+) (*RunResult, error) {
+	client, err := r.client()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	logs := r.logs
+	r.mu.Unlock()
+
+	// Запас поверх максимального таймаута выполнения на стороне runnerd
+	ctx, cancel := context.WithTimeout(ctx, MaxRunTimeout+10*time.Second)
+	defer cancel()
+	if r.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+r.token)
+	}
+
+	stream, err := method(client, ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("call runnerd: %w", err)
+	}
+
+	var result *RunResult
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("runnerd stream: %w", err)
+		}
+		if event.Log != "" {
+			_, _ = logs.Write([]byte(event.Log))
+		}
+		if event.Result != nil {
+			result = &RunResult{
+				Success: event.Result.Success,
+				Stdout:  event.Result.Stdout,
+				Stderr:  event.Result.Stderr,
+				Error:   event.Result.Error,
+			}
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf("runnerd закрыл поток без результата")
+	}
+	return result, nil
+}
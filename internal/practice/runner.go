@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,10 +14,35 @@ import (
 const (
 	// MaxCodeSize — максимальный размер кода (100KB).
 	MaxCodeSize = 100 * 1024
-	// RunTimeout — таймаут выполнения (15 секунд).
+	// RunTimeout — таймаут выполнения по умолчанию (15 секунд).
 	RunTimeout = 15 * time.Second
+	// MaxRunTimeout — верхняя граница таймаута: больше не выдаётся
+	// даже заданию с собственным бюджетом.
+	MaxRunTimeout = 120 * time.Second
 )
 
+// RunLimits — лимиты выполнения одного запуска. Нулевые значения
+// означают умолчания: RunTimeout и без ограничения памяти. Тяжёлые
+// задания (бенчмарки, конкурентность) задают свой бюджет в настройках.
+type RunLimits struct {
+	// Timeout — бюджет времени; ограничивается сверху MaxRunTimeout.
+	Timeout time.Duration
+	// MemoryMB — лимит памяти в мегабайтах.
+	MemoryMB int
+}
+
+// effectiveTimeout возвращает таймаут запуска с учётом умолчания и
+// верхней границы.
+func (l RunLimits) effectiveTimeout() time.Duration {
+	if l.Timeout <= 0 {
+		return RunTimeout
+	}
+	if l.Timeout > MaxRunTimeout {
+		return MaxRunTimeout
+	}
+	return l.Timeout
+}
+
 // RunResult — результат выполнения кода.
 type RunResult struct {
 	Success bool
@@ -27,8 +53,8 @@ type RunResult struct {
 
 // Runner — интерфейс для выполнения Go-кода.
 type Runner interface {
-	Run(ctx context.Context, code string) (*RunResult, error)
-	Check(ctx context.Context, code string, testsGo string) (*RunResult, error)
+	Run(ctx context.Context, code string, limits RunLimits) (*RunResult, error)
+	Check(ctx context.Context, code string, testsGo string, limits RunLimits) (*RunResult, error)
 }
 
 // LocalRunner — локальный runner (выполняет код через go run/test).
@@ -42,7 +68,14 @@ func NewLocalRunner() *LocalRunner {
 }
 
 // Run выполняет Go-код и возвращает результат.
-func (r *LocalRunner) Run(ctx context.Context, code string) (*RunResult, error) {
+func (r *LocalRunner) Run(ctx context.Context, code string, limits RunLimits) (*RunResult, error) {
+	return r.RunStream(ctx, code, limits, io.Discard)
+}
+
+// RunStream выполняет Go-код, дублируя stdout и stderr в logs по мере
+// выполнения — так runnerd стримит живой вывод клиенту, не дожидаясь
+// завершения программы.
+func (r *LocalRunner) RunStream(ctx context.Context, code string, limits RunLimits, logs io.Writer) (*RunResult, error) {
 	// Проверяем размер кода
 	if len(code) > MaxCodeSize {
 		return &RunResult{
@@ -71,16 +104,18 @@ func (r *LocalRunner) Run(ctx context.Context, code string) (*RunResult, error)
 	}
 
 	// Устанавливаем таймаут
-	ctx, cancel := context.WithTimeout(ctx, RunTimeout)
+	timeout := limits.effectiveTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Запускаем go run
 	cmd := exec.CommandContext(ctx, "go", "run", "main.go")
 	cmd.Dir = tempDir
+	applyMemoryLimit(cmd, limits)
 
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	cmd.Stdout = io.MultiWriter(&stdout, logs)
+	cmd.Stderr = io.MultiWriter(&stderr, logs)
 
 	err = cmd.Run()
 
@@ -91,7 +126,7 @@ func (r *LocalRunner) Run(ctx context.Context, code string) (*RunResult, error)
 
 	if ctx.Err() == context.DeadlineExceeded {
 		result.Success = false
-		result.Error = fmt.Sprintf("Превышено время выполнения (%v)", RunTimeout)
+		result.Error = fmt.Sprintf("Превышено время выполнения (%v)", timeout)
 		return result, nil
 	}
 
@@ -109,8 +144,24 @@ func (r *LocalRunner) Run(ctx context.Context, code string) (*RunResult, error)
 	return result, nil
 }
 
+// applyMemoryLimit выставляет GOMEMLIMIT для запускаемой программы.
+// Это мягкий лимит рантайма Go, а не жёсткая изоляция — для неё
+// runnerd запускается в контейнере с лимитами cgroups.
+func applyMemoryLimit(cmd *exec.Cmd, limits RunLimits) {
+	if limits.MemoryMB <= 0 {
+		return
+	}
+	cmd.Env = append(os.Environ(), fmt.Sprintf("GOMEMLIMIT=%dMiB", limits.MemoryMB))
+}
+
 // Check проверяет код с помощью тестов.
-func (r *LocalRunner) Check(ctx context.Context, code string, testsGo string) (*RunResult, error) {
+func (r *LocalRunner) Check(ctx context.Context, code string, testsGo string, limits RunLimits) (*RunResult, error) {
+	return r.CheckStream(ctx, code, testsGo, limits, io.Discard)
+}
+
+// CheckStream проверяет код тестами, дублируя вывод go test в logs по
+// мере выполнения.
+func (r *LocalRunner) CheckStream(ctx context.Context, code string, testsGo string, limits RunLimits, logs io.Writer) (*RunResult, error) {
 	// Проверяем размер кода
 	if len(code) > MaxCodeSize {
 		return &RunResult{
@@ -145,16 +196,18 @@ func (r *LocalRunner) Check(ctx context.Context, code string, testsGo string) (*
 	}
 
 	// Устанавливаем таймаут
-	ctx, cancel := context.WithTimeout(ctx, RunTimeout)
+	timeout := limits.effectiveTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Запускаем go test
 	cmd := exec.CommandContext(ctx, "go", "test", "-v", ".")
 	cmd.Dir = tempDir
+	applyMemoryLimit(cmd, limits)
 
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	cmd.Stdout = io.MultiWriter(&stdout, logs)
+	cmd.Stderr = io.MultiWriter(&stderr, logs)
 
 	err = cmd.Run()
 
@@ -165,7 +218,7 @@ func (r *LocalRunner) Check(ctx context.Context, code string, testsGo string) (*
 
 	if ctx.Err() == context.DeadlineExceeded {
 		result.Success = false
-		result.Error = fmt.Sprintf("Превышено время выполнения (%v)", RunTimeout)
+		result.Error = fmt.Sprintf("Превышено время выполнения (%v)", timeout)
 		return result, nil
 	}
 
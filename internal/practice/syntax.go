@@ -0,0 +1,75 @@
+package practice
+
+// Быстрая синтаксическая проверка решений go/parser-ом: опечатки
+// отсекаются за миллисекунды до записи отправки и запуска песочницы,
+// а учащийся получает точные позиции ошибок.
+
+import (
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"strconv"
+)
+
+// maxSyntaxErrors — сколько ошибок возвращаем за раз: после первых
+// десяти парсер обычно уже потерял синхронизацию с кодом.
+const maxSyntaxErrors = 10
+
+// SyntaxError — ошибка разбора или компиляции с позицией в коде.
+type SyntaxError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Col     int    `json:"col"`
+	Message string `json:"message"`
+}
+
+// CheckSyntax разбирает код и возвращает синтаксические ошибки с
+// точными позициями; nil — код синтаксически корректен.
+func CheckSyntax(code string) []SyntaxError {
+	fset := token.NewFileSet()
+	_, err := parser.ParseFile(fset, "main.go", code, parser.AllErrors)
+	if err == nil {
+		return nil
+	}
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return []SyntaxError{{File: "main.go", Line: 1, Col: 1, Message: err.Error()}}
+	}
+	errs := make([]SyntaxError, 0, len(list))
+	for _, e := range list {
+		if len(errs) == maxSyntaxErrors {
+			break
+		}
+		errs = append(errs, SyntaxError{
+			File:    e.Pos.Filename,
+			Line:    e.Pos.Line,
+			Col:     e.Pos.Column,
+			Message: e.Msg,
+		})
+	}
+	return errs
+}
+
+// compilerErrorRe — строка ошибки go build/test вида
+// «./main.go:5:2: undefined: x»; колонка может отсутствовать.
+var compilerErrorRe = regexp.MustCompile(`(?m)^\.?/?([\w./-]+\.go):(\d+)(?::(\d+))?: (.+)$`)
+
+// ParseCompilerErrors извлекает позиции ошибок компиляции из вывода
+// песочницы, чтобы редактор подчеркнул проблемные строки. Строки без
+// позиций (заголовки «# command-line-arguments» и т.п.) пропускаются.
+func ParseCompilerErrors(output string) []SyntaxError {
+	var errs []SyntaxError
+	for _, m := range compilerErrorRe.FindAllStringSubmatch(output, maxSyntaxErrors) {
+		line, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		col := 0
+		if m[3] != "" {
+			col, _ = strconv.Atoi(m[3])
+		}
+		errs = append(errs, SyntaxError{File: m[1], Line: line, Col: col, Message: m[4]})
+	}
+	return errs
+}
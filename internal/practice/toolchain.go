@@ -0,0 +1,23 @@
+package practice
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ToolchainVersion возвращает версию установленного Go toolchain
+// без префикса "go" (например "1.22.0"). Ошибка означает, что
+// toolchain в PATH недоступен — локальное выполнение кода не заработает.
+func ToolchainVersion(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOVERSION: %w", err)
+	}
+	v := strings.TrimPrefix(strings.TrimSpace(string(out)), "go")
+	if v == "" {
+		return "", fmt.Errorf("go env GOVERSION вернул пустую версию")
+	}
+	return v, nil
+}
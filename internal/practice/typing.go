@@ -0,0 +1,134 @@
+package practice
+
+// Клавиатурный тренажёр — режим «набери этот пример»: ученик перепечатывает
+// блок кода из урока, а сервер считает точность и скорость. Механическое
+// повторение закрепляет синтаксис лучше чтения; очки маленькие — это
+// тренировка, а не экзамен. Лучшие результаты хранятся в typing_results.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strings"
+)
+
+const (
+	// typingBasePoints — очки за безупречный набор одного примера.
+	typingBasePoints = 5
+	// typingMinAccuracy — порог точности, ниже которого очки не начисляются.
+	typingMinAccuracy = 0.9
+	// typingMaxWPM — скорость выше этой считается вставкой из буфера:
+	// такая попытка не получает ни очков, ни записи скорости.
+	typingMaxWPM = 250
+)
+
+// TypingScore — оценка одной попытки набора.
+type TypingScore struct {
+	// Accuracy — доля совпадения с целевым текстом, 0..1.
+	Accuracy float64
+	// WPM — скорость в «словах» в минуту (слово — 5 символов).
+	WPM float64
+	// Points — начисленные очки (0, если точность ниже порога).
+	Points int
+}
+
+// TypingKey возвращает стабильный ключ примера — хэш его текста.
+// Индекс code_blocks перестраивается при каждом редактировании урока
+// и id блоков меняются, поэтому результаты привязываются к содержимому:
+// если пример в уроке переписали, старый результат честно обнуляется.
+func TypingKey(code string) string {
+	sum := sha256.Sum256([]byte(normalizeTyping(code)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ScoreTyping сравнивает набранный текст с целевым и считает метрики.
+// elapsedMS — время набора по часам клиента; мы ему доверяем, но
+// неправдоподобно высокая скорость лишает попытку очков.
+func ScoreTyping(target, typed string, elapsedMS int64) TypingScore {
+	target = normalizeTyping(target)
+	typed = normalizeTyping(typed)
+
+	score := TypingScore{Accuracy: typingAccuracy(target, typed)}
+
+	if elapsedMS > 0 {
+		minutes := float64(elapsedMS) / 60000
+		score.WPM = float64(len([]rune(typed))) / 5 / minutes
+	}
+	if score.WPM > typingMaxWPM {
+		// Похоже на вставку: скорость не засчитываем, чтобы она не
+		// попала в таблицу результатов как «лучшая».
+		score.WPM = 0
+	}
+
+	if score.Accuracy >= typingMinAccuracy && score.WPM > 0 {
+		score.Points = int(math.Round(typingBasePoints * score.Accuracy))
+	}
+
+	return score
+}
+
+// normalizeTyping приводит текст к сравнимому виду: единые переводы
+// строк, без хвостовых пробелов в строках — такие расхождения не
+// говорят ничего о знании синтаксиса.
+func normalizeTyping(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// typingAccuracy — точность набора как 1 − d/len, где d — редакционное
+// расстояние Левенштейна по рунам.
+func typingAccuracy(target, typed string) float64 {
+	a, b := []rune(target), []rune(typed)
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	dist := levenshtein(a, b)
+	acc := 1 - float64(dist)/float64(longest)
+	if acc < 0 {
+		acc = 0
+	}
+	return acc
+}
+
+// levenshtein — классическое редакционное расстояние с одной строкой
+// таблицы DP; примеры короткие, квадратичной сложности достаточно.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
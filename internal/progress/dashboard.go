@@ -0,0 +1,87 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Данные для виджетов главной страницы: очередь повторения и лента
+// последних отправок. Обе выборки читают существующие таблицы и
+// подтягивают контекст задания для ссылок на уроки.
+
+// DueReview — задание из очереди повторения, срок которого наступил.
+type DueReview struct {
+	TaskID      int64
+	TaskTitle   string
+	LessonSlug  string
+	LessonTitle string
+	DueAt       time.Time
+}
+
+// ListDueReviews возвращает задания к повторению (срок наступил),
+// самые просроченные первыми.
+func (r *Repository) ListDueReviews(ctx context.Context, limit int) ([]DueReview, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT q.task_id, t.title, l.slug, l.title, q.due_at
+		 FROM review_queue q
+		 JOIN tasks t ON t.id = q.task_id
+		 JOIN lessons l ON l.id = t.lesson_id AND l.deleted_at IS NULL
+		 WHERE q.due_at <= CURRENT_TIMESTAMP
+		 ORDER BY q.due_at LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []DueReview
+	for rows.Next() {
+		var rev DueReview
+		if err := rows.Scan(&rev.TaskID, &rev.TaskTitle, &rev.LessonSlug, &rev.LessonTitle, &rev.DueAt); err != nil {
+			return nil, fmt.Errorf("scan due review: %w", err)
+		}
+		reviews = append(reviews, rev)
+	}
+	return reviews, rows.Err()
+}
+
+// RecentSubmission — отправка решения с контекстом задания.
+type RecentSubmission struct {
+	ID          int64
+	TaskID      int64
+	TaskTitle   string
+	LessonSlug  string
+	LessonTitle string
+	Status      string
+	CreatedAt   time.Time
+}
+
+// ListRecentSubmissions возвращает последние отправки решений,
+// новые первыми.
+func (r *Repository) ListRecentSubmissions(ctx context.Context, limit int) ([]RecentSubmission, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT s.id, s.task_id, t.title, l.slug, l.title, s.status, s.created_at
+		 FROM submissions s
+		 JOIN tasks t ON t.id = s.task_id
+		 JOIN lessons l ON l.id = t.lesson_id AND l.deleted_at IS NULL
+		 ORDER BY s.created_at DESC LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list recent submissions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []RecentSubmission
+	for rows.Next() {
+		var sub RecentSubmission
+		if err := rows.Scan(&sub.ID, &sub.TaskID, &sub.TaskTitle, &sub.LessonSlug, &sub.LessonTitle,
+			&sub.Status, &sub.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan recent submission: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
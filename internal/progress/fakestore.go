@@ -0,0 +1,867 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeStore — in-memory реализация Store для тестов без SQLite.
+// Повторяет поведение SQL-репозитория в главном: идемпотентный
+// CompleteTask, версии заметок, «не найдено» — (nil, nil). Поиск по
+// заметкам — вхождение подстроки вместо FTS; GetStats считает только
+// по накопленному прогрессу (общие числа уроков и очков курса фейку
+// неизвестны). Транзакционность WithTx не эмулируется.
+type FakeStore struct {
+	mu sync.Mutex
+
+	nextID      int64
+	progress    map[int64]*Progress
+	notes       map[int64]*Note
+	revisions   []NoteRevision
+	submissions []Submission
+	completions map[int64]completion // task_id -> начисление
+	settings    map[string]string
+	sessions    map[int64]*PracticeSession
+	milestones  map[string]map[int]bool            // project_id -> отмеченные пункты
+	grades      map[string]map[string]ProjectGrade // project_id -> оценки по критериям
+	kataResults map[string]KataResult              // kata_slug -> лучший результат
+	typing      map[string]TypingResult            // code_key -> лучший результат набора
+	guidedSteps map[int64]map[int]GuidedStep       // task_id -> состояние шагов
+	peerReviews []PeerReview
+	vocabulary  map[int64]*VocabularyTerm
+	hintAt      map[int64]time.Time // submission_id -> момент генерации подсказки
+}
+
+type completion struct {
+	lessonID int64
+	points   int
+}
+
+// NewFakeStore создаёт пустое in-memory хранилище прогресса.
+func NewFakeStore() *FakeStore {
+	return &FakeStore{
+		progress:    make(map[int64]*Progress),
+		notes:       make(map[int64]*Note),
+		completions: make(map[int64]completion),
+		settings:    make(map[string]string),
+		sessions:    make(map[int64]*PracticeSession),
+		milestones:  make(map[string]map[int]bool),
+		grades:      make(map[string]map[string]ProjectGrade),
+		kataResults: make(map[string]KataResult),
+		typing:      make(map[string]TypingResult),
+		guidedSteps: make(map[int64]map[int]GuidedStep),
+		vocabulary:  make(map[int64]*VocabularyTerm),
+	}
+}
+
+var _ Store = (*FakeStore)(nil)
+
+func (f *FakeStore) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+// WithTx у фейка просто вызывает fn: отката нет.
+func (f *FakeStore) WithTx(ctx context.Context, fn func(s Store) error) error {
+	return fn(f)
+}
+
+// GetStaleInProgress отдаёт зависшие в «читаю» записи. Названия и
+// slug уроков фейку неизвестны — заполняется только LessonID.
+func (f *FakeStore) GetStaleInProgress(ctx context.Context, olderThan time.Duration) ([]StaleLesson, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	var stale []StaleLesson
+	for _, p := range f.progress {
+		if p.Status == StatusReading && p.UpdatedAt.Before(cutoff) {
+			stale = append(stale, StaleLesson{LessonID: p.LessonID, UpdatedAt: p.UpdatedAt})
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].UpdatedAt.Before(stale[j].UpdatedAt) })
+	return stale, nil
+}
+
+// --- Прогресс ---
+
+func (f *FakeStore) GetProgress(ctx context.Context, lessonID int64) (*Progress, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, ok := f.progress[lessonID]; ok {
+		out := *p
+		return &out, nil
+	}
+	return &Progress{LessonID: lessonID, Status: StatusNew, UpdatedAt: time.Now()}, nil
+}
+
+func (f *FakeStore) UpdateProgress(ctx context.Context, p *Progress) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored := *p
+	stored.UpdatedAt = time.Now()
+	f.progress[p.LessonID] = &stored
+	return nil
+}
+
+func (f *FakeStore) SetStatus(ctx context.Context, lessonID int64, status Status) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.progress[lessonID]
+	if !ok {
+		p = &Progress{LessonID: lessonID}
+		f.progress[lessonID] = p
+	}
+	p.Status = status
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (f *FakeStore) GetAllProgress(ctx context.Context) (map[int64]*Progress, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[int64]*Progress, len(f.progress))
+	for id, p := range f.progress {
+		c := *p
+		out[id] = &c
+	}
+	return out, nil
+}
+
+func (f *FakeStore) ResetAllProgress(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.progress = make(map[int64]*Progress)
+	f.completions = make(map[int64]completion)
+	f.submissions = nil
+	return nil
+}
+
+func (f *FakeStore) GetStats(ctx context.Context) (*Stats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stats := &Stats{}
+	for _, p := range f.progress {
+		switch p.Status {
+		case StatusDone:
+			stats.CompletedCount++
+		case StatusReading:
+			stats.InProgressCount++
+		}
+		stats.EarnedPoints += p.PointsEarned
+	}
+	for _, projectGrades := range f.grades {
+		for _, g := range projectGrades {
+			stats.ProjectPoints += g.Points
+		}
+	}
+	for _, rev := range f.peerReviews {
+		stats.ReviewPoints += rev.Points
+	}
+	return stats, nil
+}
+
+// GetCourseStats в фейке не различает курсы и возвращает общую статистику.
+func (f *FakeStore) GetCourseStats(ctx context.Context, courseID int64) (*Stats, error) {
+	return f.GetStats(ctx)
+}
+
+// --- Засчитанные задания ---
+
+func (f *FakeStore) CompleteTask(ctx context.Context, taskID, lessonID int64, points int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.completions[taskID]; ok {
+		return false, nil
+	}
+	f.completions[taskID] = completion{lessonID: lessonID, points: points}
+
+	// Пересчитываем очки урока из completions, как SQL-репозиторий
+	total := 0
+	for _, c := range f.completions {
+		if c.lessonID == lessonID {
+			total += c.points
+		}
+	}
+	p, ok := f.progress[lessonID]
+	if !ok {
+		p = &Progress{LessonID: lessonID, Status: StatusNew}
+		f.progress[lessonID] = p
+	}
+	p.PracticeDone = true
+	p.PointsEarned = total
+	p.UpdatedAt = time.Now()
+	return true, nil
+}
+
+func (f *FakeStore) IsTaskCompleted(ctx context.Context, taskID int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.completions[taskID]
+	return ok, nil
+}
+
+func (f *FakeStore) GetTaskCompletionPoints(ctx context.Context, lessonID int64) (map[int64]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[int64]int)
+	for taskID, c := range f.completions {
+		if c.lessonID == lessonID {
+			out[taskID] = c.points
+		}
+	}
+	return out, nil
+}
+
+// --- Заметки ---
+
+func (f *FakeStore) GetNote(ctx context.Context, lessonID int64) (*Note, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n, ok := f.notes[lessonID]; ok {
+		out := *n
+		return &out, nil
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) SaveNote(ctx context.Context, lessonID int64, noteMD string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notes[lessonID] = &Note{LessonID: lessonID, NoteMD: noteMD, UpdatedAt: time.Now()}
+	f.revisions = append(f.revisions, NoteRevision{
+		ID:        f.id(),
+		LessonID:  lessonID,
+		NoteMD:    noteMD,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+func (f *FakeStore) SearchNotes(ctx context.Context, query string, limit int) ([]NoteSearchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit <= 0 {
+		limit = 20
+	}
+	needle := strings.ToLower(query)
+	var out []NoteSearchResult
+	for _, n := range f.notes {
+		if strings.Contains(strings.ToLower(n.NoteMD), needle) {
+			out = append(out, NoteSearchResult{LessonID: n.LessonID, Snippet: n.NoteMD})
+			if len(out) >= limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) ListNoteRevisions(ctx context.Context, lessonID int64, limit int) ([]NoteRevision, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit <= 0 {
+		limit = 20
+	}
+	var out []NoteRevision
+	for i := len(f.revisions) - 1; i >= 0 && len(out) < limit; i-- {
+		if f.revisions[i].LessonID == lessonID {
+			out = append(out, f.revisions[i])
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) GetNoteRevision(ctx context.Context, id int64) (*NoteRevision, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.revisions {
+		if f.revisions[i].ID == id {
+			r := f.revisions[i]
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+// --- Отправки решений ---
+
+func (f *FakeStore) CreateSubmission(ctx context.Context, s *Submission) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s.ID = f.id()
+	s.CreatedAt = time.Now()
+	f.submissions = append(f.submissions, *s)
+	return nil
+}
+
+func (f *FakeStore) ClaimSubmission(ctx context.Context, id int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.submissions {
+		if f.submissions[i].ID == id && f.submissions[i].Status == "pending" {
+			f.submissions[i].Status = "running"
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakeStore) UpdateSubmission(ctx context.Context, s *Submission) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.submissions {
+		if f.submissions[i].ID == s.ID {
+			f.submissions[i] = *s
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeStore) ReconcileInterrupted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var marked int64
+	cutoff := time.Now().Add(-olderThan)
+	for i := range f.submissions {
+		status := f.submissions[i].Status
+		if (status == "pending" || status == "running") && f.submissions[i].CreatedAt.Before(cutoff) {
+			f.submissions[i].Status = "interrupted"
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (f *FakeStore) GetSubmissionByID(ctx context.Context, id int64) (*Submission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.submissions {
+		if f.submissions[i].ID == id {
+			s := f.submissions[i]
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) SetSubmissionHint(ctx context.Context, id int64, hint string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.submissions {
+		if f.submissions[i].ID == id {
+			f.submissions[i].Hint = hint
+			if f.hintAt == nil {
+				f.hintAt = make(map[int64]time.Time)
+			}
+			f.hintAt[id] = time.Now()
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeStore) CountHintsSince(ctx context.Context, since time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, at := range f.hintAt {
+		if !at.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeStore) GetSubmissionsByTaskID(ctx context.Context, taskID int64, limit int) ([]Submission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit <= 0 {
+		limit = 10
+	}
+	var out []Submission
+	for i := len(f.submissions) - 1; i >= 0 && len(out) < limit; i-- {
+		if f.submissions[i].TaskID == taskID {
+			out = append(out, f.submissions[i])
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) IsTaskSolvedSuccessfully(ctx context.Context, taskID int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range f.submissions {
+		if s.TaskID == taskID && s.Status == "success" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakeStore) CountSubmissionsByTask(ctx context.Context, taskID int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, s := range f.submissions {
+		if s.TaskID == taskID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *FakeStore) GetTaskStats(ctx context.Context, taskID int64) (*TaskStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stats := &TaskStats{TaskID: taskID}
+	var runtimeSum float64
+	var runtimeCount int
+	for _, s := range f.submissions {
+		if s.TaskID != taskID {
+			continue
+		}
+		stats.Attempts++
+		if s.Status == "success" {
+			stats.Passed++
+			if stats.AttemptsToPass == 0 {
+				stats.AttemptsToPass = stats.Attempts
+			}
+		}
+		if s.DurationMS > 0 {
+			runtimeSum += float64(s.DurationMS)
+			runtimeCount++
+		}
+	}
+	if stats.Attempts > 0 {
+		stats.PassRate = float64(stats.Passed) / float64(stats.Attempts)
+	}
+	if runtimeCount > 0 {
+		stats.AvgRuntimeMS = runtimeSum / float64(runtimeCount)
+	}
+	return stats, nil
+}
+
+// --- Настройки ---
+
+func (f *FakeStore) GetSetting(ctx context.Context, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.settings[key], nil
+}
+
+func (f *FakeStore) SetSetting(ctx context.Context, key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.settings[key] = value
+	return nil
+}
+
+// --- Практические сессии и журнал ошибок ---
+
+func (f *FakeStore) ListPracticeCandidates(ctx context.Context) ([]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// Фейк не знает структуру курса — кандидаты это просто задания,
+	// по которым ещё не было успешной отправки.
+	solved := make(map[int64]bool)
+	seen := make(map[int64]bool)
+	var order []int64
+	for _, s := range f.submissions {
+		if !seen[s.TaskID] {
+			seen[s.TaskID] = true
+			order = append(order, s.TaskID)
+		}
+		if s.Status == "success" {
+			solved[s.TaskID] = true
+		}
+	}
+	var out []int64
+	for _, id := range order {
+		if !solved[id] {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeStore) CreatePracticeSession(ctx context.Context, taskIDs []int64, durationMin int) (*PracticeSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session := &PracticeSession{
+		ID:          f.id(),
+		TaskIDs:     append([]int64(nil), taskIDs...),
+		DurationMin: durationMin,
+		StartedAt:   time.Now(),
+		TotalCount:  len(taskIDs),
+	}
+	f.sessions[session.ID] = session
+	out := *session
+	return &out, nil
+}
+
+func (f *FakeStore) GetPracticeSession(ctx context.Context, id int64) (*PracticeSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.sessions[id]; ok {
+		out := *s
+		return &out, nil
+	}
+	return nil, nil
+}
+
+func (f *FakeStore) FinishPracticeSession(ctx context.Context, id int64, solvedTaskIDs []int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.sessions[id]
+	if !ok {
+		return nil
+	}
+	s.FinishedAt = time.Now()
+	s.SolvedCount = len(solvedTaskIDs)
+	return nil
+}
+
+func (f *FakeStore) MistakeJournal(ctx context.Context) ([]MistakeStat, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// Фейк не знает модули, поэтому журнал пуст. Достаточно для
+	// хендлеров, которым важно отсутствие ошибки.
+	return nil, nil
+}
+
+// GetProjectMilestones возвращает отмеченные пункты чек-листа проекта.
+func (f *FakeStore) GetProjectMilestones(ctx context.Context, projectID string) (map[int]bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	done := make(map[int]bool, len(f.milestones[projectID]))
+	for index := range f.milestones[projectID] {
+		done[index] = true
+	}
+	return done, nil
+}
+
+// SetProjectMilestone отмечает или снимает пункт чек-листа проекта.
+func (f *FakeStore) SetProjectMilestone(ctx context.Context, projectID string, index int, done bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if done {
+		if f.milestones[projectID] == nil {
+			f.milestones[projectID] = make(map[int]bool)
+		}
+		f.milestones[projectID][index] = true
+	} else {
+		delete(f.milestones[projectID], index)
+	}
+	return nil
+}
+
+// GetProjectGrades возвращает выставленные оценки по критериям проекта.
+func (f *FakeStore) GetProjectGrades(ctx context.Context, projectID string) (map[string]ProjectGrade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	grades := make(map[string]ProjectGrade, len(f.grades[projectID]))
+	for criterion, g := range f.grades[projectID] {
+		grades[criterion] = g
+	}
+	return grades, nil
+}
+
+// SetProjectGrade выставляет баллы и комментарий по критерию рубрики.
+func (f *FakeStore) SetProjectGrade(ctx context.Context, projectID, criterion string, points int, comment string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.grades[projectID] == nil {
+		f.grades[projectID] = make(map[string]ProjectGrade)
+	}
+	f.grades[projectID][criterion] = ProjectGrade{
+		Criterion: criterion,
+		Points:    points,
+		Comment:   comment,
+		GradedAt:  time.Now(),
+	}
+	return nil
+}
+
+// GetKataResults возвращает результаты всех ката по slug'ам.
+func (f *FakeStore) GetKataResults(ctx context.Context) (map[string]KataResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make(map[string]KataResult, len(f.kataResults))
+	for slug, k := range f.kataResults {
+		results[slug] = k
+	}
+	return results, nil
+}
+
+// RecordKataAttempt записывает попытку решения ката. Успех «залипает».
+func (f *FakeStore) RecordKataAttempt(ctx context.Context, slug string, passed bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k := f.kataResults[slug]
+	k.Slug = slug
+	k.Attempts++
+	k.Passed = k.Passed || passed
+	f.kataResults[slug] = k
+	return nil
+}
+
+// GetTypingResults возвращает результаты тренажёра по ключам примеров.
+func (f *FakeStore) GetTypingResults(ctx context.Context) (map[string]TypingResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make(map[string]TypingResult, len(f.typing))
+	for key, t := range f.typing {
+		results[key] = t
+	}
+	return results, nil
+}
+
+// RecordTypingAttempt записывает попытку набора примера, сохраняя
+// лучшее значение по каждой метрике.
+func (f *FakeStore) RecordTypingAttempt(ctx context.Context, codeKey string, lessonID int64, accuracy, wpm float64, points int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := f.typing[codeKey]
+	t.CodeKey = codeKey
+	t.LessonID = lessonID
+	t.Attempts++
+	t.Accuracy = math.Max(t.Accuracy, accuracy)
+	t.WPM = math.Max(t.WPM, wpm)
+	if points > t.Points {
+		t.Points = points
+	}
+	f.typing[codeKey] = t
+	return nil
+}
+
+// GetGuidedSteps возвращает состояние шагов задания по их индексам.
+func (f *FakeStore) GetGuidedSteps(ctx context.Context, taskID int64) (map[int]GuidedStep, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	steps := make(map[int]GuidedStep, len(f.guidedSteps[taskID]))
+	for idx, st := range f.guidedSteps[taskID] {
+		steps[idx] = st
+	}
+	return steps, nil
+}
+
+// SaveGuidedStep сохраняет код и результат шага. Успех «залипает».
+func (f *FakeStore) SaveGuidedStep(ctx context.Context, taskID int64, stepIndex int, code string, passed bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.guidedSteps[taskID] == nil {
+		f.guidedSteps[taskID] = make(map[int]GuidedStep)
+	}
+	st := f.guidedSteps[taskID][stepIndex]
+	st.StepIndex = stepIndex
+	st.Code = code
+	st.Passed = st.Passed || passed
+	f.guidedSteps[taskID][stepIndex] = st
+	return nil
+}
+
+// GetReviewCandidates возвращает успешные отправки задания без рецензии.
+// В отличие от SQL-репозитория порядок не случайный, а по времени.
+func (f *FakeStore) GetReviewCandidates(ctx context.Context, taskID, exceptID int64, limit int) ([]Submission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reviewed := make(map[int64]bool, len(f.peerReviews))
+	for _, rev := range f.peerReviews {
+		reviewed[rev.SubmissionID] = true
+	}
+	seen := make(map[string]bool)
+	var out []Submission
+	for _, s := range f.submissions {
+		if len(out) >= limit {
+			break
+		}
+		if s.TaskID != taskID || s.Status != "success" || s.ID == exceptID {
+			continue
+		}
+		if reviewed[s.ID] || seen[s.Code] {
+			continue
+		}
+		seen[s.Code] = true
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// CreatePeerReview сохраняет рецензию на отправку. Как и SQL-репозиторий,
+// повторную рецензию на ту же отправку не принимает.
+func (f *FakeStore) CreatePeerReview(ctx context.Context, rev *PeerReview) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, existing := range f.peerReviews {
+		if existing.SubmissionID == rev.SubmissionID {
+			return fmt.Errorf("submission %d уже отрецензирована", rev.SubmissionID)
+		}
+	}
+	rev.ID = f.id()
+	rev.CreatedAt = time.Now()
+	f.peerReviews = append(f.peerReviews, *rev)
+	return nil
+}
+
+// ListDueReviews возвращает задания к повторению. Фейк не моделирует
+// очередь повторения, поэтому список всегда пуст.
+func (f *FakeStore) ListDueReviews(ctx context.Context, limit int) ([]DueReview, error) {
+	return nil, nil
+}
+
+// ListRecentSubmissions возвращает последние отправки, новые первыми.
+// Контекст задания (заголовки, slug урока) фейку неизвестен.
+func (f *FakeStore) ListRecentSubmissions(ctx context.Context, limit int) ([]RecentSubmission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []RecentSubmission
+	for i := len(f.submissions) - 1; i >= 0 && len(out) < limit; i-- {
+		s := f.submissions[i]
+		out = append(out, RecentSubmission{
+			ID:        s.ID,
+			TaskID:    s.TaskID,
+			Status:    s.Status,
+			CreatedAt: s.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+// AddVocabularyTerm добавляет термин в словарь; повтор по названию
+// обновляет существующую запись, не сбрасывая расписание.
+func (f *FakeStore) AddVocabularyTerm(ctx context.Context, term, definition string, lessonID int64) (*VocabularyTerm, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("пустой термин")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, t := range f.vocabulary {
+		if t.Term == term {
+			if definition != "" {
+				t.Definition = strings.TrimSpace(definition)
+			}
+			if lessonID > 0 {
+				t.LessonID = lessonID
+			}
+			copied := *t
+			return &copied, nil
+		}
+	}
+
+	t := &VocabularyTerm{
+		ID:         f.id(),
+		Term:       term,
+		Definition: strings.TrimSpace(definition),
+		LessonID:   lessonID,
+		DueAt:      time.Now(),
+		CreatedAt:  time.Now(),
+	}
+	f.vocabulary[t.ID] = t
+	copied := *t
+	return &copied, nil
+}
+
+// ListVocabulary возвращает весь словарь: сначала термины к повторению.
+func (f *FakeStore) ListVocabulary(ctx context.Context) ([]VocabularyTerm, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []VocabularyTerm
+	for _, t := range f.vocabulary {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].DueAt.Equal(out[j].DueAt) {
+			return out[i].DueAt.Before(out[j].DueAt)
+		}
+		return out[i].Term < out[j].Term
+	})
+	return out, nil
+}
+
+// DueVocabulary возвращает термины, срок повторения которых наступил.
+func (f *FakeStore) DueVocabulary(ctx context.Context, limit int) ([]VocabularyTerm, error) {
+	all, err := f.ListVocabulary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []VocabularyTerm
+	for _, t := range all {
+		if len(out) >= limit {
+			break
+		}
+		if !t.DueAt.After(time.Now()) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// ReviewVocabularyTerm фиксирует итог повторения термина.
+func (f *FakeStore) ReviewVocabularyTerm(ctx context.Context, id int64, remembered bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t, ok := f.vocabulary[id]
+	if !ok {
+		return fmt.Errorf("термин %d не найден", id)
+	}
+	if remembered {
+		t.IntervalDays *= 2
+		if t.IntervalDays < 3 {
+			t.IntervalDays = 3
+		}
+	} else {
+		t.IntervalDays = 1
+	}
+	t.DueAt = time.Now().AddDate(0, 0, t.IntervalDays)
+	t.Reviews++
+	return nil
+}
+
+// DeleteVocabularyTerm удаляет термин из словаря.
+func (f *FakeStore) DeleteVocabularyTerm(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.vocabulary, id)
+	return nil
+}
+
+// GetPeerReviewsByTaskID возвращает рецензии на решения задания,
+// новые первыми.
+func (f *FakeStore) GetPeerReviewsByTaskID(ctx context.Context, taskID int64) ([]PeerReview, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []PeerReview
+	for i := len(f.peerReviews) - 1; i >= 0; i-- {
+		if f.peerReviews[i].TaskID == taskID {
+			out = append(out, f.peerReviews[i])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package progress
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// MistakeStat — сводка ошибок по модулю для журнала ошибок.
+type MistakeStat struct {
+	ModuleID    int64
+	ModuleSlug  string
+	ModuleTitle string
+	// Категории неудачных отправок
+	CompileErrors int
+	WrongOutput   int
+	Timeouts      int
+	// Slug урока модуля с секцией "подводные камни" (пусто, если нет)
+	PitfallsLessonSlug string
+}
+
+// Total возвращает общее количество неудачных отправок по модулю.
+func (m MistakeStat) Total() int {
+	return m.CompileErrors + m.WrongOutput + m.Timeouts
+}
+
+// MistakeJournal агрегирует неудачные отправки по модулям и категориям.
+// Категория определяется эвристически по содержимому stderr:
+// ошибки компиляции содержат позицию в файле, таймауты — сообщение раннера.
+func (r *Repository) MistakeJournal(ctx context.Context) ([]MistakeStat, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT m.id, m.slug, m.title,
+		        SUM(CASE WHEN s.stderr LIKE '%.go:%' THEN 1 ELSE 0 END) AS compile_errors,
+		        SUM(CASE WHEN s.status = 'timeout' OR s.stderr LIKE '%Превышено время%' THEN 1 ELSE 0 END) AS timeouts,
+		        SUM(CASE WHEN s.stderr NOT LIKE '%.go:%'
+		                  AND s.status != 'timeout'
+		                  AND s.stderr NOT LIKE '%Превышено время%' THEN 1 ELSE 0 END) AS wrong_output,
+		        COALESCE((
+		            SELECT l2.slug FROM lessons l2
+		            JOIN lesson_sections ls ON ls.lesson_id = l2.id AND ls.kind = 'pitfalls'
+		            WHERE l2.module_id = m.id
+		            ORDER BY l2.order_index LIMIT 1
+		        ), '') AS pitfalls_slug
+		 FROM submissions s
+		 JOIN tasks t ON t.id = s.task_id
+		 JOIN lessons l ON l.id = t.lesson_id
+		 JOIN modules m ON m.id = l.module_id
+		 WHERE s.status IN ('error', 'timeout')
+		 GROUP BY m.id, m.slug, m.title
+		 ORDER BY compile_errors + timeouts + wrong_output DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("mistake journal: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []MistakeStat
+	for rows.Next() {
+		var s MistakeStat
+		var compileErrors, timeouts, wrongOutput sql.NullInt64
+		if err := rows.Scan(&s.ModuleID, &s.ModuleSlug, &s.ModuleTitle,
+			&compileErrors, &timeouts, &wrongOutput, &s.PitfallsLessonSlug); err != nil {
+			return nil, fmt.Errorf("scan mistake stat: %w", err)
+		}
+		s.CompileErrors = int(compileErrors.Int64)
+		s.Timeouts = int(timeouts.Int64)
+		s.WrongOutput = int(wrongOutput.Int64)
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
@@ -1,6 +1,7 @@
 package progress
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -24,6 +25,23 @@ type Progress struct {
 	UpdatedAt    time.Time
 }
 
+// StaleReadingAfter — срок, после которого урок в статусе «читаю»
+// считается отложенным (виджет «вернуться к чтению» и дайджест).
+const StaleReadingAfter = 7 * 24 * time.Hour
+
+// StaleLesson — урок, зависший в статусе «читаю».
+type StaleLesson struct {
+	LessonID  int64
+	Title     string
+	Slug      string
+	UpdatedAt time.Time
+}
+
+// DaysStale — сколько полных дней урок без движения.
+func (s StaleLesson) DaysStale() int {
+	return int(time.Since(s.UpdatedAt).Hours() / 24)
+}
+
 // Note — заметка к уроку.
 type Note struct {
 	LessonID  int64
@@ -33,13 +51,16 @@ type Note struct {
 
 // Submission — отправка решения.
 type Submission struct {
-	ID        int64
-	TaskID    int64
-	Code      string
-	Status    string // pending, success, error, timeout
-	Stdout    string
-	Stderr    string
-	CreatedAt time.Time
+	ID             int64
+	TaskID         int64
+	Code           string
+	Status         string // pending, running, success, error, timeout, interrupted
+	Stdout         string
+	Stderr         string
+	DurationMS     int64  // время выполнения кода, мс
+	StarterVersion int    // версия начального кода задания на момент отправки
+	Hint           string // LLM-подсказка по проваленной проверке; пустая, если не запрашивалась
+	CreatedAt      time.Time
 }
 
 // Stats — общая статистика.
@@ -49,24 +70,69 @@ type Stats struct {
 	InProgressCount int
 	TotalPoints     int
 	EarnedPoints    int
+	ProjectPoints   int // баллы за capstone-проекты (ручная оценка по рубрике)
+	ReviewPoints    int // бонусы за завершённые код-ревью
+	TypingPoints    int // очки клавиатурного тренажёра
+}
+
+// dbtx — общая часть *sql.DB и *sql.Tx. Благодаря ей методы репозитория
+// работают и напрямую с базой, и внутри транзакции (см. WithTx).
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
 // Repository — репозиторий для работы с прогрессом.
 type Repository struct {
-	db *sql.DB
+	db   dbtx
+	base *sql.DB // для открытия транзакций; nil внутри WithTx
 }
 
 // NewRepository создаёт новый репозиторий.
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{db: db, base: db}
+}
+
+// WithTx выполняет fn в одной транзакции: все вызовы методов переданного
+// репозитория попадают в неё, ошибка из fn откатывает транзакцию целиком.
+func (r *Repository) WithTx(ctx context.Context, fn func(s Store) error) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	txRepo := &Repository{db: tx}
+	if err := fn(txRepo); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// begin открывает новую транзакцию. Внутри WithTx это запрещено:
+// вложенных транзакций в SQLite нет.
+func (r *Repository) begin(ctx context.Context) (*sql.Tx, error) {
+	if r.base == nil {
+		return nil, fmt.Errorf("вложенная транзакция внутри WithTx")
+	}
+	tx, err := r.base.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	return tx, nil
 }
 
 // --- Progress ---
 
 // GetProgress возвращает прогресс по уроку.
-func (r *Repository) GetProgress(lessonID int64) (*Progress, error) {
+func (r *Repository) GetProgress(ctx context.Context, lessonID int64) (*Progress, error) {
 	p := &Progress{}
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(ctx,
 		`SELECT lesson_id, status, practice_done, points_earned, updated_at 
 		 FROM progress WHERE lesson_id = ?`,
 		lessonID,
@@ -90,8 +156,8 @@ func (r *Repository) GetProgress(lessonID int64) (*Progress, error) {
 }
 
 // UpdateProgress обновляет прогресс по уроку.
-func (r *Repository) UpdateProgress(p *Progress) error {
-	_, err := r.db.Exec(
+func (r *Repository) UpdateProgress(ctx context.Context, p *Progress) error {
+	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO progress (lesson_id, status, practice_done, points_earned, updated_at)
 		 VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
 		 ON CONFLICT(lesson_id) DO UPDATE SET 
@@ -108,8 +174,8 @@ func (r *Repository) UpdateProgress(p *Progress) error {
 }
 
 // SetStatus устанавливает статус урока.
-func (r *Repository) SetStatus(lessonID int64, status Status) error {
-	_, err := r.db.Exec(
+func (r *Repository) SetStatus(ctx context.Context, lessonID int64, status Status) error {
+	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO progress (lesson_id, status, updated_at)
 		 VALUES (?, ?, CURRENT_TIMESTAMP)
 		 ON CONFLICT(lesson_id) DO UPDATE SET 
@@ -120,23 +186,92 @@ func (r *Repository) SetStatus(lessonID int64, status Status) error {
 	return err
 }
 
-// SetPracticeDone отмечает практику как выполненную.
-func (r *Repository) SetPracticeDone(lessonID int64, points int) error {
-	_, err := r.db.Exec(
+// CompleteTask фиксирует успешное решение задания и начисляет очки.
+// Возвращает true, если решение засчитано впервые: повторные вызовы
+// идемпотентны, очки урока всегда пересчитываются из task_completions.
+func (r *Repository) CompleteTask(ctx context.Context, taskID, lessonID int64, points int) (bool, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("complete task: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO task_completions (task_id, lesson_id, points_awarded)
+		 VALUES (?, ?, ?)`,
+		taskID, lessonID, points,
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert completion: %w", err)
+	}
+	inserted, _ := res.RowsAffected()
+	if inserted == 0 {
+		// Задание уже было засчитано ранее
+		return false, nil
+	}
+
+	// Пересчитываем очки урока из completions
+	_, err = tx.ExecContext(ctx,
 		`INSERT INTO progress (lesson_id, practice_done, points_earned, updated_at)
-		 VALUES (?, 1, ?, CURRENT_TIMESTAMP)
-		 ON CONFLICT(lesson_id) DO UPDATE SET 
+		 VALUES (?, 1,
+		   (SELECT COALESCE(SUM(points_awarded), 0) FROM task_completions WHERE lesson_id = ?),
+		   CURRENT_TIMESTAMP)
+		 ON CONFLICT(lesson_id) DO UPDATE SET
 		   practice_done = 1,
-		   points_earned = points_earned + excluded.points_earned,
+		   points_earned = (SELECT COALESCE(SUM(points_awarded), 0) FROM task_completions WHERE lesson_id = progress.lesson_id),
 		   updated_at = CURRENT_TIMESTAMP`,
-		lessonID, points,
+		lessonID, lessonID,
 	)
-	return err
+	if err != nil {
+		return false, fmt.Errorf("recalc lesson points: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("complete task: %w", err)
+	}
+	return true, nil
+}
+
+// IsTaskCompleted проверяет, засчитано ли уже задание.
+func (r *Repository) IsTaskCompleted(ctx context.Context, taskID int64) (bool, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM task_completions WHERE task_id = ?`,
+		taskID,
+	).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("check task completed: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetTaskCompletionPoints возвращает начисленные очки по каждому
+// засчитанному заданию урока (task_id -> points_awarded).
+func (r *Repository) GetTaskCompletionPoints(ctx context.Context, lessonID int64) (map[int64]int, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT task_id, points_awarded FROM task_completions WHERE lesson_id = ?`,
+		lessonID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get task completion points: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int64]int)
+	for rows.Next() {
+		var taskID int64
+		var points int
+		if err := rows.Scan(&taskID, &points); err != nil {
+			return nil, fmt.Errorf("scan task completion: %w", err)
+		}
+		result[taskID] = points
+	}
+	return result, nil
 }
 
 // GetAllProgress возвращает прогресс по всем урокам.
-func (r *Repository) GetAllProgress() (map[int64]*Progress, error) {
-	rows, err := r.db.Query(
+func (r *Repository) GetAllProgress(ctx context.Context) (map[int64]*Progress, error) {
+	rows, err := r.db.QueryContext(ctx,
 		`SELECT lesson_id, status, practice_done, points_earned, updated_at FROM progress`,
 	)
 	if err != nil {
@@ -159,9 +294,9 @@ func (r *Repository) GetAllProgress() (map[int64]*Progress, error) {
 // --- Notes ---
 
 // GetNote возвращает заметку к уроку.
-func (r *Repository) GetNote(lessonID int64) (*Note, error) {
+func (r *Repository) GetNote(ctx context.Context, lessonID int64) (*Note, error) {
 	n := &Note{}
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(ctx,
 		`SELECT lesson_id, note_md, updated_at FROM notes WHERE lesson_id = ?`,
 		lessonID,
 	).Scan(&n.LessonID, &n.NoteMD, &n.UpdatedAt)
@@ -176,27 +311,141 @@ func (r *Repository) GetNote(lessonID int64) (*Note, error) {
 	return n, nil
 }
 
-// SaveNote сохраняет заметку к уроку.
-func (r *Repository) SaveNote(lessonID int64, noteMD string) error {
-	_, err := r.db.Exec(
+// NoteSearchResult — результат поиска по заметкам.
+type NoteSearchResult struct {
+	LessonID int64
+	Slug     string
+	Title    string
+	Snippet  string
+	Rank     float64
+}
+
+// SearchNotes выполняет полнотекстовый поиск по заметкам пользователя.
+func (r *Repository) SearchNotes(ctx context.Context, query string, limit int) ([]NoteSearchResult, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT l.id, l.slug, l.title, snippet(notes_fts, 0, '<mark>', '</mark>', '...', 32) as snippet,
+		        bm25(notes_fts) as rank
+		 FROM notes_fts
+		 JOIN lessons l ON l.id = notes_fts.rowid
+		 WHERE notes_fts MATCH ?
+		 ORDER BY rank
+		 LIMIT ?`,
+		query, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search notes: %w", err)
+	}
+	defer rows.Close()
+
+	var results []NoteSearchResult
+	for rows.Next() {
+		var res NoteSearchResult
+		if err := rows.Scan(&res.LessonID, &res.Slug, &res.Title, &res.Snippet, &res.Rank); err != nil {
+			return nil, fmt.Errorf("scan note search result: %w", err)
+		}
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+// SaveNote сохраняет заметку к уроку и пишет версию в историю.
+func (r *Repository) SaveNote(ctx context.Context, lessonID int64, noteMD string) error {
+	// Не плодим ревизии, если содержимое не изменилось
+	current, err := r.GetNote(ctx, lessonID)
+	if err != nil {
+		return err
+	}
+	if current.NoteMD == noteMD {
+		return nil
+	}
+
+	_, err = r.db.ExecContext(ctx,
 		`INSERT INTO notes (lesson_id, note_md, updated_at)
 		 VALUES (?, ?, CURRENT_TIMESTAMP)
-		 ON CONFLICT(lesson_id) DO UPDATE SET 
+		 ON CONFLICT(lesson_id) DO UPDATE SET
 		   note_md = excluded.note_md,
 		   updated_at = CURRENT_TIMESTAMP`,
 		lessonID, noteMD,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO note_revisions (lesson_id, note_md) VALUES (?, ?)`,
+		lessonID, noteMD,
+	)
+	if err != nil {
+		return fmt.Errorf("save note revision: %w", err)
+	}
+	return nil
+}
+
+// NoteRevision — сохранённая версия заметки.
+type NoteRevision struct {
+	ID        int64
+	LessonID  int64
+	NoteMD    string
+	CreatedAt time.Time
+}
+
+// ListNoteRevisions возвращает версии заметки урока (новые первыми).
+func (r *Repository) ListNoteRevisions(ctx context.Context, lessonID int64, limit int) ([]NoteRevision, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, lesson_id, note_md, created_at
+		 FROM note_revisions WHERE lesson_id = ? ORDER BY id DESC LIMIT ?`,
+		lessonID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list note revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []NoteRevision
+	for rows.Next() {
+		var rev NoteRevision
+		if err := rows.Scan(&rev.ID, &rev.LessonID, &rev.NoteMD, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan note revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+// GetNoteRevision возвращает версию заметки по ID.
+func (r *Repository) GetNoteRevision(ctx context.Context, id int64) (*NoteRevision, error) {
+	rev := &NoteRevision{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, lesson_id, note_md, created_at FROM note_revisions WHERE id = ?`,
+		id,
+	).Scan(&rev.ID, &rev.LessonID, &rev.NoteMD, &rev.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get note revision: %w", err)
+	}
+	return rev, nil
 }
 
 // --- Submissions ---
 
 // CreateSubmission создаёт запись об отправке решения.
-func (r *Repository) CreateSubmission(s *Submission) error {
-	result, err := r.db.Exec(
-		`INSERT INTO submissions (task_id, code, status, stdout, stderr)
-		 VALUES (?, ?, ?, ?, ?)`,
-		s.TaskID, s.Code, s.Status, s.Stdout, s.Stderr,
+func (r *Repository) CreateSubmission(ctx context.Context, s *Submission) error {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO submissions (task_id, code, status, stdout, stderr, starter_version)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		s.TaskID, s.Code, s.Status, s.Stdout, s.Stderr, s.StarterVersion,
 	)
 	if err != nil {
 		return fmt.Errorf("create submission: %w", err)
@@ -205,19 +454,151 @@ func (r *Repository) CreateSubmission(s *Submission) error {
 	return nil
 }
 
+// ClaimSubmission атомарно забирает отправку в обработку: переводит её
+// из pending в running. Возвращает false, если отправку уже забрала
+// другая реплика — повторная проверка того же решения не запускается.
+func (r *Repository) ClaimSubmission(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE submissions SET status = 'running' WHERE id = ? AND status = 'pending'`,
+		id,
+	)
+	if err != nil {
+		return false, fmt.Errorf("claim submission: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// InterruptedAfter — возраст, после которого отправка в pending или
+// running считается брошенной: проверку оборвал перезапуск сервера.
+// Порог с запасом больше любого таймаута выполнения, чтобы не задеть
+// отправку, которую прямо сейчас проверяет другая реплика.
+const InterruptedAfter = 10 * time.Minute
+
+// ReconcileInterrupted помечает отправки, застрявшие в pending или
+// running дольше olderThan, как interrupted. Вызывается при старте
+// сервера: после перезапуска такие проверки уже никто не завершит.
+func (r *Repository) ReconcileInterrupted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE submissions
+		 SET status = 'interrupted', stderr = 'Проверка прервана перезапуском сервера — отправьте решение ещё раз'
+		 WHERE status IN ('pending', 'running')
+		   AND created_at <= datetime('now', ?)`,
+		fmt.Sprintf("-%d seconds", int(olderThan.Seconds())),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("reconcile interrupted: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetSubmissionByID возвращает отправку по ID или nil, если её нет.
+func (r *Repository) GetSubmissionByID(ctx context.Context, id int64) (*Submission, error) {
+	var s Submission
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, task_id, code, status, stdout, stderr, COALESCE(starter_version, 0), hint, created_at
+		 FROM submissions WHERE id = ?`,
+		id,
+	).Scan(&s.ID, &s.TaskID, &s.Code, &s.Status, &s.Stdout, &s.Stderr, &s.StarterVersion, &s.Hint, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get submission: %w", err)
+	}
+	return &s, nil
+}
+
+// SetSubmissionHint сохраняет LLM-подсказку у отправки.
+func (r *Repository) SetSubmissionHint(ctx context.Context, id int64, hint string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE submissions SET hint = ?, hint_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		hint, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set submission hint: %w", err)
+	}
+	return nil
+}
+
+// CountHintsSince возвращает число подсказок, сгенерированных с момента
+// since. Используется для суточной квоты LLM-подсказок.
+func (r *Repository) CountHintsSince(ctx context.Context, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM submissions WHERE hint_at IS NOT NULL AND hint_at >= ?`,
+		since.UTC().Format("2006-01-02 15:04:05"),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count hints: %w", err)
+	}
+	return count, nil
+}
+
 // UpdateSubmission обновляет статус отправки.
-func (r *Repository) UpdateSubmission(s *Submission) error {
-	_, err := r.db.Exec(
-		`UPDATE submissions SET status = ?, stdout = ?, stderr = ? WHERE id = ?`,
-		s.Status, s.Stdout, s.Stderr, s.ID,
+func (r *Repository) UpdateSubmission(ctx context.Context, s *Submission) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE submissions SET status = ?, stdout = ?, stderr = ?, duration_ms = ? WHERE id = ?`,
+		s.Status, s.Stdout, s.Stderr, s.DurationMS, s.ID,
 	)
 	return err
 }
 
+// TaskStats — агрегированная статистика по заданию: сколько было попыток,
+// какая доля успешна, с какой попытки задание решилось и сколько в среднем
+// работал код. Используется админской аналитикой и калибровкой сложности.
+type TaskStats struct {
+	TaskID         int64   `json:"task_id"`
+	Attempts       int     `json:"attempts"`
+	Passed         int     `json:"passed"`
+	PassRate       float64 `json:"pass_rate"`
+	AttemptsToPass int     `json:"attempts_to_pass"` // 0 — задание ещё не решено
+	AvgRuntimeMS   float64 `json:"avg_runtime_ms"`
+}
+
+// GetTaskStats возвращает статистику по заданию.
+func (r *Repository) GetTaskStats(ctx context.Context, taskID int64) (*TaskStats, error) {
+	stats := &TaskStats{TaskID: taskID}
+
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*),
+		        COALESCE(SUM(CASE WHEN status = 'success' THEN 1 ELSE 0 END), 0),
+		        COALESCE(AVG(CASE WHEN duration_ms > 0 THEN duration_ms END), 0)
+		 FROM submissions WHERE task_id = ?`,
+		taskID,
+	).Scan(&stats.Attempts, &stats.Passed, &stats.AvgRuntimeMS)
+	if err != nil {
+		return nil, fmt.Errorf("get task stats: %w", err)
+	}
+
+	if stats.Attempts > 0 {
+		stats.PassRate = float64(stats.Passed) / float64(stats.Attempts)
+	}
+
+	// Номер попытки, на которой задание решилось впервые
+	if stats.Passed > 0 {
+		err = r.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM submissions
+			 WHERE task_id = ? AND id <= (
+			   SELECT MIN(id) FROM submissions WHERE task_id = ? AND status = 'success'
+			 )`,
+			taskID, taskID,
+		).Scan(&stats.AttemptsToPass)
+		if err != nil {
+			return nil, fmt.Errorf("get attempts to pass: %w", err)
+		}
+	}
+
+	return stats, nil
+}
+
 // IsTaskSolvedSuccessfully проверяет, было ли задание уже успешно решено.
-func (r *Repository) IsTaskSolvedSuccessfully(taskID int64) (bool, error) {
+func (r *Repository) IsTaskSolvedSuccessfully(ctx context.Context, taskID int64) (bool, error) {
 	var count int
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(ctx,
 		`SELECT COUNT(*) FROM submissions WHERE task_id = ? AND status = 'success'`,
 		taskID,
 	).Scan(&count)
@@ -227,14 +608,50 @@ func (r *Repository) IsTaskSolvedSuccessfully(taskID int64) (bool, error) {
 	return count > 0, nil
 }
 
+// CountSubmissionsByTask возвращает количество отправок по заданию.
+func (r *Repository) CountSubmissionsByTask(ctx context.Context, taskID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM submissions WHERE task_id = ?`, taskID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count submissions: %w", err)
+	}
+	return count, nil
+}
+
+// GetSetting возвращает значение настройки или пустую строку, если её нет.
+func (r *Repository) GetSetting(ctx context.Context, key string) (string, error) {
+	var value string
+	err := r.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get setting: %w", err)
+	}
+	return value, nil
+}
+
+// SetSetting сохраняет значение настройки.
+func (r *Repository) SetSetting(ctx context.Context, key, value string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO settings (key, value, updated_at)
+		 VALUES (?, ?, CURRENT_TIMESTAMP)
+		 ON CONFLICT(key) DO UPDATE SET
+		   value = excluded.value,
+		   updated_at = CURRENT_TIMESTAMP`,
+		key, value,
+	)
+	return err
+}
+
 // GetSubmissionsByTaskID возвращает отправки по заданию.
-func (r *Repository) GetSubmissionsByTaskID(taskID int64, limit int) ([]Submission, error) {
+func (r *Repository) GetSubmissionsByTaskID(ctx context.Context, taskID int64, limit int) ([]Submission, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
-	rows, err := r.db.Query(
-		`SELECT id, task_id, code, status, stdout, stderr, created_at 
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, task_id, code, status, stdout, stderr, COALESCE(starter_version, 0) as starter_version, created_at
 		 FROM submissions WHERE task_id = ? ORDER BY created_at DESC LIMIT ?`,
 		taskID, limit,
 	)
@@ -246,7 +663,7 @@ func (r *Repository) GetSubmissionsByTaskID(taskID int64, limit int) ([]Submissi
 	var submissions []Submission
 	for rows.Next() {
 		var s Submission
-		if err := rows.Scan(&s.ID, &s.TaskID, &s.Code, &s.Status, &s.Stdout, &s.Stderr, &s.CreatedAt); err != nil {
+		if err := rows.Scan(&s.ID, &s.TaskID, &s.Code, &s.Status, &s.Stdout, &s.Stderr, &s.StarterVersion, &s.CreatedAt); err != nil {
 			return nil, fmt.Errorf("scan submission: %w", err)
 		}
 		submissions = append(submissions, s)
@@ -258,13 +675,13 @@ func (r *Repository) GetSubmissionsByTaskID(taskID int64, limit int) ([]Submissi
 // --- Stats ---
 
 // ResetAllProgress сбрасывает весь прогресс (очки, статусы, отправки).
-func (r *Repository) ResetAllProgress() error {
+func (r *Repository) ResetAllProgress(ctx context.Context) error {
 	// Удаляем все отправки
-	if _, err := r.db.Exec(`DELETE FROM submissions`); err != nil {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM submissions`); err != nil {
 		return fmt.Errorf("delete submissions: %w", err)
 	}
 	// Удаляем весь прогресс
-	if _, err := r.db.Exec(`DELETE FROM progress`); err != nil {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM progress`); err != nil {
 		return fmt.Errorf("delete progress: %w", err)
 	}
 	// Заметки оставляем — они полезны
@@ -272,38 +689,452 @@ func (r *Repository) ResetAllProgress() error {
 }
 
 // GetStats возвращает общую статистику.
-func (r *Repository) GetStats() (*Stats, error) {
+func (r *Repository) GetStats(ctx context.Context) (*Stats, error) {
 	stats := &Stats{}
 
 	// Общее количество уроков
-	err := r.db.QueryRow(`SELECT COUNT(*) FROM lessons`).Scan(&stats.TotalLessons)
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM lessons`).Scan(&stats.TotalLessons)
 	if err != nil {
 		return nil, fmt.Errorf("count lessons: %w", err)
 	}
 
 	// Завершённые уроки
-	err = r.db.QueryRow(`SELECT COUNT(*) FROM progress WHERE status = 'done'`).Scan(&stats.CompletedCount)
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM progress WHERE status = 'done'`).Scan(&stats.CompletedCount)
 	if err != nil {
 		return nil, fmt.Errorf("count completed: %w", err)
 	}
 
 	// В процессе
-	err = r.db.QueryRow(`SELECT COUNT(*) FROM progress WHERE status = 'reading'`).Scan(&stats.InProgressCount)
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM progress WHERE status = 'reading'`).Scan(&stats.InProgressCount)
 	if err != nil {
 		return nil, fmt.Errorf("count in progress: %w", err)
 	}
 
 	// Общее количество очков
-	err = r.db.QueryRow(`SELECT COALESCE(SUM(points), 0) FROM tasks`).Scan(&stats.TotalPoints)
+	err = r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(points), 0) FROM tasks`).Scan(&stats.TotalPoints)
 	if err != nil {
 		return nil, fmt.Errorf("sum total points: %w", err)
 	}
 
 	// Заработанные очки
-	err = r.db.QueryRow(`SELECT COALESCE(SUM(points_earned), 0) FROM progress`).Scan(&stats.EarnedPoints)
+	err = r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(points_earned), 0) FROM progress`).Scan(&stats.EarnedPoints)
 	if err != nil {
 		return nil, fmt.Errorf("sum earned points: %w", err)
 	}
 
+	// Баллы за capstone-проекты
+	err = r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(points), 0) FROM project_grades`).Scan(&stats.ProjectPoints)
+	if err != nil {
+		return nil, fmt.Errorf("sum project points: %w", err)
+	}
+
+	// Бонусы за код-ревью
+	err = r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(points), 0) FROM peer_reviews`).Scan(&stats.ReviewPoints)
+	if err != nil {
+		return nil, fmt.Errorf("sum review points: %w", err)
+	}
+
+	// Очки клавиатурного тренажёра
+	err = r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(points), 0) FROM typing_results`).Scan(&stats.TypingPoints)
+	if err != nil {
+		return nil, fmt.Errorf("sum typing points: %w", err)
+	}
+
 	return stats, nil
 }
+
+// GetCourseStats возвращает статистику в рамках одного курса.
+// Баллы за capstone-проекты и код-ревью к курсу не привязаны
+// и здесь не учитываются.
+func (r *Repository) GetCourseStats(ctx context.Context, courseID int64) (*Stats, error) {
+	stats := &Stats{}
+
+	// Количество уроков курса
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM lessons WHERE course_id = ? AND deleted_at IS NULL`,
+		courseID,
+	).Scan(&stats.TotalLessons)
+	if err != nil {
+		return nil, fmt.Errorf("count course lessons: %w", err)
+	}
+
+	// Завершённые уроки
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM progress p JOIN lessons l ON l.id = p.lesson_id
+		 WHERE p.status = 'done' AND l.course_id = ?`,
+		courseID,
+	).Scan(&stats.CompletedCount)
+	if err != nil {
+		return nil, fmt.Errorf("count course completed: %w", err)
+	}
+
+	// В процессе
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM progress p JOIN lessons l ON l.id = p.lesson_id
+		 WHERE p.status = 'reading' AND l.course_id = ?`,
+		courseID,
+	).Scan(&stats.InProgressCount)
+	if err != nil {
+		return nil, fmt.Errorf("count course in progress: %w", err)
+	}
+
+	// Очки заданий курса
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(t.points), 0) FROM tasks t JOIN lessons l ON l.id = t.lesson_id
+		 WHERE l.course_id = ?`,
+		courseID,
+	).Scan(&stats.TotalPoints)
+	if err != nil {
+		return nil, fmt.Errorf("sum course total points: %w", err)
+	}
+
+	// Заработанные очки
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(p.points_earned), 0) FROM progress p JOIN lessons l ON l.id = p.lesson_id
+		 WHERE l.course_id = ?`,
+		courseID,
+	).Scan(&stats.EarnedPoints)
+	if err != nil {
+		return nil, fmt.Errorf("sum course earned points: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetStaleInProgress возвращает уроки, зависшие в статусе «читаю»
+// дольше olderThan: начаты, но без движения. Отсортированы от самых
+// давних.
+func (r *Repository) GetStaleInProgress(ctx context.Context, olderThan time.Duration) ([]StaleLesson, error) {
+	cutoff := time.Now().UTC().Add(-olderThan)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT p.lesson_id, l.title, l.slug, p.updated_at
+		 FROM progress p
+		 JOIN lessons l ON l.id = p.lesson_id
+		 WHERE p.status = 'reading' AND l.deleted_at IS NULL AND p.updated_at < ?
+		 ORDER BY p.updated_at`,
+		cutoff,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("stale in progress: %w", err)
+	}
+	defer rows.Close()
+
+	var stale []StaleLesson
+	for rows.Next() {
+		var s StaleLesson
+		if err := rows.Scan(&s.LessonID, &s.Title, &s.Slug, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan stale lesson: %w", err)
+		}
+		stale = append(stale, s)
+	}
+	return stale, rows.Err()
+}
+
+// --- Capstone-проекты ---
+
+// GetProjectMilestones возвращает номера отмеченных пунктов
+// чек-листа проекта.
+func (r *Repository) GetProjectMilestones(ctx context.Context, projectID string) (map[int]bool, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT milestone_index FROM project_progress WHERE project_id = ?`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("get project milestones: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int]bool)
+	for rows.Next() {
+		var index int
+		if err := rows.Scan(&index); err != nil {
+			return nil, fmt.Errorf("scan milestone: %w", err)
+		}
+		done[index] = true
+	}
+	return done, rows.Err()
+}
+
+// SetProjectMilestone отмечает или снимает пункт чек-листа проекта.
+func (r *Repository) SetProjectMilestone(ctx context.Context, projectID string, index int, done bool) error {
+	var err error
+	if done {
+		_, err = r.db.ExecContext(ctx,
+			`INSERT INTO project_progress (project_id, milestone_index) VALUES (?, ?)
+			 ON CONFLICT(project_id, milestone_index) DO NOTHING`,
+			projectID, index)
+	} else {
+		_, err = r.db.ExecContext(ctx,
+			`DELETE FROM project_progress WHERE project_id = ? AND milestone_index = ?`,
+			projectID, index)
+	}
+	if err != nil {
+		return fmt.Errorf("set project milestone: %w", err)
+	}
+	return nil
+}
+
+// ProjectGrade — оценка по одному критерию рубрики проекта.
+type ProjectGrade struct {
+	Criterion string
+	Points    int
+	Comment   string
+	GradedAt  time.Time
+}
+
+// GetProjectGrades возвращает выставленные оценки по критериям проекта.
+func (r *Repository) GetProjectGrades(ctx context.Context, projectID string) (map[string]ProjectGrade, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT criterion, points, comment, graded_at FROM project_grades WHERE project_id = ?`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("get project grades: %w", err)
+	}
+	defer rows.Close()
+
+	grades := make(map[string]ProjectGrade)
+	for rows.Next() {
+		var g ProjectGrade
+		if err := rows.Scan(&g.Criterion, &g.Points, &g.Comment, &g.GradedAt); err != nil {
+			return nil, fmt.Errorf("scan project grade: %w", err)
+		}
+		grades[g.Criterion] = g
+	}
+	return grades, rows.Err()
+}
+
+// SetProjectGrade выставляет баллы и комментарий по критерию рубрики.
+func (r *Repository) SetProjectGrade(ctx context.Context, projectID, criterion string, points int, comment string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO project_grades (project_id, criterion, points, comment) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(project_id, criterion) DO UPDATE SET
+		   points = excluded.points,
+		   comment = excluded.comment,
+		   graded_at = CURRENT_TIMESTAMP`,
+		projectID, criterion, points, comment)
+	if err != nil {
+		return fmt.Errorf("set project grade: %w", err)
+	}
+	return nil
+}
+
+// KataResult — лучший результат и число попыток по одному ката.
+type KataResult struct {
+	Slug     string
+	Passed   bool
+	Attempts int
+}
+
+// GetKataResults возвращает результаты всех ката по slug'ам.
+func (r *Repository) GetKataResults(ctx context.Context) (map[string]KataResult, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT kata_slug, passed, attempts FROM kata_results`)
+	if err != nil {
+		return nil, fmt.Errorf("get kata results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make(map[string]KataResult)
+	for rows.Next() {
+		var k KataResult
+		if err := rows.Scan(&k.Slug, &k.Passed, &k.Attempts); err != nil {
+			return nil, fmt.Errorf("scan kata result: %w", err)
+		}
+		results[k.Slug] = k
+	}
+	return results, rows.Err()
+}
+
+// RecordKataAttempt записывает попытку решения ката. Успех «залипает»:
+// однажды пройденное ката остаётся пройденным.
+func (r *Repository) RecordKataAttempt(ctx context.Context, slug string, passed bool) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO kata_results (kata_slug, passed, attempts) VALUES (?, ?, 1)
+		 ON CONFLICT(kata_slug) DO UPDATE SET
+		   attempts = attempts + 1,
+		   passed = MAX(passed, excluded.passed),
+		   updated_at = CURRENT_TIMESTAMP`,
+		slug, passed)
+	if err != nil {
+		return fmt.Errorf("record kata attempt: %w", err)
+	}
+	return nil
+}
+
+// TypingResult — лучший результат набора одного примера кода.
+// CodeKey — хэш текста примера (см. practice.TypingKey).
+type TypingResult struct {
+	CodeKey  string
+	LessonID int64
+	Accuracy float64
+	WPM      float64
+	Points   int
+	Attempts int
+}
+
+// GetTypingResults возвращает результаты тренажёра по ключам примеров.
+func (r *Repository) GetTypingResults(ctx context.Context) (map[string]TypingResult, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT code_key, lesson_id, accuracy, wpm, points, attempts FROM typing_results`)
+	if err != nil {
+		return nil, fmt.Errorf("get typing results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make(map[string]TypingResult)
+	for rows.Next() {
+		var t TypingResult
+		if err := rows.Scan(&t.CodeKey, &t.LessonID, &t.Accuracy, &t.WPM, &t.Points, &t.Attempts); err != nil {
+			return nil, fmt.Errorf("scan typing result: %w", err)
+		}
+		results[t.CodeKey] = t
+	}
+	return results, rows.Err()
+}
+
+// RecordTypingAttempt записывает попытку набора примера. По каждой
+// метрике сохраняется лучшее значение, как в kata_results.
+func (r *Repository) RecordTypingAttempt(ctx context.Context, codeKey string, lessonID int64, accuracy, wpm float64, points int) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO typing_results (code_key, lesson_id, accuracy, wpm, points, attempts) VALUES (?, ?, ?, ?, ?, 1)
+		 ON CONFLICT(code_key) DO UPDATE SET
+		   attempts = attempts + 1,
+		   accuracy = MAX(accuracy, excluded.accuracy),
+		   wpm = MAX(wpm, excluded.wpm),
+		   points = MAX(points, excluded.points),
+		   updated_at = CURRENT_TIMESTAMP`,
+		codeKey, lessonID, accuracy, wpm, points)
+	if err != nil {
+		return fmt.Errorf("record typing attempt: %w", err)
+	}
+	return nil
+}
+
+// GuidedStep — состояние одного шага пошагового задания: последний
+// сохранённый код и отметка о прохождении.
+type GuidedStep struct {
+	StepIndex int
+	Code      string
+	Passed    bool
+}
+
+// GetGuidedSteps возвращает состояние шагов задания по их индексам.
+func (r *Repository) GetGuidedSteps(ctx context.Context, taskID int64) (map[int]GuidedStep, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT step_index, code, passed FROM guided_progress WHERE task_id = ?`,
+		taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get guided steps: %w", err)
+	}
+	defer rows.Close()
+
+	steps := make(map[int]GuidedStep)
+	for rows.Next() {
+		var st GuidedStep
+		if err := rows.Scan(&st.StepIndex, &st.Code, &st.Passed); err != nil {
+			return nil, fmt.Errorf("scan guided step: %w", err)
+		}
+		steps[st.StepIndex] = st
+	}
+	return steps, rows.Err()
+}
+
+// SaveGuidedStep сохраняет код и результат шага. Как и у ката, успех
+// «залипает» — пройденный шаг не закрывается обратно.
+func (r *Repository) SaveGuidedStep(ctx context.Context, taskID int64, stepIndex int, code string, passed bool) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO guided_progress (task_id, step_index, code, passed) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(task_id, step_index) DO UPDATE SET
+		   code = excluded.code,
+		   passed = MAX(passed, excluded.passed),
+		   updated_at = CURRENT_TIMESTAMP`,
+		taskID, stepIndex, code, passed)
+	if err != nil {
+		return fmt.Errorf("save guided step: %w", err)
+	}
+	return nil
+}
+
+// PeerReviewBonus — бонусные очки за одну завершённую рецензию.
+const PeerReviewBonus = 5
+
+// PeerReview — рецензия на анонимное решение задания.
+// Оценки по критериям: 0 — плохо, 1 — нормально, 2 — хорошо.
+type PeerReview struct {
+	ID           int64
+	SubmissionID int64
+	TaskID       int64
+	Readability  int
+	Correctness  int
+	Idiomatic    int
+	CommentMD    string
+	Points       int
+	CreatedAt    time.Time
+}
+
+// GetReviewCandidates возвращает до limit успешных решений задания,
+// ещё не получивших рецензию. Отправка exceptID (только что засчитанная)
+// исключается: рецензируют чужой код, а не свой. Одинаковые решения
+// схлопываются, порядок случайный.
+func (r *Repository) GetReviewCandidates(ctx context.Context, taskID, exceptID int64, limit int) ([]Submission, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, task_id, code, status, stdout, stderr, created_at
+		 FROM submissions
+		 WHERE task_id = ? AND status = 'success' AND id != ?
+		   AND id NOT IN (SELECT submission_id FROM peer_reviews)
+		 GROUP BY code
+		 ORDER BY RANDOM()
+		 LIMIT ?`,
+		taskID, exceptID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get review candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var submissions []Submission
+	for rows.Next() {
+		var s Submission
+		if err := rows.Scan(&s.ID, &s.TaskID, &s.Code, &s.Status, &s.Stdout, &s.Stderr, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan review candidate: %w", err)
+		}
+		submissions = append(submissions, s)
+	}
+	return submissions, rows.Err()
+}
+
+// CreatePeerReview сохраняет рецензию. Повторная рецензия на ту же
+// отправку запрещена ограничением UNIQUE(submission_id).
+func (r *Repository) CreatePeerReview(ctx context.Context, rev *PeerReview) error {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO peer_reviews (submission_id, task_id, readability, correctness, idiomatic, comment_md, points)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		rev.SubmissionID, rev.TaskID, rev.Readability, rev.Correctness, rev.Idiomatic, rev.CommentMD, rev.Points,
+	)
+	if err != nil {
+		return fmt.Errorf("create peer review: %w", err)
+	}
+	rev.ID, _ = res.LastInsertId()
+	return nil
+}
+
+// GetPeerReviewsByTaskID возвращает рецензии на решения задания,
+// новые первыми.
+func (r *Repository) GetPeerReviewsByTaskID(ctx context.Context, taskID int64) ([]PeerReview, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, submission_id, task_id, readability, correctness, idiomatic, comment_md, points, created_at
+		 FROM peer_reviews WHERE task_id = ? ORDER BY created_at DESC`,
+		taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get peer reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []PeerReview
+	for rows.Next() {
+		var rev PeerReview
+		if err := rows.Scan(&rev.ID, &rev.SubmissionID, &rev.TaskID, &rev.Readability, &rev.Correctness, &rev.Idiomatic, &rev.CommentMD, &rev.Points, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan peer review: %w", err)
+		}
+		reviews = append(reviews, rev)
+	}
+	return reviews, rows.Err()
+}
@@ -0,0 +1,186 @@
+package progress
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PracticeSession — практическая сессия из случайных заданий.
+type PracticeSession struct {
+	ID          int64
+	TaskIDs     []int64
+	DurationMin int
+	StartedAt   time.Time
+	FinishedAt  time.Time // нулевое время, если сессия не завершена
+	SolvedCount int
+	TotalCount  int
+}
+
+// ListPracticeCandidates возвращает ID заданий-кандидатов для сессии:
+// ещё не решённые задания из модулей, в которых все уроки прочитаны.
+func (r *Repository) ListPracticeCandidates(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT t.id
+		 FROM tasks t
+		 JOIN lessons l ON l.id = t.lesson_id
+		 WHERE t.id NOT IN (SELECT task_id FROM submissions WHERE status = 'success')
+		   AND l.module_id IN (
+		       SELECT m.id FROM modules m
+		       WHERE NOT EXISTS (
+		           SELECT 1 FROM lessons l2
+		           LEFT JOIN progress p ON p.lesson_id = l2.id
+		           WHERE l2.module_id = m.id AND (p.status IS NULL OR p.status != 'done')
+		       )
+		   )`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list practice candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan candidate: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CreatePracticeSession сохраняет новую сессию.
+func (r *Repository) CreatePracticeSession(ctx context.Context, taskIDs []int64, durationMin int) (*PracticeSession, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO practice_sessions (task_ids, duration_min, total_count) VALUES (?, ?, ?)`,
+		joinIDs(taskIDs), durationMin, len(taskIDs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create practice session: %w", err)
+	}
+
+	id, _ := result.LastInsertId()
+	return &PracticeSession{
+		ID:          id,
+		TaskIDs:     taskIDs,
+		DurationMin: durationMin,
+		StartedAt:   time.Now(),
+		TotalCount:  len(taskIDs),
+	}, nil
+}
+
+// GetPracticeSession возвращает сессию по ID.
+func (r *Repository) GetPracticeSession(ctx context.Context, id int64) (*PracticeSession, error) {
+	s := &PracticeSession{}
+	var taskIDs string
+	var finishedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, task_ids, duration_min, started_at, finished_at, solved_count, total_count
+		 FROM practice_sessions WHERE id = ?`,
+		id,
+	).Scan(&s.ID, &taskIDs, &s.DurationMin, &s.StartedAt, &finishedAt, &s.SolvedCount, &s.TotalCount)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get practice session: %w", err)
+	}
+
+	s.TaskIDs = splitIDs(taskIDs)
+	if finishedAt.Valid {
+		s.FinishedAt = finishedAt.Time
+	}
+	return s, nil
+}
+
+// FinishPracticeSession фиксирует итоги сессии и пополняет очередь повторения:
+// нерешённые задания попадают на завтра, решённые — с растущим интервалом.
+func (r *Repository) FinishPracticeSession(ctx context.Context, id int64, solvedTaskIDs []int64) error {
+	session, err := r.GetPracticeSession(ctx, id)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return fmt.Errorf("practice session %d not found", id)
+	}
+
+	solved := make(map[int64]bool, len(solvedTaskIDs))
+	for _, taskID := range solvedTaskIDs {
+		solved[taskID] = true
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`UPDATE practice_sessions SET solved_count = ?, finished_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		len(solvedTaskIDs), id,
+	)
+	if err != nil {
+		return fmt.Errorf("finish practice session: %w", err)
+	}
+
+	for _, taskID := range session.TaskIDs {
+		if err := r.scheduleReview(ctx, taskID, solved[taskID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleReview обновляет очередь интервального повторения для задания.
+func (r *Repository) scheduleReview(ctx context.Context, taskID int64, solved bool) error {
+	var intervalDays int
+	err := r.db.QueryRowContext(ctx, `SELECT interval_days FROM review_queue WHERE task_id = ?`, taskID).Scan(&intervalDays)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("get review interval: %w", err)
+	}
+
+	if solved {
+		// Успех — удваиваем интервал (минимум 3 дня)
+		intervalDays *= 2
+		if intervalDays < 3 {
+			intervalDays = 3
+		}
+	} else {
+		// Неудача — повторяем завтра
+		intervalDays = 1
+	}
+
+	dueAt := time.Now().AddDate(0, 0, intervalDays)
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO review_queue (task_id, due_at, interval_days, updated_at)
+		 VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		 ON CONFLICT(task_id) DO UPDATE SET
+		   due_at = excluded.due_at,
+		   interval_days = excluded.interval_days,
+		   updated_at = CURRENT_TIMESTAMP`,
+		taskID, dueAt, intervalDays,
+	)
+	if err != nil {
+		return fmt.Errorf("schedule review: %w", err)
+	}
+	return nil
+}
+
+// joinIDs сериализует список ID в строку через запятую.
+func joinIDs(ids []int64) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitIDs разбирает список ID из строки.
+func splitIDs(s string) []int64 {
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
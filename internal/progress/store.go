@@ -0,0 +1,96 @@
+package progress
+
+import (
+	"context"
+	"time"
+)
+
+// Store — интерфейс хранилища прогресса. Его реализуют SQL-репозиторий
+// (Repository) и in-memory фейк (FakeStore) для тестов без SQLite.
+type Store interface {
+	// WithTx выполняет fn в одной транзакции (для фейка — просто вызывает fn).
+	WithTx(ctx context.Context, fn func(s Store) error) error
+
+	// Прогресс по урокам
+	GetProgress(ctx context.Context, lessonID int64) (*Progress, error)
+	UpdateProgress(ctx context.Context, p *Progress) error
+	SetStatus(ctx context.Context, lessonID int64, status Status) error
+	GetAllProgress(ctx context.Context) (map[int64]*Progress, error)
+	ResetAllProgress(ctx context.Context) error
+	GetStats(ctx context.Context) (*Stats, error)
+	GetCourseStats(ctx context.Context, courseID int64) (*Stats, error)
+	GetStaleInProgress(ctx context.Context, olderThan time.Duration) ([]StaleLesson, error)
+
+	// Чек-листы и оценки capstone-проектов
+	GetProjectMilestones(ctx context.Context, projectID string) (map[int]bool, error)
+	SetProjectMilestone(ctx context.Context, projectID string, index int, done bool) error
+	GetProjectGrades(ctx context.Context, projectID string) (map[string]ProjectGrade, error)
+	SetProjectGrade(ctx context.Context, projectID, criterion string, points int, comment string) error
+
+	// Результаты ката
+	GetKataResults(ctx context.Context) (map[string]KataResult, error)
+	RecordKataAttempt(ctx context.Context, slug string, passed bool) error
+
+	// Клавиатурный тренажёр
+	GetTypingResults(ctx context.Context) (map[string]TypingResult, error)
+	RecordTypingAttempt(ctx context.Context, codeKey string, lessonID int64, accuracy, wpm float64, points int) error
+
+	// Шаги пошаговых заданий
+	GetGuidedSteps(ctx context.Context, taskID int64) (map[int]GuidedStep, error)
+	SaveGuidedStep(ctx context.Context, taskID int64, stepIndex int, code string, passed bool) error
+
+	// Взаимное код-ревью
+	GetReviewCandidates(ctx context.Context, taskID, exceptID int64, limit int) ([]Submission, error)
+	CreatePeerReview(ctx context.Context, rev *PeerReview) error
+	GetPeerReviewsByTaskID(ctx context.Context, taskID int64) ([]PeerReview, error)
+
+	// Засчитанные задания и очки
+	CompleteTask(ctx context.Context, taskID, lessonID int64, points int) (bool, error)
+	IsTaskCompleted(ctx context.Context, taskID int64) (bool, error)
+	GetTaskCompletionPoints(ctx context.Context, lessonID int64) (map[int64]int, error)
+
+	// Заметки
+	GetNote(ctx context.Context, lessonID int64) (*Note, error)
+	SaveNote(ctx context.Context, lessonID int64, noteMD string) error
+	SearchNotes(ctx context.Context, query string, limit int) ([]NoteSearchResult, error)
+	ListNoteRevisions(ctx context.Context, lessonID int64, limit int) ([]NoteRevision, error)
+	GetNoteRevision(ctx context.Context, id int64) (*NoteRevision, error)
+
+	// Отправки решений
+	CreateSubmission(ctx context.Context, s *Submission) error
+	ClaimSubmission(ctx context.Context, id int64) (bool, error)
+	UpdateSubmission(ctx context.Context, s *Submission) error
+	ReconcileInterrupted(ctx context.Context, olderThan time.Duration) (int64, error)
+	GetSubmissionsByTaskID(ctx context.Context, taskID int64, limit int) ([]Submission, error)
+	GetSubmissionByID(ctx context.Context, id int64) (*Submission, error)
+	SetSubmissionHint(ctx context.Context, id int64, hint string) error
+	CountHintsSince(ctx context.Context, since time.Time) (int, error)
+	IsTaskSolvedSuccessfully(ctx context.Context, taskID int64) (bool, error)
+	CountSubmissionsByTask(ctx context.Context, taskID int64) (int, error)
+	GetTaskStats(ctx context.Context, taskID int64) (*TaskStats, error)
+
+	// Настройки
+	GetSetting(ctx context.Context, key string) (string, error)
+	SetSetting(ctx context.Context, key, value string) error
+
+	// Практические сессии и журнал ошибок
+	ListPracticeCandidates(ctx context.Context) ([]int64, error)
+	CreatePracticeSession(ctx context.Context, taskIDs []int64, durationMin int) (*PracticeSession, error)
+	GetPracticeSession(ctx context.Context, id int64) (*PracticeSession, error)
+	FinishPracticeSession(ctx context.Context, id int64, solvedTaskIDs []int64) error
+	MistakeJournal(ctx context.Context) ([]MistakeStat, error)
+
+	// Данные для виджетов главной страницы
+	ListDueReviews(ctx context.Context, limit int) ([]DueReview, error)
+	ListRecentSubmissions(ctx context.Context, limit int) ([]RecentSubmission, error)
+
+	// Личный словарь терминов
+	AddVocabularyTerm(ctx context.Context, term, definition string, lessonID int64) (*VocabularyTerm, error)
+	ListVocabulary(ctx context.Context) ([]VocabularyTerm, error)
+	DueVocabulary(ctx context.Context, limit int) ([]VocabularyTerm, error)
+	ReviewVocabularyTerm(ctx context.Context, id int64, remembered bool) error
+	DeleteVocabularyTerm(ctx context.Context, id int64) error
+}
+
+// Проверка соответствия интерфейсу на этапе компиляции.
+var _ Store = (*Repository)(nil)
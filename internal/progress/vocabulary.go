@@ -0,0 +1,135 @@
+package progress
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VocabularyTerm — термин из личного словаря. Словарь пополняется со
+// страниц уроков (выделение текста) или вручную и питает колоду
+// интервального повторения: расписание то же, что у очереди заданий.
+type VocabularyTerm struct {
+	ID           int64
+	Term         string
+	Definition   string
+	LessonID     int64  // 0 — добавлен без привязки к уроку
+	LessonSlug   string // заполняется при чтении, если урок ещё существует
+	DueAt        time.Time
+	IntervalDays int
+	Reviews      int
+	CreatedAt    time.Time
+}
+
+// AddVocabularyTerm добавляет термин в словарь; повторное добавление
+// обновляет определение и привязку к уроку, не сбрасывая расписание.
+func (r *Repository) AddVocabularyTerm(ctx context.Context, term, definition string, lessonID int64) (*VocabularyTerm, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("пустой термин")
+	}
+
+	var lesson interface{}
+	if lessonID > 0 {
+		lesson = lessonID
+	}
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO vocabulary (term, definition, lesson_id) VALUES (?, ?, ?)
+		 ON CONFLICT(term) DO UPDATE SET
+		   definition = CASE WHEN excluded.definition != '' THEN excluded.definition ELSE definition END,
+		   lesson_id = COALESCE(excluded.lesson_id, lesson_id)`,
+		term, strings.TrimSpace(definition), lesson,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("add vocabulary term: %w", err)
+	}
+
+	return r.getVocabularyTerm(ctx, `WHERE v.term = ?`, term)
+}
+
+// ListVocabulary возвращает весь словарь: сначала термины к повторению.
+func (r *Repository) ListVocabulary(ctx context.Context) ([]VocabularyTerm, error) {
+	return r.queryVocabulary(ctx, `ORDER BY v.due_at, v.term`)
+}
+
+// DueVocabulary возвращает термины, срок повторения которых наступил.
+func (r *Repository) DueVocabulary(ctx context.Context, limit int) ([]VocabularyTerm, error) {
+	return r.queryVocabulary(ctx,
+		`WHERE v.due_at <= CURRENT_TIMESTAMP ORDER BY v.due_at LIMIT ?`, limit)
+}
+
+// ReviewVocabularyTerm фиксирует итог повторения термина по той же
+// схеме, что scheduleReview: успех удваивает интервал (минимум 3 дня),
+// неудача возвращает термин на завтра.
+func (r *Repository) ReviewVocabularyTerm(ctx context.Context, id int64, remembered bool) error {
+	var intervalDays int
+	err := r.db.QueryRowContext(ctx, `SELECT interval_days FROM vocabulary WHERE id = ?`, id).Scan(&intervalDays)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("термин %d не найден", id)
+	}
+	if err != nil {
+		return fmt.Errorf("get vocabulary interval: %w", err)
+	}
+
+	if remembered {
+		intervalDays *= 2
+		if intervalDays < 3 {
+			intervalDays = 3
+		}
+	} else {
+		intervalDays = 1
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`UPDATE vocabulary SET due_at = ?, interval_days = ?, reviews = reviews + 1 WHERE id = ?`,
+		time.Now().AddDate(0, 0, intervalDays), intervalDays, id,
+	)
+	if err != nil {
+		return fmt.Errorf("review vocabulary term: %w", err)
+	}
+	return nil
+}
+
+// DeleteVocabularyTerm удаляет термин из словаря.
+func (r *Repository) DeleteVocabularyTerm(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM vocabulary WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete vocabulary term: %w", err)
+	}
+	return nil
+}
+
+// vocabularySelect — общая часть запросов словаря: slug урока
+// подтягивается для ссылки «откуда термин».
+const vocabularySelect = `SELECT v.id, v.term, v.definition, COALESCE(v.lesson_id, 0),
+       COALESCE(l.slug, ''), v.due_at, v.interval_days, v.reviews, v.created_at
+ FROM vocabulary v
+ LEFT JOIN lessons l ON l.id = v.lesson_id AND l.deleted_at IS NULL `
+
+func (r *Repository) getVocabularyTerm(ctx context.Context, tail string, args ...interface{}) (*VocabularyTerm, error) {
+	terms, err := r.queryVocabulary(ctx, tail, args...)
+	if err != nil || len(terms) == 0 {
+		return nil, err
+	}
+	return &terms[0], nil
+}
+
+func (r *Repository) queryVocabulary(ctx context.Context, tail string, args ...interface{}) ([]VocabularyTerm, error) {
+	rows, err := r.db.QueryContext(ctx, vocabularySelect+tail, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	var terms []VocabularyTerm
+	for rows.Next() {
+		var t VocabularyTerm
+		if err := rows.Scan(&t.ID, &t.Term, &t.Definition, &t.LessonID, &t.LessonSlug,
+			&t.DueAt, &t.IntervalDays, &t.Reviews, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan vocabulary term: %w", err)
+		}
+		terms = append(terms, t)
+	}
+	return terms, rows.Err()
+}
@@ -0,0 +1,41 @@
+package rag
+
+import "strings"
+
+// maxChunkChars — целевой размер фрагмента. Фрагмент собирается из
+// целых абзацев: резать посреди предложения — портить и поиск, и цитаты.
+const maxChunkChars = 1200
+
+// minChunkChars — слишком короткие обрывки (заголовки, разделители)
+// в индекс не попадают.
+const minChunkChars = 40
+
+// chunkText режет текст урока на фрагменты по абзацам.
+func chunkText(text string) []string {
+	var chunks []string
+	var current strings.Builder
+
+	flush := func() {
+		chunk := strings.TrimSpace(current.String())
+		if len(chunk) >= minChunkChars {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+	}
+
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		if current.Len() > 0 && current.Len()+len(para) > maxChunkChars {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(para)
+	}
+	flush()
+	return chunks
+}
@@ -0,0 +1,224 @@
+// Package rag — «спросить курс»: поиск по урокам через эмбеддинги и
+// ответ языковой модели с цитатами. Уроки режутся на фрагменты, векторы
+// хранятся в SQLite (таблица rag_chunks); подходящие фрагменты ищутся
+// косинусной близостью в приложении — объём курса невелик.
+package rag
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"golearning/internal/config"
+	"golearning/internal/content"
+	"golearning/internal/llm"
+)
+
+// topChunks — сколько фрагментов попадает в контекст ответа.
+const topChunks = 6
+
+// embedBatch — сколько фрагментов уходит в один запрос эмбеддингов.
+const embedBatch = 32
+
+// Service отвечает на вопросы по содержимому курса.
+type Service struct {
+	db          *sql.DB
+	contentRepo *content.Repository
+	client      *llm.Client
+}
+
+// NewService создаёт сервис вопросов по курсу.
+func NewService(db *sql.DB, contentRepo *content.Repository, cfg config.LLM) *Service {
+	return &Service{db: db, contentRepo: contentRepo, client: llm.NewClient(cfg)}
+}
+
+// Source — фрагмент урока, на который опирается ответ.
+type Source struct {
+	LessonID    int64  `json:"-"`
+	LessonSlug  string `json:"lesson_slug"`
+	LessonTitle string `json:"lesson_title"`
+	Text        string `json:"text"`
+}
+
+// Answer — ответ модели с источниками.
+type Answer struct {
+	Text    string   `json:"answer"`
+	Sources []Source `json:"sources"`
+}
+
+// Reindex перестраивает векторный индекс целиком: режет уроки на
+// фрагменты и запрашивает эмбеддинги пакетами. Возвращает число
+// проиндексированных фрагментов.
+func (s *Service) Reindex(ctx context.Context) (int, error) {
+	lessons, err := s.contentRepo.ListAllLessons(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("чтение уроков: %w", err)
+	}
+
+	type pending struct {
+		lessonID int64
+		seq      int
+		text     string
+	}
+	var chunks []pending
+	for _, l := range lessons {
+		for seq, text := range chunkText(l.Title + "\n\n" + l.BodyMD) {
+			chunks = append(chunks, pending{lessonID: l.ID, seq: seq, text: text})
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("начало транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rag_chunks`); err != nil {
+		return 0, fmt.Errorf("очистка индекса: %w", err)
+	}
+
+	for start := 0; start < len(chunks); start += embedBatch {
+		end := start + embedBatch
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+		batch := chunks[start:end]
+
+		texts := make([]string, len(batch))
+		for i, c := range batch {
+			texts[i] = c.text
+		}
+		vectors, err := s.client.Embed(ctx, texts)
+		if err != nil {
+			return 0, fmt.Errorf("эмбеддинги: %w", err)
+		}
+
+		for i, c := range batch {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO rag_chunks (lesson_id, seq, text, embedding) VALUES (?, ?, ?, ?)`,
+				c.lessonID, c.seq, c.text, encodeVector(vectors[i]),
+			)
+			if err != nil {
+				return 0, fmt.Errorf("запись фрагмента: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("фиксация индекса: %w", err)
+	}
+	return len(chunks), nil
+}
+
+// Ask отвечает на вопрос по материалам курса. Ответ опирается только
+// на найденные фрагменты; их уроки возвращаются как источники.
+func (s *Service) Ask(ctx context.Context, question string) (*Answer, error) {
+	vectors, err := s.client.Embed(ctx, []string{question})
+	if err != nil {
+		return nil, fmt.Errorf("эмбеддинг вопроса: %w", err)
+	}
+
+	sources, err := s.retrieve(ctx, vectors[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("векторный индекс пуст — запустите задачу rag.reindex")
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Вопрос: %s\n\nФрагменты уроков:\n", question)
+	for i, src := range sources {
+		fmt.Fprintf(&prompt, "[%d] Урок «%s»:\n%s\n\n", i+1, src.LessonTitle, src.Text)
+	}
+
+	system := "Ты помощник учебного курса по Go. Отвечай на русском и только по приведённым фрагментам уроков; " +
+		"на утверждения ссылайся номерами фрагментов в квадратных скобках. " +
+		"Если во фрагментах ответа нет, скажи об этом прямо и не придумывай."
+
+	text, err := s.client.Complete(ctx, system, prompt.String())
+	if err != nil {
+		return nil, fmt.Errorf("ответ модели: %w", err)
+	}
+	return &Answer{Text: text, Sources: sources}, nil
+}
+
+// retrieve возвращает фрагменты, ближайшие к вектору вопроса.
+func (s *Service) retrieve(ctx context.Context, query []float32) ([]Source, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT c.text, c.embedding, l.id, l.slug, l.title
+		 FROM rag_chunks c
+		 JOIN lessons l ON l.id = c.lesson_id AND l.deleted_at IS NULL`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("чтение индекса: %w", err)
+	}
+	defer rows.Close()
+
+	type scored struct {
+		src   Source
+		score float64
+	}
+	var all []scored
+	for rows.Next() {
+		var src Source
+		var blob []byte
+		if err := rows.Scan(&src.Text, &blob, &src.LessonID, &src.LessonSlug, &src.LessonTitle); err != nil {
+			return nil, fmt.Errorf("чтение фрагмента: %w", err)
+		}
+		all = append(all, scored{src: src, score: cosine(query, decodeVector(blob))})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].score > all[j].score })
+	if len(all) > topChunks {
+		all = all[:topChunks]
+	}
+
+	sources := make([]Source, len(all))
+	for i, s := range all {
+		sources[i] = s.src
+	}
+	return sources, nil
+}
+
+// cosine — косинусная близость двух векторов; 0 при несовпадении длин.
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// encodeVector сериализует вектор в little-endian float32 BLOB.
+func encodeVector(v []float32) []byte {
+	buf := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(f))
+	}
+	return buf
+}
+
+// decodeVector разбирает BLOB обратно в вектор.
+func decodeVector(buf []byte) []float32 {
+	v := make([]float32, len(buf)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return v
+}
@@ -0,0 +1,260 @@
+// Package render — единая точка рендеринга Markdown. Поверх GFM
+// (таблицы, чек-листы) включены сноски, автоматические якоря заголовков,
+// admonition-блоки в стиле GitHub (> [!WARNING]) и подсветка синтаксиса.
+// Отсюда же извлекается оглавление урока.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Heading — элемент оглавления урока.
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// Renderer конвертирует Markdown в HTML.
+type Renderer struct {
+	md goldmark.Markdown
+}
+
+// New создаёт рендерер со всеми расширениями.
+func New() *Renderer {
+	return &Renderer{md: newGoldmark()}
+}
+
+func newGoldmark() goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,      // таблицы, чек-листы, зачёркивание
+			extension.Footnote, // сноски [^1]
+			highlighting.NewHighlighting(
+				// monokai — тёмная тема в тон остальному интерфейсу
+				highlighting.WithStyle("monokai"),
+			),
+		),
+		goldmark.WithParserOptions(
+			parser.WithASTTransformers(
+				// Свои якоря вместо parser.WithAutoHeadingID: штатные
+				// ломаются на кириллице («heading», «heading-1»)
+				util.Prioritized(headingIDTransformer{}, 800),
+				util.Prioritized(admonitionTransformer{}, 900),
+				util.Prioritized(stdlibLinkTransformer{}, 950),
+			),
+		),
+		goldmark.WithRendererOptions(
+			html.WithUnsafe(), // Разрешаем HTML в Markdown
+		),
+	)
+}
+
+// HTML конвертирует Markdown в HTML.
+func (r *Renderer) HTML(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(src), &buf); err != nil {
+		return "", fmt.Errorf("render markdown: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Outline извлекает заголовки из Markdown с теми же якорями,
+// что получаются при рендеринге.
+func (r *Renderer) Outline(src string) []Heading {
+	source := []byte(src)
+	reader := text.NewReader(source)
+	doc := r.md.Parser().Parse(reader)
+
+	var outline []Heading
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		heading, ok := n.(*ast.Heading)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		id := ""
+		if v, found := heading.AttributeString("id"); found {
+			if b, ok := v.([]byte); ok {
+				id = string(b)
+			}
+		}
+		outline = append(outline, Heading{
+			Level: heading.Level,
+			ID:    id,
+			Text:  string(heading.Text(source)),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	return outline
+}
+
+// admonitionKinds — поддерживаемые виды admonition-блоков GitHub.
+var admonitionKinds = map[string]string{
+	"NOTE":      "note",
+	"TIP":       "tip",
+	"IMPORTANT": "important",
+	"WARNING":   "warning",
+	"CAUTION":   "caution",
+}
+
+// admonitionTransformer превращает цитаты вида «> [!WARNING] ...» в
+// блоки с классом admonition-*: маркер вырезается, blockquote получает
+// CSS-класс, по которому оформляется цветная плашка.
+type admonitionTransformer struct{}
+
+func (admonitionTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		quote, ok := n.(*ast.Blockquote)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+
+		para, ok := quote.FirstChild().(*ast.Paragraph)
+		if !ok || para.Lines().Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+		line := para.Lines().At(0)
+		kind := admonitionKind(string(line.Value(source)))
+		if kind == "" {
+			return ast.WalkContinue, nil
+		}
+
+		quote.SetAttributeString("class", []byte("admonition admonition-"+kind))
+		trimMarker(para, source)
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// admonitionKind возвращает вид блока по первой строке цитаты.
+func admonitionKind(line string) string {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[!") {
+		return ""
+	}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return ""
+	}
+	return admonitionKinds[line[2:end]]
+}
+
+// trimMarker убирает «[!KIND]» из первой строки абзаца. Правится не
+// только сегмент строки, но и inline-узлы: рендерер абзаца читает текст
+// именно из них.
+func trimMarker(para *ast.Paragraph, source []byte) {
+	line := para.Lines().At(0)
+	value := string(line.Value(source))
+	end := strings.Index(value, "]")
+	if end < 0 {
+		return
+	}
+	offset := end + 1
+	// Съедаем пробелы после маркера
+	for offset < len(value) && (value[offset] == ' ' || value[offset] == '\t') {
+		offset++
+	}
+	cut := line.Start + offset
+	line.Start = cut
+	para.Lines().Set(0, line)
+
+	for child := para.FirstChild(); child != nil; {
+		next := child.NextSibling()
+		textNode, ok := child.(*ast.Text)
+		if !ok || textNode.Segment.Start >= cut {
+			break
+		}
+		if textNode.Segment.Stop <= cut {
+			// Узел целиком внутри маркера — убираем вместе с переводом строки
+			para.RemoveChild(para, child)
+		} else {
+			textNode.Segment = textNode.Segment.WithStart(cut)
+			break
+		}
+		child = next
+	}
+}
+
+// headingIDTransformer проставляет заголовкам якоря-id на основе
+// транслитерации текста — теми же правилами, что slug'и уроков при
+// импорте. Повторяющиеся якоря получают числовой суффикс.
+type headingIDTransformer struct{}
+
+func (headingIDTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	seen := map[string]int{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		heading, ok := n.(*ast.Heading)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		id := headingID(string(heading.Text(source)))
+		if count := seen[id]; count > 0 {
+			seen[id] = count + 1
+			id = fmt.Sprintf("%s-%d", id, count)
+		} else {
+			seen[id] = 1
+		}
+		heading.SetAttributeString("id", []byte(id))
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// headingID преобразует текст заголовка в якорь.
+func headingID(s string) string {
+	// Транслитерация русских букв
+	translit := map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+		'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+		'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+		'ф': "f", 'х': "h", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "",
+		'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+		'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "Yo",
+		'Ж': "Zh", 'З': "Z", 'И': "I", 'Й': "Y", 'К': "K", 'Л': "L", 'М': "M",
+		'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U",
+		'Ф': "F", 'Х': "H", 'Ц': "Ts", 'Ч': "Ch", 'Ш': "Sh", 'Щ': "Sch", 'Ъ': "",
+		'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya",
+	}
+
+	var result strings.Builder
+	for _, r := range s {
+		if tr, ok := translit[r]; ok {
+			result.WriteString(tr)
+		} else if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			result.WriteRune(r)
+		} else if r == ' ' || r == '-' || r == '_' {
+			result.WriteRune('-')
+		}
+	}
+
+	id := strings.ToLower(result.String())
+	id = dashesRE.ReplaceAllString(id, "-")
+	id = strings.Trim(id, "-")
+	if id == "" {
+		id = "section"
+	}
+	return id
+}
+
+var dashesRE = regexp.MustCompile(`-+`)
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// stdlibDocBase — базовый URL документации стандартной библиотеки.
+// Меняется один раз на старте сервера (--godoc-url), поэтому без мьютекса.
+var stdlibDocBase = "https://pkg.go.dev"
+
+// SetStdlibDocBase переключает ссылки на документацию, например на
+// офлайн-зеркало godoc в закрытой сети.
+func SetStdlibDocBase(base string) {
+	if base = strings.TrimRight(strings.TrimSpace(base), "/"); base != "" {
+		stdlibDocBase = base
+	}
+}
+
+// stdlibImportPaths — пакеты stdlib, на которые ссылаются уроки.
+// Последний элемент пути служит ключом поиска: `http.Get` → net/http.
+// Неоднозначные имена (rand) разрешены в пользу пакета, чаще
+// встречающегося в учебном курсе.
+var stdlibImportPaths = []string{
+	"bufio", "bytes", "cmp", "context", "embed", "errors", "flag", "fmt",
+	"io", "log", "maps", "math", "net", "os", "path", "reflect", "regexp",
+	"runtime", "slices", "sort", "strconv", "strings", "sync", "testing",
+	"time", "unicode",
+	"container/heap", "container/list",
+	"crypto/md5", "crypto/sha256", "crypto/tls",
+	"database/sql",
+	"encoding/base64", "encoding/csv", "encoding/hex", "encoding/json", "encoding/xml",
+	"html/template",
+	"io/fs",
+	"math/big", "math/rand",
+	"net/http", "net/url",
+	"os/exec", "os/signal",
+	"path/filepath",
+	"sync/atomic",
+	"unicode/utf8",
+}
+
+var (
+	stdlibIndexOnce sync.Once
+	stdlibIndex     map[string]string
+)
+
+// lookupStdlibPackage возвращает полный путь импорта по имени пакета.
+// Таблица символов строится один раз при первом рендере и кешируется.
+func lookupStdlibPackage(name string) (string, bool) {
+	stdlibIndexOnce.Do(func() {
+		stdlibIndex = make(map[string]string, len(stdlibImportPaths))
+		for _, path := range stdlibImportPaths {
+			key := path
+			if i := strings.LastIndex(path, "/"); i >= 0 {
+				key = path[i+1:]
+			}
+			// Первый пакет в списке выигрывает (rand → math/rand)
+			if _, ok := stdlibIndex[key]; !ok {
+				stdlibIndex[key] = path
+			}
+		}
+	})
+	path, ok := stdlibIndex[name]
+	return path, ok
+}
+
+// stdlibRefRE — ссылка на идентификатор stdlib во встроенном коде:
+// `fmt.Println`, `sync.WaitGroup` или `sync.WaitGroup.Wait`.
+var stdlibRefRE = regexp.MustCompile(`^([a-z][a-z0-9]*)\.([A-Z][A-Za-z0-9_]*(?:\.[A-Z][A-Za-z0-9_]*)?)$`)
+
+// stdlibLinkTransformer оборачивает встроенные фрагменты кода вида
+// `fmt.Println` ссылками на документацию пакета. Уже расставленные
+// вручную ссылки не трогаются.
+type stdlibLinkTransformer struct{}
+
+func (stdlibLinkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	// Узлы собираются до изменений: перестройка дерева во время обхода
+	// ломает порядок Walk
+	type match struct {
+		span *ast.CodeSpan
+		dest string
+	}
+	var matches []match
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		span, ok := n.(*ast.CodeSpan)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		if _, inLink := span.Parent().(*ast.Link); inLink {
+			return ast.WalkSkipChildren, nil
+		}
+
+		ref := stdlibRefRE.FindStringSubmatch(string(span.Text(source)))
+		if ref == nil {
+			return ast.WalkSkipChildren, nil
+		}
+		path, ok := lookupStdlibPackage(ref[1])
+		if !ok {
+			return ast.WalkSkipChildren, nil
+		}
+
+		matches = append(matches, match{
+			span: span,
+			dest: stdlibDocBase + "/" + path + "#" + ref[2],
+		})
+		return ast.WalkSkipChildren, nil
+	})
+
+	for _, m := range matches {
+		parent := m.span.Parent()
+		link := ast.NewLink()
+		link.Destination = []byte(m.dest)
+		link.SetAttributeString("class", []byte("stdlib-link"))
+		parent.ReplaceChild(parent, m.span, link)
+		link.AppendChild(link, m.span)
+	}
+}
@@ -0,0 +1,140 @@
+// Package runnerapi — gRPC-контракт сервиса выполнения кода (cmd/runnerd).
+// Сервис небольшой (два метода с потоком логов), поэтому вместо protoc
+// и генерации кода описание сервиса и сообщения написаны вручную, а
+// сериализация — JSON-кодек, зарегистрированный в gRPC. Клиент и сервер
+// обязаны использовать один content-subtype (см. CodecName).
+package runnerapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// ServiceName — полное имя gRPC-сервиса.
+const ServiceName = "golearning.runner.Runner"
+
+// CodecName — content-subtype JSON-кодека; клиент передаёт его в
+// grpc.CallContentSubtype.
+const CodecName = "json"
+
+// RunRequest — параметры запуска. Нулевые лимиты означают значения по
+// умолчанию на стороне runnerd.
+type RunRequest struct {
+	Code       string `json:"code"`
+	TestsGo    string `json:"tests_go,omitempty"` // только для Check
+	TimeoutSec int    `json:"timeout_sec,omitempty"`
+	MemoryMB   int    `json:"memory_mb,omitempty"`
+}
+
+// Result — итог выполнения. Зеркало practice.RunResult: прямой импорт
+// создал бы цикл practice → runnerapi → practice.
+type Result struct {
+	Success bool   `json:"success"`
+	Stdout  string `json:"stdout"`
+	Stderr  string `json:"stderr"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Event — элемент потока ответа: либо порция лога по мере выполнения,
+// либо финальный результат (последнее сообщение потока).
+type Event struct {
+	Log    string  `json:"log,omitempty"`
+	Result *Result `json:"result,omitempty"`
+}
+
+// Codec — JSON-сериализация gRPC-сообщений.
+type Codec struct{}
+
+// Marshal кодирует сообщение в JSON.
+func (Codec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal декодирует сообщение из JSON.
+func (Codec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// Name возвращает имя кодека для content-subtype.
+func (Codec) Name() string { return CodecName }
+
+func init() {
+	encoding.RegisterCodec(Codec{})
+}
+
+// RunnerServer — серверная сторона сервиса: оба метода принимают один
+// запрос и стримят события до финального результата.
+type RunnerServer interface {
+	Run(req *RunRequest, stream grpc.ServerStreamingServer[Event]) error
+	Check(req *RunRequest, stream grpc.ServerStreamingServer[Event]) error
+}
+
+// RegisterRunnerServer регистрирует реализацию сервиса на gRPC-сервере.
+func RegisterRunnerServer(s *grpc.Server, srv RunnerServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*RunnerServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Run", Handler: runHandler, ServerStreams: true},
+		{StreamName: "Check", Handler: checkHandler, ServerStreams: true},
+	},
+	Metadata: "runnerapi (без .proto: JSON-кодек)",
+}
+
+func runHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(RunRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(RunnerServer).Run(req, &grpc.GenericServerStream[RunRequest, Event]{ServerStream: stream})
+}
+
+func checkHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(RunRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(RunnerServer).Check(req, &grpc.GenericServerStream[RunRequest, Event]{ServerStream: stream})
+}
+
+// RunnerClient — клиентская сторона сервиса.
+type RunnerClient interface {
+	Run(ctx context.Context, req *RunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	Check(ctx context.Context, req *RunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+// NewRunnerClient создаёт клиент сервиса поверх соединения.
+func NewRunnerClient(cc grpc.ClientConnInterface) RunnerClient {
+	return &runnerClient{cc: cc}
+}
+
+type runnerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *runnerClient) Run(ctx context.Context, req *RunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	return c.stream(ctx, 0, "Run", req, opts)
+}
+
+func (c *runnerClient) Check(ctx context.Context, req *RunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	return c.stream(ctx, 1, "Check", req, opts)
+}
+
+func (c *runnerClient) stream(ctx context.Context, idx int, method string, req *RunRequest, opts []grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(CodecName)}, opts...)
+	stream, err := c.cc.NewStream(ctx, &serviceDesc.Streams[idx], fmt.Sprintf("/%s/%s", ServiceName, method), opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RunRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
@@ -0,0 +1,175 @@
+// Package sanitize чистит HTML перед показом пользователю. Уроки
+// импортируются с внешних сайтов, а заметки пишет пользователь — и то и
+// другое рендерится с разрешённым HTML (goldmark WithUnsafe), поэтому
+// скрипты, опасные атрибуты и трекинг-пиксели вырезаются здесь.
+package sanitize
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// bannedTags — элементы, которые удаляются целиком вместе с содержимым.
+var bannedTags = map[string]bool{
+	"script": true,
+	"style":  true,
+	"iframe": true,
+	"object": true,
+	"embed":  true,
+	"form":   true,
+	"link":   true,
+	"meta":   true,
+	"base":   true,
+}
+
+// HTML возвращает очищенный HTML: без запрещённых элементов,
+// обработчиков событий, javascript:-ссылок и трекинг-пикселей.
+// При ошибке разбора возвращается пустая строка — лучше не показать
+// ничего, чем показать неочищенное.
+func HTML(input string) string {
+	doc, err := html.Parse(strings.NewReader(input))
+	if err != nil {
+		return ""
+	}
+
+	body := findBody(doc)
+	if body == nil {
+		return ""
+	}
+	cleanNode(body)
+
+	var buf bytes.Buffer
+	for child := body.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(&buf, child); err != nil {
+			return ""
+		}
+	}
+	return buf.String()
+}
+
+// findBody находит элемент body в разобранном документе.
+func findBody(n *html.Node) *html.Node {
+	if n.Type == html.ElementNode && n.Data == "body" {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if body := findBody(child); body != nil {
+			return body
+		}
+	}
+	return nil
+}
+
+// cleanNode рекурсивно вырезает опасные элементы и атрибуты.
+func cleanNode(n *html.Node) {
+	var next *html.Node
+	for child := n.FirstChild; child != nil; child = next {
+		next = child.NextSibling
+		if child.Type == html.ElementNode && shouldRemove(child) {
+			n.RemoveChild(child)
+			continue
+		}
+		if child.Type == html.ElementNode {
+			child.Attr = cleanAttrs(child.Attr)
+		}
+		cleanNode(child)
+	}
+}
+
+// shouldRemove сообщает, нужно ли удалить элемент целиком.
+func shouldRemove(n *html.Node) bool {
+	if bannedTags[n.Data] {
+		return true
+	}
+	if n.Data == "img" && isTrackingPixel(n) {
+		return true
+	}
+	return false
+}
+
+// isTrackingPixel распознаёт однопиксельные картинки-счётчики.
+func isTrackingPixel(n *html.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "width" || attr.Key == "height" {
+			if attr.Val == "0" || attr.Val == "1" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cleanAttrs убирает обработчики событий и ссылки с небезопасными
+// схемами.
+func cleanAttrs(attrs []html.Attribute) []html.Attribute {
+	cleaned := attrs[:0]
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if strings.HasPrefix(key, "on") || key == "srcdoc" {
+			continue
+		}
+		if (key == "href" || key == "src" || key == "action") && !safeURL(attr.Val) {
+			continue
+		}
+		cleaned = append(cleaned, attr)
+	}
+	return cleaned
+}
+
+// safeSchemes — разрешённые схемы ссылок; всё остальное вырезается.
+// data:image/ оставлен для картинок, встроенных в импортированные уроки.
+var safeSchemes = []string{"http:", "https:", "mailto:", "data:image/"}
+
+// safeURL пропускает относительные ссылки и схемы из safeSchemes.
+// Денилист по префиксу ненадёжен: парсер HTML декодирует сущности вида
+// jav&#x09;ascript:, а браузеры игнорируют табы и переводы строк внутри
+// URL — поэтому перед проверкой управляющие символы удаляются, а схема
+// сверяется со списком разрешённых.
+func safeURL(raw string) bool {
+	var b strings.Builder
+	for _, r := range raw {
+		if r > 0x20 && r != 0x7f {
+			b.WriteRune(r)
+		}
+	}
+	value := strings.ToLower(b.String())
+
+	colon := strings.IndexByte(value, ':')
+	if colon == -1 {
+		return true // относительная ссылка
+	}
+	// Двоеточие после разделителя пути — не схема (например "/a:b")
+	if sep := strings.IndexAny(value, "/?#"); sep != -1 && sep < colon {
+		return true
+	}
+	for _, scheme := range safeSchemes {
+		if strings.HasPrefix(value, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// Регулярные выражения для чистки сырого Markdown: текст ещё не HTML,
+// полноценный разбор невозможен, поэтому вырезаются только явно
+// опасные вкрапления.
+var (
+	scriptBlockRE = regexp.MustCompile(`(?is)<script.*?</script>|<script[^>]*/?>`)
+	iframeBlockRE = regexp.MustCompile(`(?is)<iframe.*?</iframe>|<iframe[^>]*/?>`)
+	eventAttrRE   = regexp.MustCompile(`(?i)\son[a-z]+\s*=\s*("[^"]*"|'[^']*'|[^\s>]+)`)
+	jsLinkRE      = regexp.MustCompile(`(?i)(href|src)\s*=\s*("\s*javascript:[^"]*"|'\s*javascript:[^']*')`)
+)
+
+// Markdown чистит пользовательский Markdown при сохранении: удаляет
+// script/iframe и inline-обработчики, не трогая остальной текст.
+// Финальная защита — HTML() на этапе рендеринга.
+func Markdown(input string) string {
+	out := scriptBlockRE.ReplaceAllString(input, "")
+	out = iframeBlockRE.ReplaceAllString(out, "")
+	out = eventAttrRE.ReplaceAllString(out, "")
+	out = jsLinkRE.ReplaceAllString(out, "")
+	return out
+}
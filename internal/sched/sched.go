@@ -0,0 +1,161 @@
+// Package sched — встроенный планировщик фоновых задач. Задачи
+// регистрируются при старте сервера и выполняются по интервалу;
+// история запусков пишется в таблицу job_runs, повторный запуск
+// работающей задачи пропускается. Задачи с нулевым интервалом
+// выполняются только вручную со страницы /admin/jobs.
+package sched
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Статусы запуска задачи в job_runs.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// JobFunc — тело задачи. Возвращает краткое описание результата
+// для истории запусков.
+type JobFunc func(ctx context.Context) (string, error)
+
+// Job — зарегистрированная задача планировщика.
+type Job struct {
+	Name        string
+	Description string
+	Every       time.Duration // 0 — только ручной запуск
+
+	fn      JobFunc
+	running int32 // защита от наложения запусков
+}
+
+// Run — запись истории запусков.
+type Run struct {
+	ID         int64
+	Job        string
+	Status     string
+	Summary    string
+	StartedAt  time.Time
+	DurationMs int64
+}
+
+// Scheduler выполняет зарегистрированные задачи по расписанию.
+type Scheduler struct {
+	db   *sql.DB
+	jobs []*Job
+}
+
+// NewScheduler создаёт планировщик поверх общей БД.
+func NewScheduler(db *sql.DB) *Scheduler {
+	return &Scheduler{db: db}
+}
+
+// Register добавляет задачу. Вызывается до Start.
+func (s *Scheduler) Register(name, description string, every time.Duration, fn JobFunc) {
+	s.jobs = append(s.jobs, &Job{
+		Name:        name,
+		Description: description,
+		Every:       every,
+		fn:          fn,
+	})
+}
+
+// Start запускает тикеры всех задач с интервалом. Останавливается
+// по отмене контекста.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		if job.Every <= 0 {
+			continue
+		}
+		go func(j *Job) {
+			ticker := time.NewTicker(j.Every)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					s.run(ctx, j)
+				}
+			}
+		}(job)
+	}
+}
+
+// RunNow запускает задачу по имени вне расписания.
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			s.run(ctx, job)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown job: %s", name)
+}
+
+// Jobs возвращает зарегистрированные задачи в порядке регистрации.
+func (s *Scheduler) Jobs() []*Job {
+	return s.jobs
+}
+
+// run выполняет задачу и записывает результат в историю.
+// Если задача ещё работает, новый запуск пропускается.
+func (s *Scheduler) run(ctx context.Context, j *Job) {
+	if !atomic.CompareAndSwapInt32(&j.running, 0, 1) {
+		log.Printf("Задача %s ещё выполняется, запуск пропущен", j.Name)
+		return
+	}
+	defer atomic.StoreInt32(&j.running, 0)
+
+	started := time.Now()
+	summary, err := j.fn(ctx)
+
+	status := StatusOK
+	if err != nil {
+		status = StatusError
+		summary = err.Error()
+		log.Printf("Задача %s завершилась ошибкой: %v", j.Name, err)
+	}
+
+	_, dbErr := s.db.ExecContext(ctx,
+		`INSERT INTO job_runs (job, status, summary, started_at, duration_ms)
+		 VALUES (?, ?, ?, ?, ?)`,
+		j.Name, status, summary, started.UTC(), time.Since(started).Milliseconds(),
+	)
+	if dbErr != nil {
+		log.Printf("Ошибка записи истории задачи %s: %v", j.Name, dbErr)
+	}
+}
+
+// History возвращает последние запуски, свежие первыми.
+func (s *Scheduler) History(ctx context.Context, limit int) ([]Run, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, job, status, summary, started_at, duration_ms
+		 FROM job_runs
+		 ORDER BY started_at DESC, id DESC
+		 LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("job history: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var r Run
+		if err := rows.Scan(&r.ID, &r.Job, &r.Status, &r.Summary, &r.StartedAt, &r.DurationMs); err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,196 @@
+// Package selfupdate — проверка новых версий и самообновление бинарника.
+// Источник — настраиваемый JSON-манифест релиза (--release-url): сервер
+// периодически сверяет его с текущей версией и показывает баннер в
+// админке, а CLI по команде self-update скачивает бинарник и заменяет
+// себя после проверки контрольной суммы.
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golearning/internal/version"
+)
+
+// Asset — бинарник релиза для одной платформы. Контрольная сумма —
+// hex-строка SHA-256 содержимого по URL.
+type Asset struct {
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// Release — манифест релиза с конечной точки --release-url.
+type Release struct {
+	Version string  `json:"version"`
+	Date    string  `json:"date"`
+	NotesMD string  `json:"notes"`
+	Assets  []Asset `json:"assets"`
+}
+
+// AssetFor возвращает бинарник релиза для платформы или nil.
+func (r *Release) AssetFor(goos, goarch string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].OS == goos && r.Assets[i].Arch == goarch {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Checker периодически опрашивает конечную точку релизов и помнит
+// последний релиз новее текущей версии. Безопасен для конкурентного
+// доступа: Check вызывает планировщик, Latest — обработчики страниц.
+type Checker struct {
+	endpoint string
+	client   *http.Client
+
+	mu     sync.RWMutex
+	latest *Release
+}
+
+// NewChecker создаёт проверку обновлений для конечной точки релизов.
+func NewChecker(endpoint string) *Checker {
+	return &Checker{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Check запрашивает манифест и обновляет запомненный релиз. Возвращает
+// сводку для истории задач планировщика; сетевые ошибки не критичны —
+// баннер просто остаётся в прежнем состоянии до следующей проверки.
+func (c *Checker) Check(ctx context.Context) (string, error) {
+	release, err := fetch(ctx, c.client, c.endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	if IsNewer(release.Version, version.Version) {
+		c.latest = release
+	} else {
+		c.latest = nil
+	}
+	c.mu.Unlock()
+
+	if !IsNewer(release.Version, version.Version) {
+		return fmt.Sprintf("Версия %s актуальна", version.Version), nil
+	}
+	return fmt.Sprintf("Доступна версия %s (текущая %s)", release.Version, version.Version), nil
+}
+
+// Latest возвращает последний известный релиз новее текущей версии
+// или nil, если обновлений нет (или проверка ещё не выполнялась).
+func (c *Checker) Latest() *Release {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.latest
+}
+
+// IsNewer сообщает, новее ли версия a версии b. Версии сравниваются
+// почленно как числа ("1.10.0" новее "1.9.2"); нечисловой сегмент
+// делает сравнение лексикографическим для этого сегмента.
+func IsNewer(a, b string) bool {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var sa, sb string
+		if i < len(as) {
+			sa = as[i]
+		}
+		if i < len(bs) {
+			sb = bs[i]
+		}
+		na, errA := strconv.Atoi(sa)
+		nb, errB := strconv.Atoi(sb)
+		if errA == nil && errB == nil {
+			if na != nb {
+				return na > nb
+			}
+			continue
+		}
+		if sa != sb {
+			return sa > sb
+		}
+	}
+	return false
+}
+
+// fetch загружает и разбирает манифест релиза.
+func fetch(ctx context.Context, client *http.Client, endpoint string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("запрос манифеста: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("загрузка манифеста: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("манифест релиза: HTTP %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&release); err != nil {
+		return nil, fmt.Errorf("разбор манифеста: %w", err)
+	}
+	if release.Version == "" {
+		return nil, fmt.Errorf("манифест релиза без версии")
+	}
+	return &release, nil
+}
+
+// Fetch загружает манифест релиза с конечной точки (для CLI).
+func Fetch(ctx context.Context, endpoint string) (*Release, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return fetch(ctx, client, endpoint)
+}
+
+// Download скачивает бинарник релиза и сверяет контрольную сумму.
+// Возвращает содержимое только при совпадении SHA-256 — повреждённый
+// или подменённый файл не попадёт на диск.
+func Download(ctx context.Context, asset *Asset) ([]byte, error) {
+	client := &http.Client{Timeout: 5 * time.Minute}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("запрос бинарника: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("загрузка бинарника: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("загрузка бинарника: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("чтение бинарника: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	want := strings.ToLower(strings.TrimSpace(asset.SHA256))
+	if want == "" {
+		return nil, fmt.Errorf("в манифесте нет контрольной суммы")
+	}
+	if got != want {
+		return nil, fmt.Errorf("контрольная сумма не совпала: ожидалась %s, получена %s", want, got)
+	}
+	return data, nil
+}
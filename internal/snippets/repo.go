@@ -0,0 +1,101 @@
+package snippets
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Snippet — сохранённый фрагмент кода из личной библиотеки.
+type Snippet struct {
+	ID        int64
+	Title     string
+	Code      string
+	Tags      string
+	LessonID  int64 // 0, если фрагмент не привязан к уроку
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Repository — репозиторий для работы с фрагментами кода.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создаёт новый репозиторий.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет новый фрагмент.
+func (r *Repository) Create(s *Snippet) error {
+	var lessonID interface{}
+	if s.LessonID > 0 {
+		lessonID = s.LessonID
+	}
+
+	result, err := r.db.Exec(
+		`INSERT INTO snippets (title, code, tags, lesson_id) VALUES (?, ?, ?, ?)`,
+		s.Title, s.Code, s.Tags, lessonID,
+	)
+	if err != nil {
+		return fmt.Errorf("insert snippet: %w", err)
+	}
+
+	s.ID, _ = result.LastInsertId()
+	return nil
+}
+
+// List возвращает фрагменты, опционально отфильтрованные по запросу
+// (поиск по заголовку, тегам и тексту кода).
+func (r *Repository) List(query string) ([]Snippet, error) {
+	q := `SELECT id, title, code, tags, COALESCE(lesson_id, 0), created_at, updated_at
+	      FROM snippets`
+	args := []interface{}{}
+	if query != "" {
+		q += ` WHERE title LIKE ? OR tags LIKE ? OR code LIKE ?`
+		pattern := "%" + query + "%"
+		args = append(args, pattern, pattern, pattern)
+	}
+	q += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list snippets: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Snippet
+	for rows.Next() {
+		var s Snippet
+		if err := rows.Scan(&s.ID, &s.Title, &s.Code, &s.Tags, &s.LessonID, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan snippet: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+// Get возвращает фрагмент по ID.
+func (r *Repository) Get(id int64) (*Snippet, error) {
+	s := &Snippet{}
+	err := r.db.QueryRow(
+		`SELECT id, title, code, tags, COALESCE(lesson_id, 0), created_at, updated_at
+		 FROM snippets WHERE id = ?`,
+		id,
+	).Scan(&s.ID, &s.Title, &s.Code, &s.Tags, &s.LessonID, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get snippet: %w", err)
+	}
+	return s, nil
+}
+
+// Delete удаляет фрагмент.
+func (r *Repository) Delete(id int64) error {
+	_, err := r.db.Exec(`DELETE FROM snippets WHERE id = ?`, id)
+	return err
+}
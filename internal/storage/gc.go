@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CollectGarbage удаляет осиротевшие артефакты отправок: объекты
+// submissions/<id>/... без соответствующей записи в submissions.
+// Возвращает число удалённых объектов.
+func CollectGarbage(ctx context.Context, st Store, db *sql.DB) (int, error) {
+	keys, err := st.List(ctx, "submissions/")
+	if err != nil {
+		return 0, err
+	}
+
+	// Кэшируем вердикт по id отправки: у одной отправки может быть
+	// несколько объектов
+	exists := map[int64]bool{}
+	removed := 0
+	for _, key := range keys {
+		parts := strings.Split(key, "/")
+		if len(parts) < 3 {
+			continue
+		}
+		id, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		alive, seen := exists[id]
+		if !seen {
+			var one int
+			err := db.QueryRowContext(ctx, `SELECT 1 FROM submissions WHERE id = ?`, id).Scan(&one)
+			if err != nil && err != sql.ErrNoRows {
+				return removed, fmt.Errorf("check submission %d: %w", id, err)
+			}
+			alive = err == nil
+			exists[id] = alive
+		}
+		if alive {
+			continue
+		}
+
+		if err := st.Delete(ctx, key); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,43 @@
+// Package storage — хранилище крупных артефактов: полные логи решений,
+// экспортированные бандлы, сгенерированные документы. Такие объекты не
+// кладутся в SQLite; реализация выбирается в файле конфигурации —
+// локальный диск или S3-совместимое хранилище.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"golearning/internal/config"
+)
+
+// Store — интерфейс хранилища артефактов. Ключ — путь вида
+// "submissions/42/output.log" с разделителем «/» независимо от ОС.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// New создаёт хранилище по конфигурации.
+func New(cfg config.Storage) (Store, error) {
+	switch cfg.Kind {
+	case "", "local":
+		return NewLocal(cfg.Dir)
+	case "s3":
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("неизвестный тип хранилища: %s (ожидается local или s3)", cfg.Kind)
+	}
+}
+
+// validKey отсекает ключи, пытающиеся выйти за пределы хранилища.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "..") {
+		return fmt.Errorf("invalid artifact key: %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package testsupport поднимает полный стек приложения в памяти:
+// временная БД с миграциями, демо-данные, HTTP-сервер на httptest и
+// заглушка runner'а. Нужен для сквозных тестов (просмотр уроков,
+// отправка решений, прогресс) без внешних зависимостей и go toolchain.
+package testsupport
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"golearning/internal/content"
+	"golearning/internal/db"
+	"golearning/internal/ingest"
+	"golearning/internal/practice"
+	"golearning/internal/progress"
+	"golearning/internal/web"
+)
+
+// Env — работающее тестовое окружение. Закрывать через Close.
+type Env struct {
+	// DB — открытое соединение с временной SQLite-базой.
+	DB *sql.DB
+	// ContentRepo и ProgressRepo — SQL-репозитории поверх DB.
+	ContentRepo  *content.Repository
+	ProgressRepo *progress.Repository
+	// Checker — проверяльщик решений поверх Runner.
+	Checker *practice.Checker
+	// Runner — заглушка выполнения кода; результат настраивается в тесте.
+	Runner *StubRunner
+	// Server — httptest-сервер с полным роутером приложения.
+	Server *httptest.Server
+
+	tempDir string
+}
+
+// StubRunner — заглушка practice.Runner: не запускает код, а возвращает
+// заранее заданный результат (по умолчанию — успех с пустым выводом).
+type StubRunner struct {
+	// Result возвращается из Run и Check, если задан.
+	Result *practice.RunResult
+	// Err возвращается вместо результата, если задан.
+	Err error
+}
+
+// Run возвращает настроенный результат.
+func (r *StubRunner) Run(ctx context.Context, code string, limits practice.RunLimits) (*practice.RunResult, error) {
+	return r.result()
+}
+
+// Check возвращает настроенный результат.
+func (r *StubRunner) Check(ctx context.Context, code string, testsGo string, limits practice.RunLimits) (*practice.RunResult, error) {
+	return r.result()
+}
+
+func (r *StubRunner) result() (*practice.RunResult, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if r.Result != nil {
+		out := *r.Result
+		return &out, nil
+	}
+	return &practice.RunResult{Success: true}, nil
+}
+
+// Options — настройки тестового окружения.
+type Options struct {
+	// SeedPack — имя демо-пака для наполнения БД ("" — без данных).
+	SeedPack string
+	// Web — настройки HTTP-сервера (сжатие, base path и т.д.).
+	Web web.Options
+}
+
+// Start поднимает окружение с демо-паком basics.
+func Start() (*Env, error) {
+	return StartWith(Options{SeedPack: "basics"})
+}
+
+// StartWith поднимает окружение с заданными настройками.
+func StartWith(opts Options) (*Env, error) {
+	tempDir, err := os.MkdirTemp("", "golearn-test-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+
+	env := &Env{tempDir: tempDir}
+	if err := env.start(opts); err != nil {
+		env.Close()
+		return nil, err
+	}
+	return env, nil
+}
+
+func (e *Env) start(opts Options) error {
+	database, err := db.Open(filepath.Join(e.tempDir, "test.db"))
+	if err != nil {
+		return fmt.Errorf("open db: %w", err)
+	}
+	e.DB = database
+
+	if err := db.Migrate(database); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	e.ContentRepo = content.NewRepository(database)
+	e.ProgressRepo = progress.NewRepository(database)
+
+	if opts.SeedPack != "" {
+		seeder := ingest.NewSeeder(e.ContentRepo)
+		if err := seeder.Seed(context.Background(), opts.SeedPack); err != nil {
+			return fmt.Errorf("seed %s: %w", opts.SeedPack, err)
+		}
+	}
+
+	e.Runner = &StubRunner{}
+	e.Checker = practice.NewChecker(e.Runner, e.ContentRepo, e.ProgressRepo)
+
+	server, err := web.NewServer(e.ContentRepo, e.ProgressRepo, e.Checker, opts.Web)
+	if err != nil {
+		return fmt.Errorf("create server: %w", err)
+	}
+	if version, err := db.SchemaVersion(database); err == nil {
+		server.SetSchemaVersion(version)
+	}
+
+	e.Server = httptest.NewServer(server.Router())
+	return nil
+}
+
+// URL возвращает абсолютный адрес пути на тестовом сервере.
+func (e *Env) URL(path string) string {
+	return e.Server.URL + path
+}
+
+// Close останавливает сервер и удаляет временную БД.
+func (e *Env) Close() {
+	if e.Server != nil {
+		e.Server.Close()
+	}
+	if e.DB != nil {
+		_ = e.DB.Close()
+	}
+	if e.tempDir != "" {
+		_ = os.RemoveAll(e.tempDir)
+	}
+}
@@ -0,0 +1,185 @@
+package testsupport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golearning/internal/content"
+	"golearning/internal/practice"
+	"golearning/internal/progress"
+	"golearning/internal/testsupport"
+)
+
+// helloCode — синтаксически корректное решение первого задания
+// демо-пака basics; результат выполнения задаёт StubRunner.
+const helloCode = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Hello, Go!")
+}
+`
+
+// startEnv поднимает окружение с демо-паком basics и закрывает его по
+// завершении теста.
+func startEnv(t *testing.T) *testsupport.Env {
+	t.Helper()
+	env, err := testsupport.Start()
+	if err != nil {
+		t.Fatalf("start env: %v", err)
+	}
+	t.Cleanup(env.Close)
+	return env
+}
+
+// firstTask возвращает первое задание урока vvedenie из демо-пака.
+func firstTask(t *testing.T, env *testsupport.Env) *content.Task {
+	t.Helper()
+	ctx := context.Background()
+
+	lesson, err := env.ContentRepo.GetLessonBySlug(ctx, "vvedenie")
+	if err != nil || lesson == nil {
+		t.Fatalf("get lesson vvedenie: %v (%v)", lesson, err)
+	}
+	tasks, err := env.ContentRepo.GetTasksByLessonID(ctx, lesson.ID)
+	if err != nil {
+		t.Fatalf("get tasks: %v", err)
+	}
+	if len(tasks) == 0 {
+		t.Fatal("в уроке vvedenie нет заданий")
+	}
+	return &tasks[0]
+}
+
+// postJSON отправляет JSON-запрос и декодирует JSON-ответ в out.
+func postJSON(t *testing.T, url string, body interface{}, out interface{}) {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("post %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		t.Fatalf("post %s: статус %d: %s", url, resp.StatusCode, raw)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+}
+
+func TestLessonPageServed(t *testing.T) {
+	env := startEnv(t)
+
+	resp, err := http.Get(env.URL("/lessons/vvedenie"))
+	if err != nil {
+		t.Fatalf("get lesson page: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("статус %d, ожидался 200", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if !strings.Contains(string(body), "Введение в Go") {
+		t.Error("на странице урока нет заголовка «Введение в Go»")
+	}
+}
+
+func TestCheckRecordsSubmissionAndPoints(t *testing.T) {
+	env := startEnv(t)
+	task := firstTask(t, env)
+	ctx := context.Background()
+
+	var result practice.CheckResult
+	postJSON(t, env.URL("/api/check"), map[string]interface{}{
+		"task_id": task.ID,
+		"code":    helloCode,
+	}, &result)
+
+	if !result.Success {
+		t.Fatalf("ожидался успех, получено: %+v", result)
+	}
+	if result.PointsAwarded != task.Points {
+		t.Errorf("PointsAwarded = %d, ожидалось %d", result.PointsAwarded, task.Points)
+	}
+
+	sub, err := env.ProgressRepo.GetSubmissionByID(ctx, result.SubmissionID)
+	if err != nil || sub == nil {
+		t.Fatalf("get submission: %v (%v)", sub, err)
+	}
+	if sub.Status != "success" {
+		t.Errorf("статус отправки = %q, ожидался success", sub.Status)
+	}
+
+	stats, err := env.ProgressRepo.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("get stats: %v", err)
+	}
+	if stats.EarnedPoints != task.Points {
+		t.Errorf("EarnedPoints = %d, ожидалось %d", stats.EarnedPoints, task.Points)
+	}
+}
+
+func TestCheckRunnerFailure(t *testing.T) {
+	env := startEnv(t)
+	task := firstTask(t, env)
+	env.Runner.Result = &practice.RunResult{
+		Success: false,
+		Error:   "main.go:6:2: undefined: фмт",
+	}
+
+	var result practice.CheckResult
+	postJSON(t, env.URL("/api/check"), map[string]interface{}{
+		"task_id": task.ID,
+		"code":    helloCode,
+	}, &result)
+
+	if result.Success {
+		t.Fatalf("ожидался провал, получено: %+v", result)
+	}
+	sub, err := env.ProgressRepo.GetSubmissionByID(context.Background(), result.SubmissionID)
+	if err != nil || sub == nil {
+		t.Fatalf("get submission: %v (%v)", sub, err)
+	}
+	if sub.Status != "error" {
+		t.Errorf("статус отправки = %q, ожидался error", sub.Status)
+	}
+}
+
+func TestLessonProgressUpdated(t *testing.T) {
+	env := startEnv(t)
+	ctx := context.Background()
+
+	lesson, err := env.ContentRepo.GetLessonBySlug(ctx, "vvedenie")
+	if err != nil || lesson == nil {
+		t.Fatalf("get lesson vvedenie: %v (%v)", lesson, err)
+	}
+
+	var resp map[string]interface{}
+	postJSON(t, env.URL(fmt.Sprintf("/api/progress/lesson/%d", lesson.ID)), map[string]string{
+		"status": string(progress.StatusDone),
+	}, &resp)
+
+	p, err := env.ProgressRepo.GetProgress(ctx, lesson.ID)
+	if err != nil || p == nil {
+		t.Fatalf("get progress: %v (%v)", p, err)
+	}
+	if p.Status != progress.StatusDone {
+		t.Errorf("статус урока = %q, ожидался %q", p.Status, progress.StatusDone)
+	}
+}
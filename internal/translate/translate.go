@@ -0,0 +1,269 @@
+// Package translate — машинный перевод уроков между русским и
+// английским через настроенный LLM. Язык оригинала определяется
+// эвристикой по алфавиту, перевод хранится в таблице translations
+// по секциям; флаг reviewed отмечает вычитку человеком, вычитанные
+// переводы не перезаписываются.
+package translate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"golearning/internal/content"
+	"golearning/internal/llm"
+)
+
+// Поддерживаемые языки переводов.
+const (
+	LangRU = "ru"
+	LangEN = "en"
+)
+
+// Translation — перевод заголовка урока (SectionID = 0) или секции.
+type Translation struct {
+	ID        int64
+	LessonID  int64
+	SectionID int64
+	Lang      string
+	Title     string
+	BodyMD    string
+	Reviewed  bool
+	UpdatedAt time.Time
+}
+
+// ReviewItem — строка страницы вычитки переводов в админке.
+type ReviewItem struct {
+	LessonID    int64
+	LessonSlug  string
+	LessonTitle string
+	Lang        string
+	Parts       int // сколько частей урока переведено
+	Reviewed    int // сколько из них вычитано
+}
+
+// Service переводит уроки и хранит переводы.
+type Service struct {
+	db          *sql.DB
+	contentRepo content.Store
+	client      *llm.Client
+}
+
+// NewService создаёт сервис переводов.
+func NewService(db *sql.DB, contentRepo content.Store, client *llm.Client) *Service {
+	return &Service{db: db, contentRepo: contentRepo, client: client}
+}
+
+// DetectLang определяет язык текста по алфавиту: кириллицы больше —
+// русский, иначе английский.
+func DetectLang(text string) string {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.IsLetter(r) && r < 128:
+			latin++
+		}
+	}
+	if cyrillic > latin {
+		return LangRU
+	}
+	return LangEN
+}
+
+// otherLang возвращает язык перевода для языка оригинала.
+func otherLang(lang string) string {
+	if lang == LangRU {
+		return LangEN
+	}
+	return LangRU
+}
+
+// TranslateAll переводит уроки, у которых ещё нет перевода на второй
+// язык. Существующие переводы не трогаются — и машинные, и вычитанные.
+// Возвращает число переведённых частей (заголовков и секций).
+func (s *Service) TranslateAll(ctx context.Context) (int, error) {
+	lessons, err := s.contentRepo.ListAllLessons(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("чтение уроков: %w", err)
+	}
+
+	translated := 0
+	for _, l := range lessons {
+		target := otherLang(DetectLang(l.Title + " " + l.BodyMD))
+
+		done, err := s.translateLesson(ctx, &l, target)
+		if err != nil {
+			return translated, fmt.Errorf("перевод урока %s: %w", l.Slug, err)
+		}
+		translated += done
+	}
+	return translated, nil
+}
+
+// translateLesson переводит заголовок и секции одного урока на target.
+func (s *Service) translateLesson(ctx context.Context, lesson *content.Lesson, target string) (int, error) {
+	existing, err := s.ListByLesson(ctx, lesson.ID, target)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+
+	if _, ok := existing[0]; !ok {
+		title, err := s.translateText(ctx, target, lesson.Title)
+		if err != nil {
+			return done, err
+		}
+		if err := s.save(ctx, &Translation{LessonID: lesson.ID, Lang: target, Title: title}); err != nil {
+			return done, err
+		}
+		done++
+	}
+
+	sections, err := s.contentRepo.GetSectionsByLessonID(ctx, lesson.ID)
+	if err != nil {
+		return done, fmt.Errorf("секции: %w", err)
+	}
+	for _, sec := range sections {
+		// Шпаргалки перегенерируются из оригинала, переводить их незачем
+		if sec.Kind == content.SectionCheatsheet {
+			continue
+		}
+		if _, ok := existing[sec.ID]; ok {
+			continue
+		}
+
+		title, err := s.translateText(ctx, target, sec.Title)
+		if err != nil {
+			return done, err
+		}
+		body, err := s.translateText(ctx, target, sec.BodyMD)
+		if err != nil {
+			return done, err
+		}
+		t := &Translation{
+			LessonID:  lesson.ID,
+			SectionID: sec.ID,
+			Lang:      target,
+			Title:     title,
+			BodyMD:    body,
+		}
+		if err := s.save(ctx, t); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// translateText переводит один фрагмент Markdown.
+func (s *Service) translateText(ctx context.Context, target, text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+
+	language := "русский"
+	if target == LangEN {
+		language = "английский"
+	}
+	system := fmt.Sprintf("Переведи текст учебного курса по Go на %s язык. "+
+		"Сохрани разметку Markdown без изменений, блоки кода и идентификаторы не переводи. "+
+		"В ответе только перевод, без пояснений.", language)
+
+	out, err := s.client.Complete(ctx, system, text)
+	if err != nil {
+		return "", fmt.Errorf("перевод: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// save вставляет перевод; существующий вычитанный не перезаписывается.
+func (s *Service) save(ctx context.Context, t *Translation) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO translations (lesson_id, section_id, lang, title, body_md)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(lesson_id, section_id, lang) DO UPDATE SET
+		   title = excluded.title,
+		   body_md = excluded.body_md,
+		   updated_at = CURRENT_TIMESTAMP
+		 WHERE reviewed = 0`,
+		t.LessonID, t.SectionID, t.Lang, t.Title, t.BodyMD,
+	)
+	if err != nil {
+		return fmt.Errorf("сохранение перевода: %w", err)
+	}
+	return nil
+}
+
+// ListByLesson возвращает переводы урока на язык lang: ключ карты —
+// section_id, 0 — перевод заголовка урока.
+func (s *Service) ListByLesson(ctx context.Context, lessonID int64, lang string) (map[int64]Translation, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, lesson_id, section_id, lang, title, body_md, reviewed, updated_at
+		 FROM translations WHERE lesson_id = ? AND lang = ?`,
+		lessonID, lang,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("чтение переводов: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[int64]Translation)
+	for rows.Next() {
+		var t Translation
+		if err := rows.Scan(&t.ID, &t.LessonID, &t.SectionID, &t.Lang, &t.Title, &t.BodyMD, &t.Reviewed, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("чтение перевода: %w", err)
+		}
+		out[t.SectionID] = t
+	}
+	return out, rows.Err()
+}
+
+// ListForReview возвращает сводку переводов по урокам для админки.
+func (s *Service) ListForReview(ctx context.Context) ([]ReviewItem, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT t.lesson_id, l.slug, l.title, t.lang,
+		        COUNT(*), COALESCE(SUM(t.reviewed), 0)
+		 FROM translations t
+		 JOIN lessons l ON l.id = t.lesson_id AND l.deleted_at IS NULL
+		 GROUP BY t.lesson_id, t.lang
+		 ORDER BY l.title, t.lang`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("сводка переводов: %w", err)
+	}
+	defer rows.Close()
+
+	var items []ReviewItem
+	for rows.Next() {
+		var it ReviewItem
+		if err := rows.Scan(&it.LessonID, &it.LessonSlug, &it.LessonTitle, &it.Lang, &it.Parts, &it.Reviewed); err != nil {
+			return nil, fmt.Errorf("чтение сводки: %w", err)
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// SetReviewed отмечает все части перевода урока вычитанными (или
+// снимает отметку, возвращая их в пул машинных переводов).
+func (s *Service) SetReviewed(ctx context.Context, lessonID int64, lang string, reviewed bool) error {
+	value := 0
+	if reviewed {
+		value = 1
+	}
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE translations SET reviewed = ?, updated_at = CURRENT_TIMESTAMP
+		 WHERE lesson_id = ? AND lang = ?`,
+		value, lessonID, lang,
+	)
+	if err != nil {
+		return fmt.Errorf("отметка вычитки: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golearning/internal/config"
+)
+
+// maxAudioBytes — предел размера ответа синтеза: секция урока не
+// должна превращаться в часовую аудиокнигу.
+const maxAudioBytes = 32 << 20
+
+// HTTP — бэкенд синтеза через OpenAI-совместимый API (/audio/speech).
+// Подходит и для локальных TTS-серверов с тем же протоколом.
+type HTTP struct {
+	endpoint string
+	apiKey   string
+	model    string
+	voice    string
+	client   *http.Client
+}
+
+// NewHTTP создаёт HTTP-бэкенд синтеза из конфигурации.
+func NewHTTP(cfg config.TTS) *HTTP {
+	voice := cfg.Voice
+	if voice == "" {
+		voice = "alloy"
+	}
+	return &HTTP{
+		endpoint: strings.TrimRight(cfg.Endpoint, "/"),
+		apiKey:   cfg.APIKey,
+		model:    cfg.Model,
+		voice:    voice,
+		client:   &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// Synthesize озвучивает текст и возвращает MP3.
+func (h *HTTP) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	payload, err := json.Marshal(map[string]string{
+		"model":           h.model,
+		"voice":           h.voice,
+		"input":           text,
+		"response_format": "mp3",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("сборка запроса: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint+"/audio/speech", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("запрос синтеза: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("запрос синтеза: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("синтез речи: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	audio, err := io.ReadAll(io.LimitReader(resp.Body, maxAudioBytes))
+	if err != nil {
+		return nil, fmt.Errorf("чтение аудио: %w", err)
+	}
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("бэкенд синтеза вернул пустое аудио")
+	}
+	return audio, nil
+}
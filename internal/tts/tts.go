@@ -0,0 +1,112 @@
+// Package tts — озвучка уроков: синтез речи по секциям через сменный
+// бэкенд (интерфейс Synthesizer) с кэшем готового аудио в хранилище
+// артефактов. Ключ кэша включает хэш текста, так что после правки
+// секции аудио синтезируется заново, а старое остаётся мусором для
+// сборщика хранилища.
+package tts
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"golearning/internal/content"
+	"golearning/internal/storage"
+)
+
+// Synthesizer — бэкенд синтеза речи. Возвращает аудио в MP3.
+type Synthesizer interface {
+	Synthesize(ctx context.Context, text string) ([]byte, error)
+}
+
+// Service озвучивает секции уроков с кэшем в хранилище артефактов.
+type Service struct {
+	synth     Synthesizer
+	artifacts storage.Store
+}
+
+// NewService создаёт сервис озвучки.
+func NewService(synth Synthesizer, artifacts storage.Store) *Service {
+	return &Service{synth: synth, artifacts: artifacts}
+}
+
+// SectionAudio возвращает озвучку секции: из кэша или, если текст
+// изменился с прошлого синтеза, заново от бэкенда.
+func (s *Service) SectionAudio(ctx context.Context, sec *content.Section) (io.ReadCloser, error) {
+	text := speechText(sec)
+	if text == "" {
+		return nil, fmt.Errorf("в секции нет текста для озвучки")
+	}
+
+	key := audioKey(sec.ID, text)
+	if cached, err := s.artifacts.Get(ctx, key); err == nil {
+		return cached, nil
+	}
+
+	audio, err := s.synth.Synthesize(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("синтез речи: %w", err)
+	}
+
+	// Кэш — ускорение, а не условие: ошибка записи не мешает отдать аудио
+	_ = s.artifacts.Put(ctx, key, bytes.NewReader(audio))
+
+	return io.NopCloser(bytes.NewReader(audio)), nil
+}
+
+// audioKey — ключ аудио в хранилище артефактов.
+func audioKey(sectionID int64, text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return fmt.Sprintf("tts/%d-%s.mp3", sectionID, hex.EncodeToString(sum[:8]))
+}
+
+// speechText готовит текст секции к озвучке: заголовок плюс проза без
+// Markdown-разметки; блоки кода пропускаются — на слух они бесполезны.
+func speechText(sec *content.Section) string {
+	var out strings.Builder
+	out.WriteString(sec.Title + ".\n")
+
+	inCode := false
+	for _, line := range strings.Split(sec.BodyMD, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		out.WriteString(stripMarkdown(trimmed) + "\n")
+	}
+	return strings.TrimSpace(out.String())
+}
+
+// stripMarkdown убирает из строки разметку, мешающую синтезу.
+func stripMarkdown(line string) string {
+	line = strings.TrimLeft(line, "#>")
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
+		line = line[2:]
+	}
+	for _, mark := range []string{"**", "__", "`", "*"} {
+		line = strings.ReplaceAll(line, mark, "")
+	}
+	// Ссылки [текст](url) — остаётся только текст
+	for {
+		open := strings.Index(line, "[")
+		mid := strings.Index(line, "](")
+		if open < 0 || mid < open {
+			break
+		}
+		end := strings.Index(line[mid:], ")")
+		if end < 0 {
+			break
+		}
+		line = line[:open] + line[open+1:mid] + line[mid+end+1:]
+	}
+	return line
+}
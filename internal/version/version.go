@@ -0,0 +1,61 @@
+// Package version — версия приложения и журнал её изменений.
+// CHANGELOG.md вшит в бинарник: сервер показывает «что нового» после
+// обновления без обращения к внешним источникам.
+package version
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// Version — текущая версия приложения. Обновляется при релизе вместе
+// с новой записью в начале CHANGELOG.md.
+const Version = "1.6.0"
+
+//go:embed CHANGELOG.md
+var changelogMD string
+
+// Release — одна запись журнала изменений.
+type Release struct {
+	Version string
+	Date    string // YYYY-MM-DD
+	NotesMD string
+}
+
+// Releases возвращает записи журнала изменений, новые первыми.
+// Запись начинается строкой вида «## 1.6.0 — 2026-08-29».
+func Releases() []Release {
+	var releases []Release
+	var current *Release
+
+	for _, line := range strings.Split(changelogMD, "\n") {
+		if header, ok := strings.CutPrefix(line, "## "); ok {
+			if current != nil {
+				current.NotesMD = strings.TrimSpace(current.NotesMD)
+				releases = append(releases, *current)
+			}
+			ver, date, _ := strings.Cut(header, " — ")
+			current = &Release{Version: strings.TrimSpace(ver), Date: strings.TrimSpace(date)}
+			continue
+		}
+		if current != nil {
+			current.NotesMD += line + "\n"
+		}
+	}
+	if current != nil {
+		current.NotesMD = strings.TrimSpace(current.NotesMD)
+		releases = append(releases, *current)
+	}
+	return releases
+}
+
+// Current возвращает запись журнала для текущей версии; если записи
+// нет, возвращается пустой Release с одним номером версии.
+func Current() Release {
+	for _, r := range Releases() {
+		if r.Version == Version {
+			return r
+		}
+	}
+	return Release{Version: Version}
+}
@@ -0,0 +1,268 @@
+package web
+
+// Публичный режим сервера (--public): уроки открытых модулей читаются
+// без входа, а практика, прогресс и заметки требуют ключ доступа.
+// Видимость настраивается помодульно на /admin/visibility и хранится
+// в content.Module.Visibility. Без --public сервер работает как раньше:
+// локальный однопользовательский, без каких-либо ограничений.
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golearning/internal/content"
+)
+
+// viewerRole — уровень доступа посетителя в публичном режиме.
+type viewerRole string
+
+const (
+	// roleOwner — сервер не в публичном режиме, доступно всё.
+	roleOwner viewerRole = "owner"
+	// roleGuest — посетитель без ключа: только чтение открытых модулей.
+	roleGuest viewerRole = "guest"
+	// roleRegistered — вошёл по ключу доступа.
+	roleRegistered viewerRole = "registered"
+	// roleCohort — вошёл по ключу когорты, включая админку.
+	roleCohort viewerRole = "cohort"
+)
+
+// accessCookie — кука с ключом доступа после входа.
+const accessCookie = "golearn_access"
+
+// roleCtxKey — ключ контекста с ролью, выданной не кукой (API-токен).
+type roleCtxKey struct{}
+
+// withRole возвращает запрос с ролью в контексте: так middleware
+// аутентификации по токену передаёт роль обработчикам.
+func withRole(r *http.Request, role viewerRole) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), roleCtxKey{}, role))
+}
+
+// viewer определяет роль посетителя по куке с ключом. Ключ когорты
+// проверяется первым: он даёт более широкий доступ.
+func (s *Server) viewer(r *http.Request) viewerRole {
+	if role, ok := r.Context().Value(roleCtxKey{}).(viewerRole); ok {
+		return role
+	}
+	if !s.opts.PublicMode {
+		return roleOwner
+	}
+
+	c, err := r.Cookie(accessCookie)
+	if err != nil {
+		return roleGuest
+	}
+	key := c.Value
+	if s.opts.CohortKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.opts.CohortKey)) == 1 {
+		return roleCohort
+	}
+	if s.opts.AccessKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.opts.AccessKey)) == 1 {
+		return roleRegistered
+	}
+	// Сессия после входа через OAuth-провайдера: в куке лежит токен,
+	// роль хранится в kv-хранилище
+	if v, ok, err := s.kv().Get(r.Context(), sessionPrefix+key); err == nil && ok && v == string(roleRegistered) {
+		return roleRegistered
+	}
+	return roleGuest
+}
+
+// canPractice сообщает, доступны ли роли практика и запись прогресса.
+func (role viewerRole) canPractice() bool {
+	return role != roleGuest
+}
+
+// canViewModule проверяет доступ роли к модулю. Пустая видимость
+// (записи до миграции) означает public.
+func canViewModule(role viewerRole, v content.ModuleVisibility) bool {
+	switch v {
+	case content.VisibilityRegistered:
+		return role != roleGuest
+	case content.VisibilityCohort:
+		return role == roleOwner || role == roleCohort
+	default:
+		return true
+	}
+}
+
+// moduleVisibility возвращает видимость модуля по ID. Для неизвестного
+// модуля — public: его уроки всё равно не найдутся.
+func (s *Server) moduleVisibility(ctx context.Context, moduleID int64) content.ModuleVisibility {
+	modules, err := s.contentRepo.ListModules(ctx)
+	if err != nil {
+		return content.VisibilityPublic
+	}
+	for _, m := range modules {
+		if m.ID == moduleID {
+			return m.Visibility
+		}
+	}
+	return content.VisibilityPublic
+}
+
+// canViewLesson проверяет доступ посетителя к уроку по видимости его
+// модуля — для выборок вроде поиска, где в результатах есть только ID
+// урока. Неизвестный урок считается недоступным.
+func (s *Server) canViewLesson(r *http.Request, lessonID int64) bool {
+	lesson, err := s.contentRepo.GetLessonByID(r.Context(), lessonID)
+	if err != nil || lesson == nil || lesson.Module == nil {
+		return false
+	}
+	return canViewModule(s.viewer(r), lesson.Module.Visibility)
+}
+
+// requirePractice — middleware для API практики и прогресса: в публичном
+// режиме гости получают 401 вместо записи чужого состояния.
+func (s *Server) requirePractice(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.viewer(r).canPractice() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Требуется вход: откройте /login и введите ключ доступа"}`))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireCohort — middleware админки: в публичном режиме она доступна
+// только по ключу когорты.
+func (s *Server) requireCohort(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := s.viewer(r)
+		if role != roleOwner && role != roleCohort {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleLogin — страница входа по ключу доступа.
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.opts.PublicMode {
+		http.Redirect(w, r, s.opts.BasePath+"/", http.StatusSeeOther)
+		return
+	}
+	data := map[string]interface{}{
+		"Error":     r.URL.Query().Get("error"),
+		"Next":      r.URL.Query().Get("next"),
+		"LoggedIn":  s.viewer(r).canPractice(),
+		"Providers": s.oauthProviderViews(),
+	}
+	if s.identities != nil {
+		if identities, err := s.identities.List(r.Context()); err == nil {
+			data["Identities"] = identities
+		}
+	}
+	s.render(w, "login.html", data)
+}
+
+// handleLoginPost проверяет ключ и ставит куку доступа.
+func (s *Server) handleLoginPost(w http.ResponseWriter, r *http.Request) {
+	if !s.opts.PublicMode {
+		http.Redirect(w, r, s.opts.BasePath+"/", http.StatusSeeOther)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+
+	key := r.FormValue("key")
+	validCohort := s.opts.CohortKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.opts.CohortKey)) == 1
+	validAccess := s.opts.AccessKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.opts.AccessKey)) == 1
+	if !validCohort && !validAccess {
+		http.Redirect(w, r, s.opts.BasePath+"/login?error=1", http.StatusSeeOther)
+		return
+	}
+	// Ключ когорты даёт админку, поэтому при включённой 2FA требует
+	// второй фактор: TOTP-код или одноразовый резервный код
+	if validCohort && !s.verifyCohortTOTP(r, r.FormValue("code")) {
+		http.Redirect(w, r, s.opts.BasePath+"/login?error=2fa", http.StatusSeeOther)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessCookie,
+		Value:    key,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	next := r.FormValue("next")
+	// Только локальные пути: "//evil.com" и "/\evil.com" браузеры
+	// трактуют как протокол-относительный адрес — открытый редирект.
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, `/\`) {
+		next = "/"
+	}
+	http.Redirect(w, r, s.opts.BasePath+next, http.StatusSeeOther)
+}
+
+// handleLogout сбрасывает куку доступа и OAuth-сессию, если она была.
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(accessCookie); err == nil {
+		_ = s.kv().Delete(r.Context(), sessionPrefix+c.Value)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	http.Redirect(w, r, s.opts.BasePath+"/", http.StatusSeeOther)
+}
+
+// redirectToLogin отправляет на страницу входа с возвратом на next.
+func (s *Server) redirectToLogin(w http.ResponseWriter, r *http.Request, next string) {
+	http.Redirect(w, r, s.opts.BasePath+"/login?next="+url.QueryEscape(next), http.StatusSeeOther)
+}
+
+// handleVisibility — страница настройки видимости модулей.
+func (s *Server) handleVisibility(w http.ResponseWriter, r *http.Request) {
+	modules, err := s.contentRepo.ListModules(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "visibility.html", map[string]interface{}{
+		"Modules":    modules,
+		"PublicMode": s.opts.PublicMode,
+	})
+}
+
+// handleVisibilitySave сохраняет видимость модуля.
+func (s *Server) handleVisibilitySave(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+
+	moduleID, err := strconv.ParseInt(r.FormValue("module_id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Некорректный ID модуля")
+		return
+	}
+	visibility := content.ModuleVisibility(r.FormValue("visibility"))
+	switch visibility {
+	case content.VisibilityPublic, content.VisibilityRegistered, content.VisibilityCohort:
+	default:
+		s.badRequest(w, "Некорректная видимость: ожидается public, registered или cohort")
+		return
+	}
+
+	if err := s.contentRepo.SetModuleVisibility(r.Context(), moduleID, visibility); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.recordAudit("modules.visibility", "module", moduleID, "Видимость: "+string(visibility))
+
+	http.Redirect(w, r, s.opts.BasePath+"/admin/visibility", http.StatusSeeOther)
+}
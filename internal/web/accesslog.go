@@ -0,0 +1,73 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// accessLogEntry — одна строка структурированного access-лога.
+type accessLogEntry struct {
+	Time      string `json:"time"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Status    int    `json:"status"`
+	Bytes     int    `json:"bytes"`
+	LatencyMS int64  `json:"latency_ms"`
+	IP        string `json:"ip"`
+}
+
+// accessLogger пишет JSON-строки access-лога с сэмплированием.
+type accessLogger struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	sample  int64 // пишется каждый sample-й запрос (1 — все)
+	counter int64
+}
+
+// skipAccessLog — пути, которые не попадают в access-лог:
+// статика и health-чеки только зашумляют его.
+func skipAccessLog(path string) bool {
+	return strings.HasPrefix(path, "/static/") || path == "/healthz"
+}
+
+// accessLogMiddleware пишет структурированный access-лог.
+func (s *Server) accessLogMiddleware(logger *accessLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skipAccessLog(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			n := atomic.AddInt64(&logger.counter, 1)
+			if logger.sample > 1 && n%logger.sample != 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			started := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			entry := accessLogEntry{
+				Time:      started.UTC().Format(time.RFC3339),
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Status:    ww.Status(),
+				Bytes:     ww.BytesWritten(),
+				LatencyMS: time.Since(started).Milliseconds(),
+				IP:        clientIP(r),
+			}
+
+			logger.mu.Lock()
+			_ = logger.encoder.Encode(entry)
+			logger.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"golearning/internal/audit"
+	"golearning/internal/sched"
+)
+
+// --- Админка ---
+
+// SetAudit подключает журнал административных действий.
+func (s *Server) SetAudit(l *audit.Log) {
+	s.auditLog = l
+}
+
+// recordAudit пишет запись в журнал аудита, если он подключен.
+func (s *Server) recordAudit(action, entity string, entityID int64, summary string) {
+	if s.auditLog == nil {
+		return
+	}
+	s.auditLog.MustRecord("admin", action, entity, entityID, summary)
+}
+
+// handleTrash — страница корзины: мягко удалённые модули и уроки.
+func (s *Server) handleTrash(w http.ResponseWriter, r *http.Request) {
+	items, err := s.contentRepo.ListTrash(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Items": items,
+		"Stats": stats,
+	}
+
+	s.render(w, "trash.html", data)
+}
+
+// handleTrashRestore восстанавливает элемент корзины.
+func (s *Server) handleTrashRestore(w http.ResponseWriter, r *http.Request) {
+	kind, id, ok := s.trashItemFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	var err error
+	if kind == "module" {
+		err = s.contentRepo.RestoreModule(r.Context(), id)
+	} else {
+		err = s.contentRepo.RestoreLesson(r.Context(), id)
+	}
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.recordAudit("trash.restore", kind, id, fmt.Sprintf("Восстановлен из корзины: %s #%d", kind, id))
+	http.Redirect(w, r, s.opts.BasePath+"/admin/trash", http.StatusSeeOther)
+}
+
+// handleTrashPurge окончательно удаляет элемент корзины.
+func (s *Server) handleTrashPurge(w http.ResponseWriter, r *http.Request) {
+	kind, id, ok := s.trashItemFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	var err error
+	if kind == "module" {
+		err = s.contentRepo.PurgeModule(r.Context(), id)
+	} else {
+		err = s.contentRepo.PurgeLesson(r.Context(), id)
+	}
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.recordAudit("trash.purge", kind, id, fmt.Sprintf("Удалён окончательно: %s #%d", kind, id))
+	http.Redirect(w, r, s.opts.BasePath+"/admin/trash", http.StatusSeeOther)
+}
+
+// handleAuditLog — страница журнала административных действий
+// с фильтрами по действию и типу сущности.
+func (s *Server) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	if s.auditLog == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	action := r.URL.Query().Get("action")
+	entity := r.URL.Query().Get("entity")
+
+	entries, err := s.auditLog.List(action, entity, 200)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	actions, _ := s.auditLog.Actions()
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Entries": entries,
+		"Actions": actions,
+		"Action":  action,
+		"Entity":  entity,
+		"Stats":   stats,
+	}
+
+	s.render(w, "audit.html", data)
+}
+
+// handleAnalytics — админский дашборд со статистикой обучения.
+func (s *Server) handleAnalytics(w http.ResponseWriter, r *http.Request) {
+	if s.analytics == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Роллап дешёвый на single-user базе — обновляем прямо при открытии,
+	// чтобы дашборд не отставал от свежих отправок
+	if err := s.analytics.RebuildRollups(r.Context()); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	daily, err := s.analytics.Daily(r.Context(), 14)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	modules, err := s.analytics.Modules(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	funnel, err := s.analytics.LearningFunnel(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	events, err := s.analytics.EventCounts(r.Context(), 30)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Daily":   daily,
+		"Modules": modules,
+		"Funnel":  funnel,
+		"Events":  events,
+		"Stats":   stats,
+	}
+
+	s.render(w, "analytics.html", data)
+}
+
+// SetScheduler подключает планировщик фоновых задач (страница /admin/jobs).
+func (s *Server) SetScheduler(sc *sched.Scheduler) {
+	s.scheduler = sc
+}
+
+// handleJobs — страница фоновых задач: список с кнопками ручного
+// запуска и история последних запусков.
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	if s.scheduler == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	history, err := s.scheduler.History(r.Context(), 50)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Jobs":    s.scheduler.Jobs(),
+		"History": history,
+		"Stats":   stats,
+	}
+
+	s.render(w, "jobs.html", data)
+}
+
+// handleJobRun запускает задачу вручную. Запуск идёт в фоне:
+// результат появится в истории на странице задач.
+func (s *Server) handleJobRun(w http.ResponseWriter, r *http.Request) {
+	if s.scheduler == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	name := r.FormValue("name")
+	found := false
+	for _, job := range s.scheduler.Jobs() {
+		if job.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		s.badRequest(w, "Unknown job")
+		return
+	}
+
+	go func() {
+		_ = s.scheduler.RunNow(context.Background(), name)
+	}()
+
+	s.recordAudit("jobs.run", "job", 0, fmt.Sprintf("Запущена задача %s", name))
+	http.Redirect(w, r, s.opts.BasePath+"/admin/jobs", http.StatusSeeOther)
+}
+
+// handleDeadLinks — страница битых внешних ссылок из уроков.
+func (s *Server) handleDeadLinks(w http.ResponseWriter, r *http.Request) {
+	if s.linkChecker == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	links, err := s.linkChecker.DeadLinks(r.Context(), 200)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Links": links,
+		"Stats": stats,
+	}
+
+	s.render(w, "deadlinks.html", data)
+}
+
+// handleErrors — страница последних ошибок сервера со счётчиками повторений.
+func (s *Server) handleErrors(w http.ResponseWriter, r *http.Request) {
+	if s.errorLog == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	entries, err := s.errorLog.Recent(100)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Entries": entries,
+		"Stats":   stats,
+	}
+
+	s.render(w, "errors.html", data)
+}
+
+// handleFlags — страница фича-флагов с переключателями.
+func (s *Server) handleFlags(w http.ResponseWriter, r *http.Request) {
+	if s.flagsService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Flags": s.flagsService.All(r.Context()),
+		"Stats": stats,
+	}
+
+	s.render(w, "flags.html", data)
+}
+
+// handleFlagToggle переключает фича-флаг.
+func (s *Server) handleFlagToggle(w http.ResponseWriter, r *http.Request) {
+	if s.flagsService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	name := r.FormValue("name")
+	enabled := r.FormValue("enabled") == "1"
+	if err := s.flagsService.SetEnabled(r.Context(), name, enabled); err != nil {
+		s.badRequest(w, err.Error())
+		return
+	}
+
+	state := "выключен"
+	if enabled {
+		state = "включён"
+	}
+	s.recordAudit("flags.toggle", "flag", 0, fmt.Sprintf("Флаг %s %s", name, state))
+	http.Redirect(w, r, s.opts.BasePath+"/admin/flags", http.StatusSeeOther)
+}
+
+// trashItemFromForm читает и валидирует kind/id из формы корзины.
+func (s *Server) trashItemFromForm(w http.ResponseWriter, r *http.Request) (string, int64, bool) {
+	kind := r.FormValue("kind")
+	if kind != "module" && kind != "lesson" {
+		s.badRequest(w, "Invalid item kind")
+		return "", 0, false
+	}
+
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid item ID")
+		return "", 0, false
+	}
+
+	return kind, id, true
+}
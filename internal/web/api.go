@@ -0,0 +1,473 @@
+package web
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+	"golearning/internal/progress"
+	"golearning/internal/render"
+)
+
+// --- REST API v1 ---
+// JSON-представления контента для PWA и внешних клиентов.
+
+// apiLesson — краткое представление урока в списках.
+type apiLesson struct {
+	ID             int64     `json:"id"`
+	ModuleID       int64     `json:"module_id"`
+	Slug           string    `json:"slug"`
+	Title          string    `json:"title"`
+	OrderIndex     int       `json:"order_index"`
+	ReadingTimeMin int       `json:"reading_time_min"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// apiLessonDetail — полное представление урока с телом и секциями.
+type apiLessonDetail struct {
+	apiLesson
+	SourceURL string       `json:"source_url"`
+	BodyMD    string       `json:"body_md"`
+	Sections  []apiSection `json:"sections"`
+}
+
+// apiSection — секция урока.
+type apiSection struct {
+	Kind       content.SectionKind `json:"kind"`
+	Title      string              `json:"title"`
+	BodyMD     string              `json:"body_md"`
+	OrderIndex int                 `json:"order_index"`
+}
+
+// fields возвращает урок как map только с запрошенными полями.
+// Имена совпадают с json-тегами.
+func (l apiLesson) fields(selected map[string]bool) map[string]interface{} {
+	all := map[string]interface{}{
+		"id":               l.ID,
+		"module_id":        l.ModuleID,
+		"slug":             l.Slug,
+		"title":            l.Title,
+		"order_index":      l.OrderIndex,
+		"reading_time_min": l.ReadingTimeMin,
+		"updated_at":       l.UpdatedAt,
+	}
+	out := make(map[string]interface{}, len(selected))
+	for name := range selected {
+		if v, ok := all[name]; ok {
+			out[name] = v
+		}
+	}
+	return out
+}
+
+// apiPageDefaults — постраничная выдача списков API.
+const (
+	apiPerPageDefault = 50
+	apiPerPageMax     = 200
+)
+
+// parseAPIPage читает ?page= и ?per_page=. Без параметров пагинация
+// выключена (совместимость со старыми клиентами): page = 0.
+func parseAPIPage(r *http.Request) (page, perPage int) {
+	q := r.URL.Query()
+	page, _ = strconv.Atoi(q.Get("page"))
+	perPage, _ = strconv.Atoi(q.Get("per_page"))
+	if page < 1 && perPage < 1 {
+		return 0, 0
+	}
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = apiPerPageDefault
+	}
+	if perPage > apiPerPageMax {
+		perPage = apiPerPageMax
+	}
+	return page, perPage
+}
+
+// parseAPIFields читает ?fields= — список полей через запятую.
+func parseAPIFields(r *http.Request) map[string]bool {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return nil
+	}
+	selected := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected[name] = true
+		}
+	}
+	return selected
+}
+
+// handleAPIListLessons — GET /api/v1/lessons: список всех уроков без тел.
+// Поддерживает ?page=/&per_page= и выбор полей через ?fields=id,slug,title —
+// мобильному клиенту не нужны все колонки на каждый экран.
+func (s *Server) handleAPIListLessons(w http.ResponseWriter, r *http.Request) {
+	lessons, err := s.contentRepo.ListAllLessons(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	role := s.viewer(r)
+	items := make([]apiLesson, 0, len(lessons))
+	var lastModified time.Time
+	for _, l := range lessons {
+		if !canViewModule(role, s.moduleVisibility(r.Context(), l.ModuleID)) {
+			continue
+		}
+		items = append(items, apiLesson{
+			ID:             l.ID,
+			ModuleID:       l.ModuleID,
+			Slug:           l.Slug,
+			Title:          l.Title,
+			OrderIndex:     l.OrderIndex,
+			ReadingTimeMin: l.ReadingTimeMin,
+			UpdatedAt:      l.UpdatedAt,
+		})
+		if l.UpdatedAt.After(lastModified) {
+			lastModified = l.UpdatedAt
+		}
+	}
+	total := len(items)
+
+	// ETag списка — по количеству, времени последнего обновления
+	// и параметрам выборки
+	etag := fmt.Sprintf(`"lessons-%d-%d-%s"`, total, lastModified.Unix(), r.URL.RawQuery)
+	if writeConditional(w, r, etag, lastModified) {
+		return
+	}
+
+	response := map[string]interface{}{"total": total}
+
+	page, perPage := parseAPIPage(r)
+	if page > 0 {
+		start := (page - 1) * perPage
+		if start > total {
+			start = total
+		}
+		end := start + perPage
+		if end > total {
+			end = total
+		}
+		items = items[start:end]
+		response["page"] = page
+		response["per_page"] = perPage
+	}
+
+	if selected := parseAPIFields(r); selected != nil {
+		filtered := make([]map[string]interface{}, 0, len(items))
+		for _, item := range items {
+			filtered = append(filtered, item.fields(selected))
+		}
+		response["lessons"] = filtered
+	} else {
+		response["lessons"] = items
+	}
+
+	s.jsonResponse(w, response)
+}
+
+// handleAPIGetLesson — GET /api/v1/lessons/{slug}: урок с телом и секциями.
+func (s *Server) handleAPIGetLesson(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	lesson, err := s.contentRepo.GetLessonBySlug(r.Context(), slug)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if lesson == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !canViewModule(s.viewer(r), lesson.Module.Visibility) {
+		http.Error(w, "Требуется вход", http.StatusUnauthorized)
+		return
+	}
+
+	if writeConditional(w, r, lessonETag(lesson), lesson.UpdatedAt) {
+		return
+	}
+
+	detail := apiLessonDetail{
+		apiLesson: apiLesson{
+			ID:             lesson.ID,
+			ModuleID:       lesson.ModuleID,
+			Slug:           lesson.Slug,
+			Title:          lesson.Title,
+			OrderIndex:     lesson.OrderIndex,
+			ReadingTimeMin: lesson.ReadingTimeMin,
+			UpdatedAt:      lesson.UpdatedAt,
+		},
+		SourceURL: lesson.SourceURL,
+		BodyMD:    lesson.BodyMD,
+		Sections:  make([]apiSection, 0, len(lesson.Sections)),
+	}
+	for _, sec := range lesson.Sections {
+		detail.Sections = append(detail.Sections, apiSection{
+			Kind:       sec.Kind,
+			Title:      sec.Title,
+			BodyMD:     sec.BodyMD,
+			OrderIndex: sec.OrderIndex,
+		})
+	}
+
+	s.jsonResponse(w, detail)
+}
+
+// handleAPIModuleLessons — GET /api/v1/modules/{id}/lessons: уроки модуля
+// с keyset-пагинацией (?after_id= — id последнего полученного урока,
+// ?limit= — размер страницы).
+func (s *Server) handleAPIModuleLessons(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Некорректный ID модуля", http.StatusBadRequest)
+		return
+	}
+
+	if !canViewModule(s.viewer(r), s.moduleVisibility(r.Context(), moduleID)) {
+		http.Error(w, "Требуется вход", http.StatusUnauthorized)
+		return
+	}
+
+	afterID, _ := strconv.ParseInt(r.URL.Query().Get("after_id"), 10, 64)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = apiPerPageDefault
+	}
+	if limit > apiPerPageMax {
+		limit = apiPerPageMax
+	}
+
+	lessons, err := s.contentRepo.ListLessonsPage(r.Context(), moduleID, afterID, limit)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	items := make([]apiLesson, 0, len(lessons))
+	for _, l := range lessons {
+		items = append(items, apiLesson{
+			ID:             l.ID,
+			ModuleID:       l.ModuleID,
+			Slug:           l.Slug,
+			Title:          l.Title,
+			OrderIndex:     l.OrderIndex,
+			ReadingTimeMin: l.ReadingTimeMin,
+			UpdatedAt:      l.UpdatedAt,
+		})
+	}
+
+	response := map[string]interface{}{"lessons": items}
+	// Курсор следующей страницы; полная страница — возможно, есть ещё
+	if len(items) == limit {
+		response["next_after_id"] = items[len(items)-1].ID
+	}
+	s.jsonResponse(w, response)
+}
+
+// apiOutlineSection — секция в оглавлении урока.
+type apiOutlineSection struct {
+	ID       int64               `json:"id"`
+	Kind     content.SectionKind `json:"kind"`
+	Title    string              `json:"title"`
+	Headings []render.Heading    `json:"headings"`
+}
+
+// handleAPILessonOutline — GET /api/v1/lessons/{slug}/outline: оглавление
+// урока по секциям. Отдаётся из сохранённых при импорте данных, Markdown
+// на запрос не разбирается.
+func (s *Server) handleAPILessonOutline(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	lesson, err := s.contentRepo.GetLessonBySlug(r.Context(), slug)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if lesson == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !canViewModule(s.viewer(r), lesson.Module.Visibility) {
+		http.Error(w, "Требуется вход", http.StatusUnauthorized)
+		return
+	}
+
+	if writeConditional(w, r, lessonETag(lesson), lesson.UpdatedAt) {
+		return
+	}
+
+	sections := make([]apiOutlineSection, 0, len(lesson.Sections))
+	for _, sec := range lesson.Sections {
+		headings := s.sectionOutline(sec)
+		if headings == nil {
+			headings = []render.Heading{}
+		}
+		sections = append(sections, apiOutlineSection{
+			ID:       sec.ID,
+			Kind:     sec.Kind,
+			Title:    sec.Title,
+			Headings: headings,
+		})
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"sections": sections})
+}
+
+// apiExample — пример кода для редакторных расширений: сам блок,
+// пояснение и ссылка на урок-источник.
+type apiExample struct {
+	Code        string `json:"code"`
+	Language    string `json:"language"`
+	Runnable    bool   `json:"runnable"`
+	Explanation string `json:"explanation"`
+	LessonTitle string `json:"lesson_title"`
+	LessonURL   string `json:"lesson_url"`
+}
+
+// handleAPIExamples — GET /api/v1/examples?topic=...: примеры кода из
+// уроков по теме или ключевому слову. ?lang= сужает до одного языка,
+// ?runnable=1 оставляет только цельные программы (package main).
+func (s *Server) handleAPIExamples(w http.ResponseWriter, r *http.Request) {
+	topic := strings.TrimSpace(r.URL.Query().Get("topic"))
+	if topic == "" {
+		http.Error(w, "Не указан параметр topic", http.StatusBadRequest)
+		return
+	}
+
+	lang := r.URL.Query().Get("lang")
+	runnableOnly := r.URL.Query().Get("runnable") == "1"
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = apiPerPageDefault
+	}
+	if limit > apiPerPageMax {
+		limit = apiPerPageMax
+	}
+
+	blocks, err := s.contentRepo.SearchExamples(r.Context(), topic, lang, limit)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	role := s.viewer(r)
+	items := make([]apiExample, 0, len(blocks))
+	for _, b := range blocks {
+		if !canViewModule(role, s.moduleVisibility(r.Context(), b.ModuleID)) {
+			continue
+		}
+		runnable := b.Language == "go" && strings.Contains(b.Code, "package main")
+		if runnableOnly && !runnable {
+			continue
+		}
+		items = append(items, apiExample{
+			Code:        b.Code,
+			Language:    b.Language,
+			Runnable:    runnable,
+			Explanation: fmt.Sprintf("%s — %s", b.LessonTitle, b.SectionTitle),
+			LessonTitle: b.LessonTitle,
+			LessonURL:   fmt.Sprintf("%s/lessons/%s#section-%d", s.opts.BasePath, b.LessonSlug, b.SectionID),
+		})
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"topic":    topic,
+		"total":    len(items),
+		"examples": items,
+	})
+}
+
+// handleAPITaskStats — GET /api/v1/tasks/{id}/stats: агрегированная статистика по заданию.
+func (s *Server) handleAPITaskStats(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Некорректный ID задания", http.StatusBadRequest)
+		return
+	}
+
+	task, err := s.contentRepo.GetTaskByID(r.Context(), id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if task == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	stats, err := s.progressRepo.GetTaskStats(r.Context(), id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, stats)
+}
+
+// --- Условные запросы (ETag / Last-Modified) ---
+
+// lessonETag возвращает ETag контента урока (хэш заголовка, тела и времени обновления).
+func lessonETag(l *content.Lesson) string {
+	h := sha256.New()
+	io.WriteString(h, l.Title)
+	io.WriteString(h, l.BodyMD)
+	io.WriteString(h, l.UpdatedAt.UTC().Format(time.RFC3339Nano))
+	return fmt.Sprintf(`"%x"`, h.Sum(nil)[:16])
+}
+
+// lessonPageETag возвращает ETag HTML-страницы урока.
+// Страница зависит не только от контента, но и от прогресса, заметки и общей статистики.
+func lessonPageETag(l *content.Lesson, prog *progress.Progress, note *progress.Note, stats *progress.Stats) string {
+	h := sha256.New()
+	io.WriteString(h, lessonETag(l))
+	if prog != nil {
+		fmt.Fprintf(h, "%s:%v:%d", prog.Status, prog.PracticeDone, prog.PointsEarned)
+	}
+	if note != nil {
+		io.WriteString(h, note.UpdatedAt.UTC().Format(time.RFC3339Nano))
+	}
+	if stats != nil {
+		fmt.Fprintf(h, "%d:%d", stats.EarnedPoints, stats.CompletedCount)
+	}
+	return fmt.Sprintf(`W/"%x"`, h.Sum(nil)[:16])
+}
+
+// writeConditional выставляет заголовки ETag/Last-Modified и отвечает 304 Not Modified,
+// если клиентская копия актуальна. Возвращает true, если ответ уже отправлен.
+func writeConditional(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	// If-None-Match имеет приоритет над If-Modified-Since
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if strings.Contains(match, strings.TrimPrefix(etag, "W/")) {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"golearning/internal/rag"
+)
+
+// askQuestionMaxLen — предел длины вопроса: всё, что длиннее, уже не
+// вопрос, а вставленный текст.
+const askQuestionMaxLen = 500
+
+// SetAsk подключает «спросить курс»: поиск по урокам через эмбеддинги
+// и ответ LLM с цитатами (страница /ask).
+func (s *Server) SetAsk(service *rag.Service) {
+	s.askService = service
+}
+
+// handleAsk — GET /ask: страница вопросов по курсу.
+func (s *Server) handleAsk(w http.ResponseWriter, r *http.Request) {
+	if s.askService == nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.render(w, "ask.html", map[string]interface{}{})
+}
+
+// handleAskQuestion — POST /api/ask: ответ на вопрос по материалам
+// курса с источниками-уроками.
+func (s *Server) handleAskQuestion(w http.ResponseWriter, r *http.Request) {
+	if s.askService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	req.Question = strings.TrimSpace(req.Question)
+	if req.Question == "" {
+		s.badRequest(w, "Задайте вопрос")
+		return
+	}
+	if len(req.Question) > askQuestionMaxLen {
+		s.badRequest(w, "Слишком длинный вопрос")
+		return
+	}
+
+	answer, err := s.askService.Ask(r.Context(), req.Question)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.jsonResponse(w, answer)
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// hashStaticAssets обходит встроенные статические файлы и возвращает
+// отображение логического имени ("app.css") в имя с хэшем контента
+// ("app.3fa9c1d2.css") для cache-busting.
+func hashStaticAssets(staticFS fs.FS) (map[string]string, error) {
+	hashes := make(map[string]string)
+
+	err := fs.WalkDir(staticFS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(staticFS, p)
+		if err != nil {
+			return fmt.Errorf("read asset %s: %w", p, err)
+		}
+
+		sum := sha256.Sum256(data)
+		hashes[p] = hashedAssetName(p, fmt.Sprintf("%x", sum[:4]))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hash static assets: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// hashedAssetName вставляет хэш перед расширением: "app.css" -> "app.<hash>.css".
+func hashedAssetName(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// realAssetName убирает вставленный хэш из имени: "app.<hash>.css" -> "app.css".
+// Если хэша нет, имя возвращается как есть.
+func realAssetName(name string) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if i := strings.LastIndex(base, "."); i >= 0 {
+		maybeHash := base[i+1:]
+		if len(maybeHash) == 8 && isHexString(maybeHash) {
+			return base[:i] + ext
+		}
+	}
+	return name
+}
+
+// isHexString проверяет, что строка состоит только из hex-символов.
+func isHexString(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// handleStatic отдаёт статические файлы.
+// Файлы с хэшем в имени получают долгий immutable-кэш,
+// обращения по обычному имени — короткий (на случай прямых ссылок).
+func (s *Server) handleStatic(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/static/")
+	real := realAssetName(name)
+
+	data, err := fs.ReadFile(s.staticFiles, real)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case s.opts.DevMode:
+		// В dev-режиме файлы правятся между запросами — не кэшируем
+		w.Header().Set("Cache-Control", "no-store")
+	case real != name:
+		// Имя содержит хэш контента — можно кэшировать навсегда
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	default:
+		w.Header().Set("Cache-Control", "public, max-age=300")
+	}
+
+	ctype := contentTypeByExt(path.Ext(real))
+	if ctype != "" {
+		w.Header().Set("Content-Type", ctype)
+	}
+
+	w.Write(data)
+}
+
+// contentTypeByExt возвращает content-type для известных расширений статики.
+func contentTypeByExt(ext string) string {
+	switch ext {
+	case ".css":
+		return "text/css; charset=utf-8"
+	case ".js":
+		return "application/javascript; charset=utf-8"
+	case ".svg":
+		return "image/svg+xml"
+	case ".png":
+		return "image/png"
+	case ".ico":
+		return "image/x-icon"
+	default:
+		return ""
+	}
+}
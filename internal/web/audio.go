@@ -0,0 +1,69 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+	"golearning/internal/tts"
+)
+
+// SetTTS подключает озвучку уроков (плееры на странице урока).
+func (s *Server) SetTTS(service *tts.Service) {
+	s.ttsService = service
+}
+
+// handleSectionAudio — GET /lessons/{slug}/audio/{sectionID}.mp3:
+// озвучка секции урока. Первый запрос синтезирует аудио, дальше оно
+// отдаётся из хранилища артефактов.
+func (s *Server) handleSectionAudio(w http.ResponseWriter, r *http.Request) {
+	if s.ttsService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	lesson, err := s.contentRepo.GetLessonBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if lesson == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !canViewModule(s.viewer(r), lesson.Module.Visibility) {
+		http.NotFound(w, r)
+		return
+	}
+
+	sectionID, err := strconv.ParseInt(chi.URLParam(r, "sectionID"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid section ID")
+		return
+	}
+	var section *content.Section
+	for i := range lesson.Sections {
+		if lesson.Sections[i].ID == sectionID {
+			section = &lesson.Sections[i]
+			break
+		}
+	}
+	if section == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	audio, err := s.ttsService.SectionAudio(r.Context(), section)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	defer audio.Close()
+
+	w.Header().Set("Content-Type", "audio/mpeg")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	io.Copy(w, audio)
+}
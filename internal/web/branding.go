@@ -0,0 +1,57 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"golearning/internal/branding"
+)
+
+// SetBranding подключает white-label-настройки (страница /admin/branding).
+func (s *Server) SetBranding(b *branding.Service) {
+	s.brandingService = b
+}
+
+// brand возвращает текущее брендирование; без сервиса — умолчания.
+func (s *Server) brand(ctx context.Context) branding.Branding {
+	if s.brandingService == nil {
+		return branding.Default()
+	}
+	return s.brandingService.Current(ctx)
+}
+
+// handleBranding — страница настроек брендирования.
+func (s *Server) handleBranding(w http.ResponseWriter, r *http.Request) {
+	if s.brandingService == nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.render(w, "branding.html", map[string]interface{}{
+		"Branding": s.brandingService.Current(r.Context()),
+		"Saved":    r.URL.Query().Get("saved") == "1",
+	})
+}
+
+// handleBrandingSave сохраняет настройки брендирования. Пустое поле
+// возвращает значение по умолчанию.
+func (s *Server) handleBrandingSave(w http.ResponseWriter, r *http.Request) {
+	if s.brandingService == nil {
+		http.NotFound(w, r)
+		return
+	}
+	b := branding.Branding{
+		SiteName:       r.FormValue("site_name"),
+		LogoEmoji:      r.FormValue("logo_emoji"),
+		Tagline:        r.FormValue("tagline"),
+		PrimaryColor:   r.FormValue("primary_color"),
+		HeroTitle:      r.FormValue("hero_title"),
+		HeroSubtitle:   r.FormValue("hero_subtitle"),
+		FooterLinksRaw: r.FormValue("footer_links"),
+	}
+	if err := s.brandingService.Save(r.Context(), b); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.recordAudit("branding.save", "branding", 0, "Настройки брендирования обновлены")
+	http.Redirect(w, r, s.opts.BasePath+"/admin/branding?saved=1", http.StatusSeeOther)
+}
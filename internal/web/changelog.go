@@ -0,0 +1,69 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golearning/internal/version"
+)
+
+// changelogSeenKey — ключ настройки с последней подтверждённой версией:
+// модал «что нового» показывается один раз на версию.
+const changelogSeenKey = "changelog.seen"
+
+// handleChangelogUnseen — GET /api/changelog: запись журнала изменений
+// текущей версии, если она ещё не подтверждена. После подтверждения
+// (или на уже виденной версии) возвращается show: false.
+func (s *Server) handleChangelogUnseen(w http.ResponseWriter, r *http.Request) {
+	current := version.Current()
+
+	seen, _ := s.progressRepo.GetSetting(r.Context(), changelogSeenKey)
+	if seen == current.Version || current.NotesMD == "" {
+		s.jsonResponse(w, map[string]interface{}{"show": false})
+		return
+	}
+
+	html, err := s.markdown.HTML(current.NotesMD)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"show":    true,
+		"version": current.Version,
+		"date":    current.Date,
+		"html":    html,
+	})
+}
+
+// handleChangelogAck — POST /api/changelog/ack: подтверждает просмотр
+// журнала изменений; до следующего релиза модал больше не показывается.
+func (s *Server) handleChangelogAck(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if req.Version != version.Version {
+		s.badRequest(w, "Неизвестная версия: "+req.Version)
+		return
+	}
+
+	if err := s.progressRepo.SetSetting(r.Context(), changelogSeenKey, req.Version); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true})
+}
+
+// handleAPIVersion — GET /api/v1/version: версия приложения и схемы БД.
+func (s *Server) handleAPIVersion(w http.ResponseWriter, r *http.Request) {
+	s.jsonResponse(w, map[string]interface{}{
+		"version":        version.Version,
+		"schema_version": s.schemaVersion,
+	})
+}
@@ -0,0 +1,138 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+)
+
+// cheatsheetLesson — урок со шпаргалкой в индексе /cheatsheets.
+type cheatsheetLesson struct {
+	Title     string
+	Slug      string
+	SectionID int64
+}
+
+// cheatsheetModule — модуль с уроками-шпаргалками.
+type cheatsheetModule struct {
+	Title   string
+	Slug    string
+	Lessons []cheatsheetLesson
+}
+
+// handleCheatsheets — GET /cheatsheets: индекс шпаргалок по модулям.
+// Показываются только модули, видимые текущему посетителю.
+func (s *Server) handleCheatsheets(w http.ResponseWriter, r *http.Request) {
+	sheets, err := s.contentRepo.ListSectionsByKind(r.Context(), content.SectionCheatsheet)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	byLesson := make(map[int64]int64, len(sheets))
+	for _, sec := range sheets {
+		byLesson[sec.LessonID] = sec.ID
+	}
+
+	role := s.viewer(r)
+	var groups []cheatsheetModule
+
+	courses, err := s.contentRepo.ListCourses(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	for _, course := range courses {
+		modules, err := s.contentRepo.ListModulesByCourseID(r.Context(), course.ID)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		for _, m := range modules {
+			if !canViewModule(role, m.Visibility) {
+				continue
+			}
+			lessons, err := s.contentRepo.ListLessonsByModuleID(r.Context(), m.ID)
+			if err != nil {
+				s.serverError(w, err)
+				return
+			}
+			group := cheatsheetModule{Title: m.Title, Slug: m.Slug}
+			for _, l := range lessons {
+				if sectionID, ok := byLesson[l.ID]; ok {
+					group.Lessons = append(group.Lessons, cheatsheetLesson{
+						Title:     l.Title,
+						Slug:      l.Slug,
+						SectionID: sectionID,
+					})
+				}
+			}
+			if len(group.Lessons) > 0 {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	s.render(w, "cheatsheets.html", map[string]interface{}{
+		"Modules": groups,
+	})
+}
+
+// handleCheatsheetExport — GET /cheatsheets/{slug}.md: все шпаргалки
+// модуля одним Markdown-файлом.
+func (s *Server) handleCheatsheetExport(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	module, err := s.contentRepo.GetModuleBySlug(r.Context(), slug)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if module == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !canViewModule(s.viewer(r), module.Visibility) {
+		s.redirectToLogin(w, r, "/cheatsheets")
+		return
+	}
+
+	sheets, err := s.contentRepo.ListSectionsByKind(r.Context(), content.SectionCheatsheet)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	byLesson := make(map[int64]content.Section, len(sheets))
+	for _, sec := range sheets {
+		byLesson[sec.LessonID] = sec
+	}
+
+	lessons, err := s.contentRepo.ListLessonsByModuleID(r.Context(), module.ID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "# Шпаргалки — %s\n\n", module.Title)
+	count := 0
+	for _, l := range lessons {
+		sec, ok := byLesson[l.ID]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&out, "## %s\n\n%s\n\n", l.Title, strings.TrimSpace(sec.BodyMD))
+		count++
+	}
+	if count == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "cheatsheets-"+module.Slug+".md"))
+	w.Write([]byte(out.String()))
+}
@@ -0,0 +1,163 @@
+package web
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// defaultCompressTypes — типы контента, которые имеет смысл сжимать.
+// Бинарные форматы (картинки, шрифты woff2) уже сжаты.
+var defaultCompressTypes = []string{
+	"text/html",
+	"text/css",
+	"text/plain",
+	"application/json",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+// defaultCompressMinSize — минимальный размер ответа для сжатия.
+// Мелкие ответы (JSON со статусом) сжимать невыгодно.
+const defaultCompressMinSize = 1024
+
+// compressMiddleware возвращает middleware, сжимающее ответы gzip или brotli
+// в зависимости от Accept-Encoding, с белым списком content-type и порогом размера.
+func compressMiddleware(level, minSize int, types []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[strings.TrimSpace(t)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := selectEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				level:          level,
+				minSize:        minSize,
+				allowed:        allowed,
+				status:         http.StatusOK,
+			}
+			defer cw.Close()
+
+			w.Header().Add("Vary", "Accept-Encoding")
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// selectEncoding выбирает лучшую поддерживаемую клиентом кодировку.
+func selectEncoding(acceptEncoding string) string {
+	if strings.Contains(acceptEncoding, "br") {
+		return "br"
+	}
+	if strings.Contains(acceptEncoding, "gzip") {
+		return "gzip"
+	}
+	return ""
+}
+
+// compressWriter буферизует начало ответа: если он меньше порога
+// или content-type не в белом списке, отдаёт как есть, иначе включает сжатие.
+type compressWriter struct {
+	http.ResponseWriter
+
+	encoding string
+	level    int
+	minSize  int
+	allowed  map[string]bool
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+	encoder     io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	cw.status = status
+	cw.wroteHeader = true
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.decided {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.minSize {
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// decide принимает решение о сжатии и сбрасывает накопленный буфер.
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+
+	compressible := len(cw.buf) >= cw.minSize &&
+		cw.Header().Get("Content-Encoding") == "" &&
+		cw.allowed[baseContentType(cw.Header().Get("Content-Type"))]
+
+	if compressible {
+		cw.Header().Set("Content-Encoding", cw.encoding)
+		cw.Header().Del("Content-Length")
+		switch cw.encoding {
+		case "br":
+			cw.encoder = brotli.NewWriterLevel(cw.ResponseWriter, cw.level)
+		default:
+			gz, err := gzip.NewWriterLevel(cw.ResponseWriter, cw.level)
+			if err != nil {
+				gz = gzip.NewWriter(cw.ResponseWriter)
+			}
+			cw.encoder = gz
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(cw.buf)
+	} else if len(cw.buf) > 0 {
+		_, err = cw.ResponseWriter.Write(cw.buf)
+	}
+	cw.buf = nil
+	return err
+}
+
+// Close досжимает и закрывает поток в конце запроса.
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if err := cw.decide(); err != nil {
+			return err
+		}
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Close()
+	}
+	return nil
+}
+
+// baseContentType отрезает параметры вида "; charset=utf-8".
+func baseContentType(ct string) string {
+	if i := strings.Index(ct, ";"); i >= 0 {
+		ct = ct[:i]
+	}
+	return strings.TrimSpace(ct)
+}
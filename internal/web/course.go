@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+)
+
+// handleCourse — страница курса: модули и уроки только этого курса
+// плюс статистика прогресса в рамках курса.
+func (s *Server) handleCourse(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	course, err := s.contentRepo.GetCourseBySlug(r.Context(), slug)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if course == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	modules, err := s.contentRepo.ListModulesByCourseID(r.Context(), course.ID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	type ModuleWithLessons struct {
+		Module  content.Module
+		Lessons []content.Lesson
+	}
+
+	var modulesWithLessons []ModuleWithLessons
+	for _, m := range modules {
+		// Недоступные посетителю модули не попадают в оглавление
+		if !canViewModule(s.viewer(r), m.Visibility) {
+			continue
+		}
+		lessons, err := s.contentRepo.ListLessonsPage(r.Context(), m.ID, 0, indexLessonsPerModule)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		modulesWithLessons = append(modulesWithLessons, ModuleWithLessons{
+			Module:  m,
+			Lessons: lessons,
+		})
+	}
+
+	progressMap, _ := s.progressRepo.GetAllProgress(r.Context())
+	stats, _ := s.progressRepo.GetCourseStats(r.Context(), course.ID)
+
+	data := map[string]interface{}{
+		"Course":   course,
+		"Modules":  modulesWithLessons,
+		"Progress": progressMap,
+		"Stats":    stats,
+	}
+
+	s.render(w, "course.html", data)
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Главная страница собрана из виджетов: их набор и порядок хранятся в
+// settings (ключ dashboard.widgets) и редактируются перетаскиванием
+// прямо на странице.
+
+// dashboardWidget — виджет главной страницы.
+type dashboardWidget struct {
+	ID    string
+	Title string
+}
+
+// dashboardWidgetList — все доступные виджеты в порядке по умолчанию.
+var dashboardWidgetList = []dashboardWidget{
+	{"stats", "Статистика"},
+	{"heatmap", "Активность"},
+	{"next", "Следующий урок"},
+	{"reviews", "К повторению"},
+	{"submissions", "Последние отправки"},
+	{"goals", "План чтения"},
+}
+
+// dashboardSettingKey — ключ настройки с набором и порядком виджетов.
+const dashboardSettingKey = "dashboard.widgets"
+
+// dashboardLayout возвращает включённые виджеты в сохранённом порядке
+// и отключённые — для панели настройки. Без сохранённой настройки
+// включены все виджеты в порядке по умолчанию.
+func (s *Server) dashboardLayout(r *http.Request) (enabled, disabled []dashboardWidget) {
+	byID := make(map[string]dashboardWidget, len(dashboardWidgetList))
+	for _, w := range dashboardWidgetList {
+		byID[w.ID] = w
+	}
+
+	saved, _ := s.progressRepo.GetSetting(r.Context(), dashboardSettingKey)
+	if saved == "" {
+		return dashboardWidgetList, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(saved, ",") {
+		w, ok := byID[strings.TrimSpace(id)]
+		// Неизвестные ID молча пропускаются: настройка могла быть
+		// сохранена другой версией сервера
+		if !ok || seen[w.ID] {
+			continue
+		}
+		seen[w.ID] = true
+		enabled = append(enabled, w)
+	}
+	for _, w := range dashboardWidgetList {
+		if !seen[w.ID] {
+			disabled = append(disabled, w)
+		}
+	}
+	return enabled, disabled
+}
+
+// handleDashboardWidgets — POST /api/dashboard/widgets: сохраняет набор
+// и порядок виджетов главной страницы. Неизвестные ID отклоняются;
+// пустой список возвращает раскладку по умолчанию.
+func (s *Server) handleDashboardWidgets(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Widgets []string `json:"widgets"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+
+	known := make(map[string]bool, len(dashboardWidgetList))
+	for _, widget := range dashboardWidgetList {
+		known[widget.ID] = true
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range req.Widgets {
+		id = strings.TrimSpace(id)
+		if !known[id] {
+			s.badRequest(w, "Неизвестный виджет: "+id)
+			return
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if err := s.progressRepo.SetSetting(r.Context(), dashboardSettingKey, strings.Join(ids, ",")); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true})
+}
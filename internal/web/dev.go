@@ -0,0 +1,45 @@
+package web
+
+// Dev-режим (--dev): шаблоны и статика перечитываются с диска на
+// каждый запрос, а страницы ошибок показывают текст ошибки вместо
+// общего "Internal Server Error". Файлы берутся из каталога пакета
+// web — режим рассчитан на запуск из корня репозитория.
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+)
+
+const (
+	devTemplatesDir = "internal/web/templates"
+	devStaticDir    = "internal/web/static"
+)
+
+// checkDevDirs проверяет, что исходники шаблонов и статики доступны:
+// без них dev-режим молча отдавал бы пустые страницы.
+func checkDevDirs() error {
+	for _, dir := range []string{devTemplatesDir, devStaticDir} {
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("--dev требует запуска из корня репозитория: %w", err)
+		}
+	}
+	return nil
+}
+
+// devTemplates парсит шаблоны с диска. Ошибка парсинга возвращается
+// вызывающему: битый шаблон показывается на странице, а не роняет
+// сервер при старте.
+func (s *Server) devTemplates() (*template.Template, error) {
+	tmpl, err := template.New("").Funcs(s.funcMap).ParseGlob(devTemplatesDir + "/*.html")
+	if err != nil {
+		return nil, err
+	}
+	// Тема перекрывает шаблоны и в dev-режиме
+	if s.opts.ThemeDir != "" {
+		if err := loadThemeTemplates(tmpl, s.opts.ThemeDir); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
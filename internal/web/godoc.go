@@ -0,0 +1,43 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/godoc"
+)
+
+// SetGodoc подключает локальное зеркало документации stdlib
+// (страницы /godoc/...). Без сервиса маршрут отвечает 404, а ссылки
+// из уроков ведут на pkg.go.dev.
+func (s *Server) SetGodoc(g *godoc.Service) {
+	s.godocService = g
+}
+
+// handleGodoc — страница документации пакета стандартной библиотеки.
+// Путь пакета берётся из хвоста URL: /godoc/net/http → net/http.
+func (s *Server) handleGodoc(w http.ResponseWriter, r *http.Request) {
+	if s.godocService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	pkgPath := chi.URLParam(r, "*")
+	if pkgPath == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	doc, err := s.godocService.Package(r.Context(), pkgPath)
+	if err != nil {
+		// Любая ошибка go doc — неизвестный пакет; детали не утекают
+		http.NotFound(w, r)
+		return
+	}
+
+	s.render(w, "godoc.html", map[string]interface{}{
+		"Package": pkgPath,
+		"DocHTML": doc,
+	})
+}
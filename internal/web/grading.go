@@ -0,0 +1,88 @@
+package web
+
+// Ручная оценка capstone-проектов по рубрике. Критерии и максимумы
+// баллов определяются в коде рядом с проектами (projects.go),
+// проверяющий выставляет баллы с комментариями на /admin/grading,
+// сумма попадает в общую статистику (Stats.ProjectPoints).
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"golearning/internal/progress"
+)
+
+// gradedProject — проект с рубрикой и выставленными оценками.
+type gradedProject struct {
+	Project
+	Grades map[string]progress.ProjectGrade
+	Total  int
+	Max    int
+}
+
+// handleGrading — страница оценки проектов.
+func (s *Server) handleGrading(w http.ResponseWriter, r *http.Request) {
+	var projects []gradedProject
+	for _, p := range capstoneProjects() {
+		grades, err := s.progressRepo.GetProjectGrades(r.Context(), p.ID)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+
+		gp := gradedProject{Project: p, Grades: grades, Max: rubricMax(p.Rubric)}
+		for _, c := range p.Rubric {
+			if g, ok := grades[c.Code]; ok {
+				gp.Total += g.Points
+			}
+		}
+		projects = append(projects, gp)
+	}
+
+	s.render(w, "grading.html", map[string]interface{}{
+		"Projects": projects,
+	})
+}
+
+// handleGradingSave сохраняет баллы и комментарии по рубрике проекта.
+func (s *Server) handleGradingSave(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+
+	projectID := r.FormValue("project_id")
+	var rubric []RubricCriterion
+	for _, p := range capstoneProjects() {
+		if p.ID == projectID {
+			rubric = p.Rubric
+			break
+		}
+	}
+	if len(rubric) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	for _, c := range rubric {
+		raw := r.FormValue("points-" + c.Code)
+		if raw == "" {
+			continue
+		}
+		points, err := strconv.Atoi(raw)
+		if err != nil || points < 0 || points > c.MaxPoints {
+			s.badRequest(w, fmt.Sprintf("Баллы по критерию «%s» должны быть от 0 до %d", c.Title, c.MaxPoints))
+			return
+		}
+		comment := r.FormValue("comment-" + c.Code)
+		if err := s.progressRepo.SetProjectGrade(r.Context(), projectID, c.Code, points, comment); err != nil {
+			s.serverError(w, err)
+			return
+		}
+	}
+
+	s.recordAudit("projects.grade", "project", 0, fmt.Sprintf("Оценён проект %s", projectID))
+
+	http.Redirect(w, r, s.opts.BasePath+"/admin/grading", http.StatusSeeOther)
+}
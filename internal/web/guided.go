@@ -0,0 +1,111 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+)
+
+// guidedStepView — шаг пошагового задания с прогрессом для шаблона.
+type guidedStepView struct {
+	content.TaskStep
+	Index   int
+	Number  int // Index + 1, для заголовка «Шаг N»
+	Passed  bool
+	Current bool
+	Locked  bool
+}
+
+// guidedTaskView — состояние пошагового задания на странице урока.
+type guidedTaskView struct {
+	Steps       []guidedStepView
+	Current     int
+	CurrentCode string // код, с которого начинается текущий шаг
+	Done        bool   // все шаги пройдены
+}
+
+// buildGuidedView собирает шаги задания и прогресс по ним. Код для
+// текущего шага переносится с предыдущего: учащийся продолжает свою
+// программу, а не начинает заново.
+func (s *Server) buildGuidedView(ctx context.Context, task *content.Task) (guidedTaskView, error) {
+	steps, err := s.contentRepo.GetTaskStepsByTaskID(ctx, task.ID)
+	if err != nil {
+		return guidedTaskView{}, err
+	}
+	state, err := s.progressRepo.GetGuidedSteps(ctx, task.ID)
+	if err != nil {
+		return guidedTaskView{}, err
+	}
+
+	view := guidedTaskView{Current: len(steps)}
+	for i, st := range steps {
+		view.Steps = append(view.Steps, guidedStepView{
+			TaskStep: st,
+			Index:    i,
+			Number:   i + 1,
+			Passed:   state[i].Passed,
+		})
+	}
+
+	// Текущий шаг — первый непройденный
+	for i := range view.Steps {
+		if !view.Steps[i].Passed {
+			view.Current = i
+			break
+		}
+	}
+	view.Done = view.Current == len(view.Steps)
+	for i := range view.Steps {
+		view.Steps[i].Current = i == view.Current
+		view.Steps[i].Locked = i > view.Current
+	}
+
+	if !view.Done {
+		if st, ok := state[view.Current]; ok && st.Code != "" {
+			view.CurrentCode = st.Code
+		} else if view.Current > 0 {
+			view.CurrentCode = state[view.Current-1].Code
+		}
+		if view.CurrentCode == "" {
+			view.CurrentCode = task.StarterCode
+		}
+	}
+	return view, nil
+}
+
+// handleGuidedCheck — POST /api/tasks/{id}/guided/check: проверка
+// одного шага пошагового задания.
+func (s *Server) handleGuidedCheck(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid task ID")
+		return
+	}
+
+	var req struct {
+		Step int    `json:"step"`
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		s.badRequest(w, "Code is empty")
+		return
+	}
+
+	result, err := s.checker.CheckGuidedStep(r.Context(), id, req.Step, req.Code)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, result)
+}
@@ -1,26 +1,52 @@
 package web
 
 import (
-	"bytes"
+	"context"
 	"embed"
 	"encoding/json"
+	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"log"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/yuin/goldmark"
-	highlighting "github.com/yuin/goldmark-highlighting/v2"
-	"github.com/yuin/goldmark/extension"
-	"github.com/yuin/goldmark/renderer/html"
 
+	"golearning/internal/analytics"
+	"golearning/internal/audit"
+	"golearning/internal/auth"
+	"golearning/internal/branding"
+	"golearning/internal/config"
 	"golearning/internal/content"
+	"golearning/internal/errlog"
+	"golearning/internal/flags"
+	"golearning/internal/godoc"
+	"golearning/internal/interview"
+	"golearning/internal/issues"
+	"golearning/internal/kv"
+	"golearning/internal/linkcheck"
+	"golearning/internal/llm"
+	"golearning/internal/notify"
+	"golearning/internal/plan"
 	"golearning/internal/practice"
 	"golearning/internal/progress"
+	"golearning/internal/rag"
+	"golearning/internal/render"
+	"golearning/internal/sanitize"
+	"golearning/internal/sched"
+	"golearning/internal/selfupdate"
+	"golearning/internal/snippets"
+	"golearning/internal/storage"
+	"golearning/internal/translate"
+	"golearning/internal/tts"
+	"golearning/internal/version"
 )
 
 //go:embed templates/*.html
@@ -29,40 +55,228 @@ var templatesFS embed.FS
 //go:embed static/*
 var staticFS embed.FS
 
+// Options — настройки HTTP-сервера.
+type Options struct {
+	// CompressLevel — уровень сжатия ответов (0 — сжатие отключено).
+	CompressLevel int
+	// CompressMinSize — минимальный размер ответа для сжатия в байтах.
+	CompressMinSize int
+	// CompressTypes — content-type, которые имеет смысл сжимать.
+	CompressTypes []string
+	// BasePath — префикс пути при работе за reverse proxy (например "/golearn").
+	BasePath string
+	// TrustProxy — доверять заголовкам X-Forwarded-For/X-Forwarded-Proto.
+	TrustProxy bool
+	// RateLimitPerMin — максимум API-запросов с одного IP в минуту (0 — без лимита).
+	RateLimitPerMin int
+	// AccessLog — куда писать access-лог: "stdout" (по умолчанию),
+	// "off" или путь к файлу.
+	AccessLog string
+	// AccessLogSample — писать каждый N-й запрос (0 и 1 — все).
+	AccessLogSample int
+	// PublicMode — публичный режим: уроки открытых модулей читаются
+	// без входа, практика и прогресс требуют ключ доступа.
+	PublicMode bool
+	// AccessKey — ключ доступа для входа в публичном режиме.
+	AccessKey string
+	// CohortKey — ключ когорты: модули cohort-only и админка.
+	CohortKey string
+	// DevMode — режим разработки: шаблоны и статика перечитываются
+	// с диска на каждый запрос, ошибки показываются на странице.
+	DevMode bool
+	// ThemeDir — каталог темы: файлы из templates/ и static/ внутри
+	// него перекрывают встроенные шаблоны и статику.
+	ThemeDir string
+}
+
 // Server — HTTP-сервер.
 type Server struct {
-	contentRepo  *content.Repository
-	progressRepo *progress.Repository
-	checker      *practice.Checker
-	templates    *template.Template
+	contentRepo      content.Store
+	progressRepo     progress.Store
+	checker          *practice.Checker
+	templates        *template.Template
+	funcMap          template.FuncMap
+	staticFiles      fs.FS
+	assetHashes      map[string]string
+	notifier         *notify.Webhooks
+	milestones       *notify.Milestones
+	xapi             *notify.XAPI
+	snippetsRepo     *snippets.Repository
+	interviewRepo    *interview.Repository
+	auditLog         *audit.Log
+	identities       *auth.Repository
+	oauth            config.OAuth
+	schemaVersion    string
+	kvStore          kv.Store
+	flagsService     *flags.Service
+	brandingService  *branding.Service
+	godocService     *godoc.Service
+	issuesRepo       *issues.Repository
+	errorLog         *errlog.Log
+	linkChecker      *linkcheck.Checker
+	analytics        *analytics.Service
+	tracker          *analytics.Tracker
+	scheduler        *sched.Scheduler
+	updateChecker    *selfupdate.Checker
+	askService       *rag.Service
+	llmClient        *llm.Client
+	translations     *translate.Service
+	ttsService       *tts.Service
+	planner          *plan.Planner
+	artifacts        storage.Store
+	uploads          map[string]*upload
+	uploadsMu        sync.Mutex
+	accessLogger     *accessLogger
+	markdown         *render.Renderer
+	markdownCache    *mdCache
+	toolchainVersion string
+	opts             Options
+}
+
+// SetErrors подключает журнал ошибок (страница /admin/errors).
+func (s *Server) SetErrors(l *errlog.Log) {
+	s.errorLog = l
+}
+
+// SetAnalytics подключает статистику обучения (страница /admin/analytics).
+func (s *Server) SetAnalytics(a *analytics.Service) {
+	s.analytics = a
+}
+
+// SetTracker подключает журнал событий обучения.
+func (s *Server) SetTracker(t *analytics.Tracker) {
+	s.tracker = t
+}
+
+// track записывает событие обучения, если трекер подключен.
+func (s *Server) track(ctx context.Context, kind string, lessonID, taskID int64) {
+	if s.tracker == nil {
+		return
+	}
+	s.tracker.Track(ctx, kind, lessonID, taskID)
+}
+
+// SetLinkChecker подключает журнал битых ссылок (страница /admin/links).
+func (s *Server) SetLinkChecker(c *linkcheck.Checker) {
+	s.linkChecker = c
+}
+
+// SetFlags подключает сервис фича-флагов (страница /admin/flags).
+func (s *Server) SetFlags(f *flags.Service) {
+	s.flagsService = f
+}
+
+// flagEnabled сообщает, включён ли фича-флаг. Без подключённого сервиса
+// действуют значения по умолчанию из реестра флагов.
+func (s *Server) flagEnabled(ctx context.Context, name string) bool {
+	if s.flagsService == nil {
+		return flags.DefaultEnabled(name)
+	}
+	return s.flagsService.Enabled(ctx, name)
+}
+
+// SetKV подключает key/value-хранилище (rate-лимиты, сессии).
+// По умолчанию используется in-memory реализация.
+func (s *Server) SetKV(store kv.Store) {
+	s.kvStore = store
+}
+
+// kv возвращает подключённое key/value-хранилище или in-memory по умолчанию.
+func (s *Server) kv() kv.Store {
+	if s.kvStore == nil {
+		s.kvStore = kv.NewMemory()
+	}
+	return s.kvStore
+}
+
+// SetSchemaVersion задаёт текущую версию схемы БД для /healthz.
+func (s *Server) SetSchemaVersion(version string) {
+	s.schemaVersion = version
+}
+
+// SetNotifier подключает отправку вебхуков о событиях прогресса.
+func (s *Server) SetNotifier(n *notify.Webhooks) {
+	s.notifier = n
+}
+
+// SetMilestones подключает уведомления о достижениях в мессенджеры.
+func (s *Server) SetMilestones(m *notify.Milestones) {
+	s.milestones = m
+}
+
+// SetXAPI подключает отправку xAPI-стейтментов в LRS.
+func (s *Server) SetXAPI(x *notify.XAPI) {
+	s.xapi = x
 }
 
 // NewServer создаёт новый сервер.
-func NewServer(contentRepo *content.Repository, progressRepo *progress.Repository, checker *practice.Checker) (*Server, error) {
-	// Инициализируем Markdown парсер с подсветкой синтаксиса
-	md := goldmark.New(
-		goldmark.WithExtensions(
-			extension.GFM, // GitHub Flavored Markdown
-			highlighting.NewHighlighting(
-				highlighting.WithStyle("monokai"),
-			),
-		),
-		goldmark.WithRendererOptions(
-			html.WithUnsafe(), // Разрешаем HTML в Markdown
-		),
-	)
+func NewServer(contentRepo content.Store, progressRepo progress.Store, checker *practice.Checker, opts Options) (*Server, error) {
+	if opts.CompressMinSize <= 0 {
+		opts.CompressMinSize = defaultCompressMinSize
+	}
+	if len(opts.CompressTypes) == 0 {
+		opts.CompressTypes = defaultCompressTypes
+	}
+	opts.BasePath = normalizeBasePath(opts.BasePath)
+
+	// Сервер создаётся заранее: часть функций шаблонов замыкается на него
+	srv := &Server{}
+
+	// Markdown рендерится через общий пакет render (GFM, сноски,
+	// admonition-блоки, якоря заголовков, подсветка синтаксиса)
+	srv.markdown = render.New()
+	srv.markdownCache = newMDCache()
+
+	// Хэшируем статику для cache-busting; в dev-режиме статика
+	// читается с диска и отдаётся под обычными именами без кэша
+	staticSubFS, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, err
+	}
+	assetHashes := map[string]string{}
+	if opts.DevMode {
+		if err := checkDevDirs(); err != nil {
+			return nil, err
+		}
+		staticSubFS = os.DirFS(devStaticDir)
+	} else {
+		assetHashes, err = hashStaticAssets(staticSubFS)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.ThemeDir != "" {
+		staticSubFS, err = overlayThemeStatic(opts.ThemeDir, staticSubFS, assetHashes)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Загружаем шаблоны
 	funcMap := template.FuncMap{
+		"asset": func(name string) string {
+			if hashed, ok := assetHashes[name]; ok {
+				return opts.BasePath + "/static/" + hashed
+			}
+			return opts.BasePath + "/static/" + name
+		},
+		"url": func(p string) string {
+			return opts.BasePath + p
+		},
+		"basePath": func() string {
+			return opts.BasePath
+		},
 		"safeHTML": func(s string) template.HTML {
 			return template.HTML(s)
 		},
+		"safeURL": func(s string) template.URL {
+			return template.URL(s)
+		},
 		"markdown": func(s string) template.HTML {
-			var buf bytes.Buffer
-			if err := md.Convert([]byte(s), &buf); err != nil {
-				return template.HTML("<p>Ошибка рендеринга</p>")
-			}
-			return template.HTML(buf.String())
+			return srv.renderMarkdown(s)
+		},
+		"clozeParts": func(code string) []string {
+			return strings.Split(code, practice.ClozePlaceholder)
 		},
 		"sectionIcon": func(kind content.SectionKind) string {
 			switch kind {
@@ -80,6 +294,8 @@ func NewServer(contentRepo *content.Repository, progressRepo *progress.Repositor
 				return "🔗"
 			case content.SectionExtra:
 				return "📚"
+			case content.SectionCheatsheet:
+				return "🗒️"
 			default:
 				return "📄"
 			}
@@ -104,6 +320,41 @@ func NewServer(contentRepo *content.Repository, progressRepo *progress.Repositor
 				return "status-new"
 			}
 		},
+		"flagEnabled": func(name string) bool {
+			return srv.flagEnabled(context.Background(), name)
+		},
+		"brand": func() branding.Branding {
+			return srv.brand(context.Background())
+		},
+		"courseList": func() []content.Course {
+			// Переключатель курсов в шапке; ListCourses кеширует выборку
+			courses, _ := srv.contentRepo.ListCourses(context.Background())
+			return courses
+		},
+		"publicMode": func() bool {
+			return opts.PublicMode
+		},
+		"appVersion": func() string {
+			return version.Version
+		},
+		"goUnsupported": func(minGo string) bool {
+			return srv.goUnsupported(minGo)
+		},
+		"goToolchain": func() string {
+			return srv.toolchainVersion
+		},
+		"askEnabled": func() bool {
+			return srv.askService != nil
+		},
+		"ttsEnabled": func() bool {
+			return srv.ttsService != nil
+		},
+		"latestRelease": func() *selfupdate.Release {
+			if srv.updateChecker == nil {
+				return nil
+			}
+			return srv.updateChecker.Latest()
+		},
 		"mulf": func(a, b float64) float64 {
 			return a * b
 		},
@@ -115,17 +366,42 @@ func NewServer(contentRepo *content.Repository, progressRepo *progress.Repositor
 		},
 	}
 
+	srv.funcMap = funcMap
 	tmpl, err := template.New("").Funcs(funcMap).ParseFS(templatesFS, "templates/*.html")
 	if err != nil {
 		return nil, err
 	}
+	if opts.ThemeDir != "" {
+		if err := loadThemeTemplates(tmpl, opts.ThemeDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// Настраиваем access-лог: stdout, файл или выключен
+	if opts.AccessLog != "off" {
+		out := io.Writer(os.Stdout)
+		if opts.AccessLog != "" && opts.AccessLog != "stdout" {
+			f, err := os.OpenFile(opts.AccessLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("open access log: %w", err)
+			}
+			out = f
+		}
+		sample := int64(opts.AccessLogSample)
+		if sample < 1 {
+			sample = 1
+		}
+		srv.accessLogger = &accessLogger{encoder: json.NewEncoder(out), sample: sample}
+	}
 
-	return &Server{
-		contentRepo:  contentRepo,
-		progressRepo: progressRepo,
-		checker:      checker,
-		templates:    tmpl,
-	}, nil
+	srv.contentRepo = contentRepo
+	srv.progressRepo = progressRepo
+	srv.checker = checker
+	srv.templates = tmpl
+	srv.staticFiles = staticSubFS
+	srv.assetHashes = assetHashes
+	srv.opts = opts
+	return srv, nil
 }
 
 // Router возвращает HTTP-роутер.
@@ -133,36 +409,166 @@ func (s *Server) Router() http.Handler {
 	r := chi.NewRouter()
 
 	// Middleware
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Compress(5))
+	if s.accessLogger != nil {
+		r.Use(s.accessLogMiddleware(s.accessLogger))
+	}
+	r.Use(s.recoverMiddleware)
+	if s.opts.TrustProxy {
+		r.Use(middleware.RealIP)
+	}
+	if s.opts.CompressLevel > 0 {
+		r.Use(compressMiddleware(s.opts.CompressLevel, s.opts.CompressMinSize, s.opts.CompressTypes))
+	}
+	if s.opts.RateLimitPerMin > 0 {
+		r.Use(s.rateLimitMiddleware(s.kv(), s.opts.RateLimitPerMin))
+	}
 
-	// Статические файлы
-	staticSubFS, _ := fs.Sub(staticFS, "static")
-	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.FS(staticSubFS))))
+	// Статические файлы (с поддержкой имён с хэшем контента)
+	r.Get("/static/*", s.handleStatic)
+	r.Get("/healthz", s.handleHealthz)
 
 	// HTML страницы
 	r.Get("/", s.handleIndex)
+	r.Get("/courses/{slug}", s.handleCourse)
 	r.Get("/lessons/{slug}", s.handleLesson)
+	r.Get("/lessons/{slug}/read", s.handleLessonRead)
+	r.Get("/lessons/{slug}/audio/{sectionID}.mp3", s.handleSectionAudio)
 	r.Get("/search", s.handleSearch)
+	r.Get("/ask", s.handleAsk)
+	r.Get("/godoc/*", s.handleGodoc)
 	r.Get("/projects", s.handleProjects)
+	r.Get("/projects/{id}/starter.zip", s.handleProjectStarter)
+	r.Get("/mistakes", s.handleMistakes)
+	r.Get("/katas", s.handleKatas)
+	r.Get("/typing", s.handleTyping)
+	r.Get("/interview", s.handleInterview)
+	r.Get("/snippets", s.handleSnippets)
+	r.Get("/vocabulary", s.handleVocabulary)
+	r.Get("/cheatsheets", s.handleCheatsheets)
+	r.Get("/cheatsheets/{slug}.md", s.handleCheatsheetExport)
+
+	// Вход по ключу доступа (публичный режим)
+	r.Get("/login", s.handleLogin)
+	r.Post("/login", s.handleLoginPost)
+	r.Post("/logout", s.handleLogout)
+	r.Get("/login/oauth/{provider}", s.handleOAuthStart)
+	r.Get("/login/oauth/{provider}/callback", s.handleOAuthCallback)
+
+	// Практика, прогресс и прочее пользовательское состояние:
+	// в публичном режиме требуют входа по ключу
+	r.Group(func(r chi.Router) {
+		r.Use(s.requirePractice)
+
+		r.Post("/plan/generate", s.handleGeneratePlan)
+		r.Post("/plan/clear", s.handleClearPlan)
+		r.Post("/api/katas/{slug}/check", s.handleKataCheck)
+		r.Post("/api/typing/{id}/result", s.handleTypingResult)
+		r.Post("/api/interview/review", s.handleInterviewReview)
+		r.Post("/snippets", s.handleCreateSnippet)
+		r.Post("/snippets/{id}/delete", s.handleDeleteSnippet)
+		r.Post("/vocabulary/add", s.handleVocabularyAdd)
+		r.Post("/vocabulary/{id}/review", s.handleVocabularyReview)
+		r.Post("/vocabulary/{id}/delete", s.handleVocabularyDelete)
+
+		r.Post("/api/progress/lesson/{id}", s.handleUpdateProgress)
+		r.Post("/api/progress/reset", s.handleResetProgress)
+		r.Post("/api/notes/lesson/{id}", s.handleSaveNote)
+		r.Post("/api/reader/prefs", s.handleSaveReaderPrefs)
+		r.Post("/api/dashboard/widgets", s.handleDashboardWidgets)
+		r.Post("/api/issues", s.handleIssueCreate)
+		r.Post("/api/ask", s.handleAskQuestion)
+		r.Post("/api/submissions/{id}/llm-hint", s.handleLLMHint)
+		r.Get("/api/changelog", s.handleChangelogUnseen)
+		r.Post("/api/changelog/ack", s.handleChangelogAck)
+		r.Get("/api/notes/lesson/{id}/revisions", s.handleListNoteRevisions)
+		r.Post("/api/notes/lesson/{id}/restore", s.handleRestoreNoteRevision)
+		r.Post("/api/run", s.handleRun)
+		r.Post("/api/check", s.handleCheck)
+		r.Post("/api/tasks/{id}/guided/check", s.handleGuidedCheck)
+		r.Post("/api/tasks/{id}/reviews", s.handleReviewSave)
+		r.Post("/api/tasks/{id}/hint", s.handleHintUsed)
+		r.Post("/api/projects/{id}/milestones", s.handleProjectMilestone)
+		r.Post("/api/practice/session", s.handleCreatePracticeSession)
+		r.Post("/api/practice/session/{id}/finish", s.handleFinishPracticeSession)
+	})
+
+	// Админка: в публичном режиме только по ключу когорты
+	r.Group(func(r chi.Router) {
+		r.Use(s.requireCohort)
+
+		r.Get("/admin/trash", s.handleTrash)
+		r.Post("/admin/trash/restore", s.handleTrashRestore)
+		r.Post("/admin/trash/purge", s.handleTrashPurge)
+		r.Get("/admin/audit", s.handleAuditLog)
+		r.Get("/admin/errors", s.handleErrors)
+		r.Get("/admin/issues", s.handleIssues)
+		r.Post("/admin/issues/status", s.handleIssueStatus)
+		r.Get("/admin/links", s.handleDeadLinks)
+		r.Get("/admin/analytics", s.handleAnalytics)
+		r.Get("/admin/jobs", s.handleJobs)
+		r.Post("/admin/jobs/run", s.handleJobRun)
+		r.Get("/admin/grading", s.handleGrading)
+		r.Post("/admin/grading/save", s.handleGradingSave)
+		r.Get("/admin/flags", s.handleFlags)
+		r.Post("/admin/flags/toggle", s.handleFlagToggle)
+		r.Get("/admin/translations", s.handleTranslations)
+		r.Post("/admin/translations/review", s.handleTranslationReview)
+		r.Get("/admin/branding", s.handleBranding)
+		r.Post("/admin/branding/save", s.handleBrandingSave)
+		r.Get("/admin/visibility", s.handleVisibility)
+		r.Post("/admin/visibility/save", s.handleVisibilitySave)
+		r.Get("/admin/tokens", s.handleTokens)
+		r.Post("/admin/tokens/create", s.handleTokenCreate)
+		r.Post("/admin/tokens/limit", s.handleTokenLimit)
+		r.Post("/admin/tokens/delete", s.handleTokenDelete)
+		r.Get("/admin/security", s.handleSecurity)
+		r.Post("/admin/security/totp/enroll", s.handleTOTPEnroll)
+		r.Post("/admin/security/totp/confirm", s.handleTOTPConfirm)
+		r.Post("/admin/security/totp/disable", s.handleTOTPDisable)
+		r.Post("/admin/security/enforce", s.handleTOTPEnforce)
+
+		// Админский API: чанковая загрузка больших Markdown-уроков
+		r.Post("/api/admin/tasks/preview", s.handleTaskPreview)
+		r.Post("/api/admin/lessons/upload", s.handleUploadStart)
+		r.Post("/api/admin/lessons/upload/{id}", s.handleUploadChunk)
+		r.Post("/api/admin/lessons/upload/{id}/commit", s.handleUploadCommit)
+	})
+
+	// REST API v1 (для PWA и внешних клиентов)
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(s.apiTokenMiddleware)
+		r.Get("/lessons", s.handleAPIListLessons)
+		r.Get("/lessons/{slug}", s.handleAPIGetLesson)
+		r.Get("/lessons/{slug}/outline", s.handleAPILessonOutline)
+		r.Get("/modules/{id}/lessons", s.handleAPIModuleLessons)
+		r.Get("/tasks/{id}/stats", s.handleAPITaskStats)
+		r.Get("/examples", s.handleAPIExamples)
+		r.Get("/vocabulary", s.handleAPIVocabulary)
+		r.Get("/version", s.handleAPIVersion)
+	})
 
-	// API
-	r.Post("/api/progress/lesson/{id}", s.handleUpdateProgress)
-	r.Post("/api/progress/reset", s.handleResetProgress)
-	r.Post("/api/notes/lesson/{id}", s.handleSaveNote)
-	r.Post("/api/run", s.handleRun)
-	r.Post("/api/check", s.handleCheck)
+	// При работе за reverse proxy монтируем всё под префиксом пути
+	if s.opts.BasePath != "" {
+		outer := chi.NewRouter()
+		outer.Mount(s.opts.BasePath, r)
+		outer.Get("/", func(w http.ResponseWriter, req *http.Request) {
+			http.Redirect(w, req, s.opts.BasePath+"/", http.StatusMovedPermanently)
+		})
+		return outer
+	}
 
 	return r
 }
 
 // --- Page Handlers ---
 
+// indexLessonsPerModule — максимум уроков модуля на главной странице.
+const indexLessonsPerModule = 200
+
 // handleIndex — главная страница со списком уроков.
 func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 	// Загружаем все курсы
-	courses, err := s.contentRepo.ListCourses()
+	courses, err := s.contentRepo.ListCourses(r.Context())
 	if err != nil {
 		s.serverError(w, err)
 		return
@@ -184,7 +590,7 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 
 	for _, course := range courses {
 		// Загружаем модули для курса
-		modules, err := s.contentRepo.ListModulesByCourseID(course.ID)
+		modules, err := s.contentRepo.ListModulesByCourseID(r.Context(), course.ID)
 		if err != nil {
 			s.serverError(w, err)
 			return
@@ -192,7 +598,13 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 
 		var modulesWithLessons []ModuleWithLessons
 		for _, m := range modules {
-			lessons, err := s.contentRepo.ListLessonsByModuleID(m.ID)
+			// Недоступные посетителю модули не попадают в оглавление
+			if !canViewModule(s.viewer(r), m.Visibility) {
+				continue
+			}
+			// Keyset-пагинация ограничивает вес главной страницы
+			// на больших импортированных курсах
+			lessons, err := s.contentRepo.ListLessonsPage(r.Context(), m.ID, 0, indexLessonsPerModule)
 			if err != nil {
 				s.serverError(w, err)
 				return
@@ -210,23 +622,113 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Загружаем прогресс
-	progressMap, _ := s.progressRepo.GetAllProgress()
-	stats, _ := s.progressRepo.GetStats()
+	progressMap, _ := s.progressRepo.GetAllProgress(r.Context())
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	// Раскладка виджетов: данные собираются только для включённых
+	widgets, disabledWidgets := s.dashboardLayout(r)
 
 	data := map[string]interface{}{
-		"Courses":  coursesWithModules,
-		"Progress": progressMap,
-		"Stats":    stats,
+		"Courses":         coursesWithModules,
+		"Progress":        progressMap,
+		"Stats":           stats,
+		"Widgets":         widgets,
+		"DisabledWidgets": disabledWidgets,
+		"PlanError":       r.URL.Query().Get("plan_error"),
+	}
+
+	for _, widget := range widgets {
+		switch widget.ID {
+		case "heatmap":
+			data["Heatmap"] = s.activityHeatmap(r)
+		case "next":
+			// Первый непройденный из видимых уроков оглавления
+		nextSearch:
+			for _, c := range coursesWithModules {
+				for _, m := range c.Modules {
+					for _, l := range m.Lessons {
+						if p := progressMap[l.ID]; p == nil || p.Status != progress.StatusDone {
+							data["NextLesson"] = l
+							break nextSearch
+						}
+					}
+				}
+			}
+			// Отложенные уроки: начаты, но давно без движения
+			staleLessons, _ := s.progressRepo.GetStaleInProgress(r.Context(), progress.StaleReadingAfter)
+			data["StaleLessons"] = staleLessons
+		case "reviews":
+			dueReviews, _ := s.progressRepo.ListDueReviews(r.Context(), 10)
+			dueTerms, _ := s.progressRepo.DueVocabulary(r.Context(), 5)
+			data["DueReviews"] = dueReviews
+			data["DueTerms"] = dueTerms
+		case "submissions":
+			recent, _ := s.progressRepo.ListRecentSubmissions(r.Context(), 10)
+			data["RecentSubmissions"] = recent
+		case "goals":
+			if s.planner != nil {
+				readingPlan, _ := s.planner.Current(r.Context())
+				data["Plan"] = readingPlan
+			}
+		}
 	}
 
 	s.render(w, "index.html", data)
 }
 
+// heatmapDay — день теплокарты активности: уровень 0..4 задаёт
+// насыщенность ячейки в CSS.
+type heatmapDay struct {
+	Day   string
+	Count int
+	Level int
+}
+
+// activityHeatmap собирает теплокарту активности за последние 13 недель
+// и текущую серию дней подряд из дневного роллапа аналитики.
+func (s *Server) activityHeatmap(r *http.Request) map[string]interface{} {
+	if s.analytics == nil {
+		return nil
+	}
+	daily, err := s.analytics.Daily(r.Context(), 13*7)
+	if err != nil {
+		return nil
+	}
+
+	days := make([]heatmapDay, 0, len(daily))
+	streak := 0
+	for _, d := range daily {
+		count := d.LessonsTouched + d.Submissions + d.TasksCompleted
+		level := 0
+		switch {
+		case count >= 10:
+			level = 4
+		case count >= 6:
+			level = 3
+		case count >= 3:
+			level = 2
+		case count >= 1:
+			level = 1
+		}
+		if count > 0 {
+			streak++
+		} else {
+			streak = 0
+		}
+		days = append(days, heatmapDay{Day: d.Day, Count: count, Level: level})
+	}
+
+	return map[string]interface{}{
+		"Days":   days,
+		"Streak": streak,
+	}
+}
+
 // handleLesson — страница урока.
 func (s *Server) handleLesson(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
-	lesson, err := s.contentRepo.GetLessonBySlug(slug)
+	lesson, err := s.contentRepo.GetLessonBySlug(r.Context(), slug)
 	if err != nil {
 		s.serverError(w, err)
 		return
@@ -236,18 +738,31 @@ func (s *Server) handleLesson(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Видимость модуля в публичном режиме
+	role := s.viewer(r)
+	if !canViewModule(role, lesson.Module.Visibility) {
+		s.redirectToLogin(w, r, "/lessons/"+lesson.Slug)
+		return
+	}
+
 	// Загружаем прогресс и заметки
-	prog, _ := s.progressRepo.GetProgress(lesson.ID)
-	note, _ := s.progressRepo.GetNote(lesson.ID)
+	prog, _ := s.progressRepo.GetProgress(r.Context(), lesson.ID)
+	note, _ := s.progressRepo.GetNote(r.Context(), lesson.ID)
 
-	// Автоматически отмечаем как "в процессе чтения"
-	if prog.Status == progress.StatusNew {
-		s.progressRepo.SetStatus(lesson.ID, progress.StatusReading)
+	// Автоматически отмечаем как "в процессе чтения" — но не для гостей:
+	// прогресс принадлежит владельцу, а не случайному посетителю
+	if prog.Status == progress.StatusNew && role.canPractice() {
+		s.progressRepo.SetStatus(r.Context(), lesson.ID, progress.StatusReading)
 		prog.Status = progress.StatusReading
 	}
 
+	if s.xapi != nil {
+		s.xapi.LessonExperienced(lesson.Slug, lesson.Title)
+	}
+	s.track(r.Context(), analytics.EventLessonViewed, lesson.ID, 0)
+
 	// Загружаем соседние уроки для навигации
-	allLessons, _ := s.contentRepo.ListAllLessons()
+	allLessons, _ := s.contentRepo.ListAllLessons(r.Context())
 	var prevLesson, nextLesson *content.Lesson
 	for i, l := range allLessons {
 		if l.ID == lesson.ID {
@@ -262,58 +777,178 @@ func (s *Server) handleLesson(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Загружаем статистику для шапки
-	stats, _ := s.progressRepo.GetStats()
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	// Условные запросы: отдаём 304, если контент, прогресс и заметка не менялись
+	if writeConditional(w, r, lessonPageETag(lesson, prog, note, stats), lesson.UpdatedAt) {
+		return
+	}
 
-	// Загружаем список выполненных заданий
+	// Загружаем список засчитанных заданий
 	completedTasks := make(map[int64]bool)
-	if lesson.Tasks != nil {
-		for _, task := range lesson.Tasks {
-			if completed, _ := s.progressRepo.IsTaskSolvedSuccessfully(task.ID); completed {
-				completedTasks[task.ID] = true
-			}
+	if completions, err := s.progressRepo.GetTaskCompletionPoints(r.Context(), lesson.ID); err == nil {
+		for taskID := range completions {
+			completedTasks[taskID] = true
+		}
+	}
+
+	// Оглавление: подзаголовки Markdown внутри каждой секции с теми же
+	// якорями, что проставляет рендерер
+	// Состояние пошаговых заданий: шаги, прогресс и код текущего шага
+	guidedState := make(map[int64]guidedTaskView)
+	for i := range lesson.Tasks {
+		if lesson.Tasks[i].Kind != content.TaskKindGuided {
+			continue
+		}
+		if view, err := s.buildGuidedView(r.Context(), &lesson.Tasks[i]); err == nil {
+			guidedState[lesson.Tasks[i].ID] = view
+		}
+	}
+
+	// Код-ревью: решённые задания с кодом получают анонимные решения
+	// на рецензию и показывают уже полученные рецензии
+	reviewState := make(map[int64]reviewTaskView)
+	for i := range lesson.Tasks {
+		task := &lesson.Tasks[i]
+		if !role.canPractice() || !completedTasks[task.ID] || task.Kind == content.TaskKindPredictOutput {
+			continue
+		}
+		if view, err := s.buildReviewView(r.Context(), task.ID); err == nil {
+			reviewState[task.ID] = view
+		}
+	}
+
+	// Обновлённый начальный код: если автор правил задание после
+	// последней отправки, предупреждаем и даём вернуть свой код
+	starterState := make(map[int64]starterTaskView)
+	for i := range lesson.Tasks {
+		if !role.canPractice() {
+			break
+		}
+		if view, ok := s.buildStarterView(r.Context(), &lesson.Tasks[i]); ok {
+			starterState[lesson.Tasks[i].ID] = view
+		}
+	}
+
+	// Перевод урока (?lang=): накладывается до сборки оглавлений,
+	// чтобы они строились по переведённому тексту
+	trans := s.applyTranslation(r, lesson)
+
+	sectionOutlines := make(map[int64][]render.Heading, len(lesson.Sections))
+	for _, sec := range lesson.Sections {
+		if outline := s.sectionOutline(sec); len(outline) > 0 {
+			sectionOutlines[sec.ID] = outline
 		}
 	}
 
 	data := map[string]interface{}{
-		"Lesson":         lesson,
-		"Progress":       prog,
-		"Note":           note,
-		"PrevLesson":     prevLesson,
-		"NextLesson":     nextLesson,
-		"Stats":          stats,
-		"CompletedTasks": completedTasks,
+		"Lesson":          lesson,
+		"Progress":        prog,
+		"Note":            note,
+		"NoteWordCount":   len(strings.Fields(note.NoteMD)),
+		"PrevLesson":      prevLesson,
+		"NextLesson":      nextLesson,
+		"Stats":           stats,
+		"CompletedTasks":  completedTasks,
+		"GuidedState":     guidedState,
+		"ReviewState":     reviewState,
+		"StarterState":    starterState,
+		"ReviewBonus":     progress.PeerReviewBonus,
+		"CanPractice":     role.canPractice(),
+		"SectionOutlines": sectionOutlines,
+		"Translation":     trans,
 	}
 
 	s.render(w, "lesson.html", data)
 }
 
 // handleSearch — страница поиска.
+// Параметр scope переключает область: notes — заметки пользователя,
+// code — только блоки кода из уроков.
 func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
+	scope := r.URL.Query().Get("scope")
 
-	var results []content.SearchResult
-	var err error
+	// Заметки принадлежат владельцу: гостям область недоступна,
+	// как и мутации заметок за requirePractice
+	if scope == "notes" && !s.viewer(r).canPractice() {
+		s.redirectToLogin(w, r, r.URL.RequestURI())
+		return
+	}
+
+	// Результаты всех областей имеют одинаковые поля для шаблона
+	var results interface{}
+	var count int
 
 	if query != "" {
-		results, err = s.contentRepo.Search(query, 50)
-		if err != nil {
-			log.Printf("Search error: %v", err)
-			// Не показываем ошибку пользователю, просто пустые результаты
+		switch scope {
+		case "notes":
+			noteResults, err := s.progressRepo.SearchNotes(r.Context(), query, 50)
+			if err != nil {
+				log.Printf("Notes search error: %v", err)
+				// Не показываем ошибку пользователю, просто пустые результаты
+			}
+			results, count = noteResults, len(noteResults)
+		case "code":
+			codeResults, err := s.contentRepo.SearchCode(r.Context(), query, 50)
+			if err != nil {
+				log.Printf("Code search error: %v", err)
+			}
+			// Сниппеты закрытых модулей не утекают гостям
+			kept := codeResults[:0]
+			for _, res := range codeResults {
+				if s.canViewLesson(r, res.LessonID) {
+					kept = append(kept, res)
+				}
+			}
+			results, count = kept, len(kept)
+		default:
+			lessonResults, err := s.contentRepo.Search(r.Context(), query, 50)
+			if err != nil {
+				log.Printf("Search error: %v", err)
+			}
+			kept := lessonResults[:0]
+			for _, res := range lessonResults {
+				if s.canViewLesson(r, res.LessonID) {
+					kept = append(kept, res)
+				}
+			}
+			results, count = kept, len(kept)
 		}
 	}
 
 	// Загружаем статистику для шапки
-	stats, _ := s.progressRepo.GetStats()
+	stats, _ := s.progressRepo.GetStats(r.Context())
 
 	data := map[string]interface{}{
-		"Query":   query,
-		"Results": results,
-		"Stats":   stats,
+		"Query":       query,
+		"Scope":       scope,
+		"Results":     results,
+		"ResultCount": count,
+		"Stats":       stats,
 	}
 
 	s.render(w, "search.html", data)
 }
 
+// handleMistakes — журнал ошибок: частые категории неудачных попыток по модулям.
+func (s *Server) handleMistakes(w http.ResponseWriter, r *http.Request) {
+	mistakes, err := s.progressRepo.MistakeJournal(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Mistakes": mistakes,
+		"Stats":    stats,
+	}
+
+	s.render(w, "mistakes.html", data)
+}
+
 // --- API Handlers ---
 
 // handleUpdateProgress обновляет прогресс урока.
@@ -335,17 +970,69 @@ func (s *Server) handleUpdateProgress(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Используем SetStatus чтобы не затереть очки
-	if err := s.progressRepo.SetStatus(id, progress.Status(req.Status)); err != nil {
+	if err := s.progressRepo.SetStatus(r.Context(), id, progress.Status(req.Status)); err != nil {
 		s.serverError(w, err)
 		return
 	}
 
+	if progress.Status(req.Status) == progress.StatusDone {
+		s.notifyLessonCompleted(r.Context(), id)
+	}
+
 	s.jsonResponse(w, map[string]interface{}{"success": true})
 }
 
+// notifyLessonCompleted отправляет вебхуки lesson.completed
+// и module.completed (если завершён последний урок модуля).
+func (s *Server) notifyLessonCompleted(ctx context.Context, lessonID int64) {
+	if s.notifier == nil && s.milestones == nil {
+		return
+	}
+
+	lesson, err := s.contentRepo.GetLessonByID(ctx, lessonID)
+	if err != nil || lesson == nil {
+		return
+	}
+
+	if s.notifier != nil {
+		s.notifier.Notify(notify.EventLessonCompleted, map[string]interface{}{
+			"lesson_id": lesson.ID,
+			"slug":      lesson.Slug,
+			"title":     lesson.Title,
+		})
+	}
+
+	// Проверяем, завершён ли модуль целиком
+	lessons, err := s.contentRepo.ListLessonsByModuleID(ctx, lesson.ModuleID)
+	if err != nil {
+		return
+	}
+	progressMap, err := s.progressRepo.GetAllProgress(ctx)
+	if err != nil {
+		return
+	}
+	for _, l := range lessons {
+		p, ok := progressMap[l.ID]
+		if !ok || p.Status != progress.StatusDone {
+			return
+		}
+	}
+
+	if s.notifier != nil {
+		s.notifier.Notify(notify.EventModuleCompleted, map[string]interface{}{
+			"module_id":    lesson.ModuleID,
+			"module_title": lesson.Module.Title,
+			"lessons":      len(lessons),
+		})
+	}
+	if s.milestones != nil {
+		s.milestones.ModuleCompleted(lesson.Module.Title, len(lessons))
+	}
+}
+
 // handleResetProgress сбрасывает весь прогресс обучения.
 func (s *Server) handleResetProgress(w http.ResponseWriter, r *http.Request) {
-	if err := s.progressRepo.ResetAllProgress(); err != nil {
+	if err := s.progressRepo.ResetAllProgress(r.Context()); err != nil {
 		s.serverError(w, err)
 		return
 	}
@@ -374,16 +1061,95 @@ func (s *Server) handleSaveNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.progressRepo.SaveNote(id, req.Note); err != nil {
+	// Пользовательский Markdown чистится от опасных вкраплений при сохранении
+	req.Note = sanitize.Markdown(req.Note)
+
+	if err := s.progressRepo.SaveNote(r.Context(), id, req.Note); err != nil {
 		s.serverError(w, err)
 		return
 	}
 
+	s.track(r.Context(), analytics.EventNoteSaved, id, 0)
+
 	s.jsonResponse(w, map[string]interface{}{"success": true})
 }
 
+// handleListNoteRevisions возвращает историю версий заметки урока.
+func (s *Server) handleListNoteRevisions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid lesson ID")
+		return
+	}
+
+	revisions, err := s.progressRepo.ListNoteRevisions(r.Context(), id, 20)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	type revisionItem struct {
+		ID        int64     `json:"id"`
+		WordCount int       `json:"word_count"`
+		CreatedAt time.Time `json:"created_at"`
+		NoteMD    string    `json:"note_md"`
+	}
+	items := make([]revisionItem, 0, len(revisions))
+	for _, rev := range revisions {
+		items = append(items, revisionItem{
+			ID:        rev.ID,
+			WordCount: len(strings.Fields(rev.NoteMD)),
+			CreatedAt: rev.CreatedAt,
+			NoteMD:    rev.NoteMD,
+		})
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"revisions": items})
+}
+
+// handleRestoreNoteRevision восстанавливает заметку из указанной версии.
+func (s *Server) handleRestoreNoteRevision(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid lesson ID")
+		return
+	}
+
+	var req struct {
+		RevisionID int64 `json:"revision_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+
+	revision, err := s.progressRepo.GetNoteRevision(r.Context(), req.RevisionID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if revision == nil || revision.LessonID != id {
+		s.badRequest(w, "Revision not found")
+		return
+	}
+
+	if err := s.progressRepo.SaveNote(r.Context(), id, revision.NoteMD); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true, "note": revision.NoteMD})
+}
+
 // handleRun выполняет Go-код.
 func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	if !s.flagEnabled(r.Context(), flags.Playground) {
+		s.badRequest(w, "Песочница отключена")
+		return
+	}
+
 	var req struct {
 		Code string `json:"code"`
 	}
@@ -410,8 +1176,9 @@ func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
 // handleCheck проверяет решение задания.
 func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		TaskID int64  `json:"task_id"`
-		Code   string `json:"code"`
+		TaskID int64    `json:"task_id"`
+		Code   string   `json:"code"`
+		Blanks []string `json:"blanks"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -424,6 +1191,17 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Задание с пропусками: вместо кода приходят ответы по пропускам
+	if len(req.Blanks) > 0 {
+		result, err := s.checker.CheckCloze(r.Context(), req.TaskID, req.Blanks)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		s.jsonResponse(w, result)
+		return
+	}
+
 	if strings.TrimSpace(req.Code) == "" {
 		s.badRequest(w, "Code is empty")
 		return
@@ -438,13 +1216,103 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, result)
 }
 
+// handleTaskPreview проверяет решение против временного задания из
+// запроса — «попробовать как студент» при редактировании. В БД ничего
+// не пишется: ни отправок, ни прогресса.
+func (s *Server) handleTaskPreview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Code           string `json:"code"`
+		ExpectedOutput string `json:"expected_output"`
+		ASTRules       string `json:"ast_rules"`
+		OutputOptions  string `json:"output_options"`
+		RunTimeoutSec  int    `json:"run_timeout_sec"`
+		MemoryLimitMB  int    `json:"memory_limit_mb"`
+		TestsGo        string `json:"tests_go"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+
+	if strings.TrimSpace(req.Code) == "" {
+		s.badRequest(w, "Code is empty")
+		return
+	}
+
+	task := &content.Task{
+		ExpectedOutput: req.ExpectedOutput,
+		ASTRules:       req.ASTRules,
+		OutputOptions:  req.OutputOptions,
+		RunTimeoutSec:  req.RunTimeoutSec,
+		MemoryLimitMB:  req.MemoryLimitMB,
+		TestsGo:        req.TestsGo,
+	}
+
+	result, err := s.checker.Preview(r.Context(), task, req.Code)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, result)
+}
+
+// handleHintUsed отмечает, что пользователь раскрыл подсказки задания.
+func (s *Server) handleHintUsed(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid task ID")
+		return
+	}
+
+	task, err := s.contentRepo.GetTaskByID(r.Context(), id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if task == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.track(r.Context(), analytics.EventHintUsed, task.LessonID, task.ID)
+
+	s.jsonResponse(w, map[string]interface{}{"success": true})
+}
+
+// handleHealthz — проверка живости для балансировщиков и мониторинга.
+// Отдаёт текущую версию схемы БД, чтобы замечать рассинхрон бинарника и базы.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	s.jsonResponse(w, map[string]interface{}{
+		"status":         "ok",
+		"version":        version.Version,
+		"schema_version": s.schemaVersion,
+	})
+}
+
 // --- Helpers ---
 
 func (s *Server) render(w http.ResponseWriter, name string, data interface{}) {
+	tmpl := s.templates
+	if s.opts.DevMode {
+		fresh, err := s.devTemplates()
+		if err != nil {
+			log.Printf("Template parse error: %v", err)
+			http.Error(w, "Ошибка парсинга шаблонов\n\n"+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		tmpl = fresh
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := s.templates.ExecuteTemplate(w, name, data); err != nil {
+	if err := tmpl.ExecuteTemplate(w, name, data); err != nil {
 		log.Printf("Template error: %v", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		msg := "Internal Server Error"
+		if s.opts.DevMode {
+			msg += "\n\n" + err.Error()
+		}
+		http.Error(w, msg, http.StatusInternalServerError)
 	}
 }
 
@@ -455,7 +1323,11 @@ func (s *Server) jsonResponse(w http.ResponseWriter, data interface{}) {
 
 func (s *Server) serverError(w http.ResponseWriter, err error) {
 	log.Printf("Server error: %v", err)
-	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	msg := "Internal Server Error"
+	if s.opts.DevMode {
+		msg += "\n\n" + err.Error()
+	}
+	http.Error(w, msg, http.StatusInternalServerError)
 }
 
 func (s *Server) badRequest(w http.ResponseWriter, msg string) {
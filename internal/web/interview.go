@@ -0,0 +1,92 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"golearning/internal/interview"
+)
+
+// SetInterview подключает банк вопросов для собеседований (страница /interview).
+func (s *Server) SetInterview(repo *interview.Repository) {
+	s.interviewRepo = repo
+}
+
+// handleInterview — страница подготовки к собеседованиям: сводка по
+// темам банка и режим мок-интервью (N случайных вопросов).
+func (s *Server) handleInterview(w http.ResponseWriter, r *http.Request) {
+	if s.interviewRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	topics, err := s.interviewRepo.Topics()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	topic := r.URL.Query().Get("topic")
+	difficulty := r.URL.Query().Get("difficulty")
+
+	var questions []interview.Question
+	if count > 0 {
+		if count > 20 {
+			count = 20
+		}
+		questions, err = s.interviewRepo.Random(count, topic, difficulty)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Topics":     topics,
+		"Questions":  questions,
+		"Count":      count,
+		"Topic":      topic,
+		"Difficulty": difficulty,
+		"Stats":      stats,
+	}
+
+	s.render(w, "interview.html", data)
+}
+
+// handleInterviewReview записывает самооценку ответа на вопрос мок-интервью.
+func (s *Server) handleInterviewReview(w http.ResponseWriter, r *http.Request) {
+	if s.interviewRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		QuestionID int64 `json:"question_id"`
+		Score      int   `json:"score"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+
+	question, err := s.interviewRepo.Get(req.QuestionID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if question == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := s.interviewRepo.RecordReview(req.QuestionID, req.Score); err != nil {
+		s.badRequest(w, "Оценка должна быть от 0 до 2")
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true})
+}
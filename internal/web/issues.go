@@ -0,0 +1,130 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golearning/internal/issues"
+)
+
+// SetIssues подключает жалобы на ошибки в материалах (страница /admin/issues).
+func (s *Server) SetIssues(repo *issues.Repository) {
+	s.issuesRepo = repo
+}
+
+// handleIssueCreate — POST /api/issues: кнопка «Сообщить об ошибке»
+// на странице урока. Снимок контекста собирается на сервере, чтобы
+// жалоба оставалась понятной и после правки урока.
+func (s *Server) handleIssueCreate(w http.ResponseWriter, r *http.Request) {
+	if s.issuesRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		LessonID int64  `json:"lesson_id"`
+		TaskID   int64  `json:"task_id"`
+		Kind     string `json:"kind"`
+		Message  string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Message == "" {
+		s.badRequest(w, "Опишите проблему")
+		return
+	}
+
+	var context []string
+	lesson, err := s.contentRepo.GetLessonByID(r.Context(), req.LessonID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if lesson == nil {
+		s.badRequest(w, "Урок не найден")
+		return
+	}
+	context = append(context, fmt.Sprintf("Урок: %s (%s)", lesson.Title, lesson.Slug))
+	if req.TaskID > 0 {
+		task, err := s.contentRepo.GetTaskByID(r.Context(), req.TaskID)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		if task == nil || task.LessonID != lesson.ID {
+			s.badRequest(w, "Задание не найдено")
+			return
+		}
+		context = append(context, fmt.Sprintf("Задание: %s (#%d)", task.Title, task.ID))
+	}
+	context = append(context, "Обновлён: "+lesson.UpdatedAt.Format("02.01.2006 15:04"))
+
+	issue := &issues.Issue{
+		LessonID: req.LessonID,
+		TaskID:   req.TaskID,
+		Kind:     req.Kind,
+		Message:  req.Message,
+		Context:  strings.Join(context, "\n"),
+	}
+	if err := s.issuesRepo.Create(issue); err != nil {
+		s.badRequest(w, err.Error())
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true, "id": issue.ID})
+}
+
+// handleIssues — очередь разбора жалоб в админке.
+func (s *Server) handleIssues(w http.ResponseWriter, r *http.Request) {
+	if s.issuesRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if _, ok := issues.Statuses[status]; !ok {
+		status = ""
+	}
+
+	list, err := s.issuesRepo.List(status, 100)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.render(w, "issues.html", map[string]interface{}{
+		"Issues":   list,
+		"Status":   status,
+		"Kinds":    issues.Kinds,
+		"Statuses": issues.Statuses,
+	})
+}
+
+// handleIssueStatus переводит жалобу в новый статус.
+func (s *Server) handleIssueStatus(w http.ResponseWriter, r *http.Request) {
+	if s.issuesRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid issue ID")
+		return
+	}
+	status := r.FormValue("status")
+
+	if err := s.issuesRepo.SetStatus(id, status); err != nil {
+		s.badRequest(w, err.Error())
+		return
+	}
+	s.recordAudit("issue.status", "issue", id, "Жалоба переведена в статус "+status)
+
+	http.Redirect(w, r, s.opts.BasePath+"/admin/issues", http.StatusSeeOther)
+}
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/practice"
+	"golearning/internal/progress"
+)
+
+// kataView — ката вместе с накопленным результатом для шаблона.
+type kataView struct {
+	practice.Kata
+	Result progress.KataResult
+}
+
+// handleKatas — страница трека ката.
+func (s *Server) handleKatas(w http.ResponseWriter, r *http.Request) {
+	results, err := s.progressRepo.GetKataResults(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	var katas []kataView
+	passed := 0
+	for _, k := range practice.Katas() {
+		kv := kataView{Kata: k, Result: results[k.Slug]}
+		if kv.Result.Passed {
+			passed++
+		}
+		katas = append(katas, kv)
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Katas":  katas,
+		"Passed": passed,
+		"Stats":  stats,
+	}
+
+	s.render(w, "katas.html", data)
+}
+
+// handleKataCheck проверяет решение ката: AST-ограничения, запуск,
+// сравнение вывода.
+func (s *Server) handleKataCheck(w http.ResponseWriter, r *http.Request) {
+	kata := practice.KataBySlug(chi.URLParam(r, "slug"))
+	if kata == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+
+	result, err := s.checker.CheckKata(r.Context(), kata, req.Code)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, result)
+}
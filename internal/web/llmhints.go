@@ -0,0 +1,116 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/flags"
+	"golearning/internal/llm"
+)
+
+// llmHintDailyQuota — сколько LLM-подсказок можно сгенерировать за
+// сутки. Квота бережёт бюджет API и подталкивает сначала думать самому.
+const llmHintDailyQuota = 10
+
+// SetLLM подключает клиент языковой модели (LLM-подсказки по
+// проваленным проверкам, флаг llm-hints).
+func (s *Server) SetLLM(c *llm.Client) {
+	s.llmClient = c
+}
+
+// llmHintFailedStatuses — статусы отправок, по которым есть смысл
+// просить подсказку.
+var llmHintFailedStatuses = map[string]bool{
+	"error":   true,
+	"timeout": true,
+}
+
+// handleLLMHint — POST /api/submissions/{id}/llm-hint: генерирует
+// подсказку по проваленной отправке. Подсказка сохраняется рядом с
+// отправкой, повторный запрос возвращает её без обращения к модели.
+func (s *Server) handleLLMHint(w http.ResponseWriter, r *http.Request) {
+	if s.llmClient == nil || !s.flagEnabled(r.Context(), flags.LLMHints) {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid submission ID")
+		return
+	}
+
+	submission, err := s.progressRepo.GetSubmissionByID(r.Context(), id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if submission == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !llmHintFailedStatuses[submission.Status] {
+		s.badRequest(w, "Подсказка доступна только по проваленной проверке")
+		return
+	}
+
+	// Уже сгенерированная подсказка отдаётся из БД — и после
+	// перезагрузки страницы, и при повторном нажатии.
+	if submission.Hint != "" {
+		s.jsonResponse(w, map[string]string{"hint": submission.Hint})
+		return
+	}
+
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	used, err := s.progressRepo.CountHintsSince(r.Context(), dayStart)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if used >= llmHintDailyQuota {
+		s.badRequest(w, "Дневная квота подсказок исчерпана — попробуйте завтра")
+		return
+	}
+
+	task, err := s.contentRepo.GetTaskByID(r.Context(), submission.TaskID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if task == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	system := "Ты наставник учебного курса по Go. Учащийся провалил проверку задания. " +
+		"Дай одну короткую подсказку на русском: укажи направление, в котором искать ошибку. " +
+		"Никогда не приводи готовое решение и не переписывай код за учащегося."
+
+	var prompt strings.Builder
+	prompt.WriteString("Условие задания:\n" + task.PromptMD + "\n\n")
+	prompt.WriteString("Код учащегося:\n```go\n" + submission.Code + "\n```\n\n")
+	if submission.Stderr != "" {
+		prompt.WriteString("Ошибки:\n" + submission.Stderr + "\n\n")
+	}
+	if submission.Stdout != "" {
+		prompt.WriteString("Вывод программы:\n" + submission.Stdout + "\n")
+	}
+
+	hint, err := s.llmClient.Complete(r.Context(), system, prompt.String())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	hint = strings.TrimSpace(hint)
+
+	if err := s.progressRepo.SetSubmissionHint(r.Context(), submission.ID, hint); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.jsonResponse(w, map[string]string{"hint": hint})
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"crypto/sha256"
+	"html/template"
+	"strings"
+	"sync"
+
+	"golearning/internal/content"
+	"golearning/internal/ingest"
+	"golearning/internal/render"
+	"golearning/internal/sanitize"
+)
+
+// mdCacheLimit — максимум закэшированных отрендеренных фрагментов.
+// При переполнении кэш сбрасывается целиком: секции уроков рендерятся
+// заново при первом обращении, дальше снова из кэша.
+const mdCacheLimit = 1024
+
+// mdCache — кэш результатов Markdown→HTML по хэшу исходника.
+// Подсветка синтаксиса через chroma заметно дороже самого рендеринга,
+// а секции уроков между импортами не меняются.
+type mdCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]template.HTML
+}
+
+func newMDCache() *mdCache {
+	return &mdCache{entries: make(map[[sha256.Size]byte]template.HTML)}
+}
+
+// renderMarkdown конвертирует Markdown в безопасный HTML с кэшем.
+func (s *Server) renderMarkdown(src string) template.HTML {
+	key := sha256.Sum256([]byte(src))
+
+	s.markdownCache.mu.Lock()
+	cached, ok := s.markdownCache.entries[key]
+	s.markdownCache.mu.Unlock()
+	if ok {
+		return cached
+	}
+
+	out, err := s.markdown.HTML(annotateBareFences(src))
+	if err != nil {
+		return template.HTML("<p>Ошибка рендеринга</p>")
+	}
+	// Контент импортируется извне, заметки пишет пользователь —
+	// чистим итоговый HTML перед показом
+	rendered := template.HTML(sanitize.HTML(out))
+
+	s.markdownCache.mu.Lock()
+	if len(s.markdownCache.entries) >= mdCacheLimit {
+		s.markdownCache.entries = make(map[[sha256.Size]byte]template.HTML)
+	}
+	s.markdownCache.entries[key] = rendered
+	s.markdownCache.mu.Unlock()
+
+	return rendered
+}
+
+// sectionOutline возвращает оглавление секции: сохранённое при импорте
+// или, для записей без него, построенное на лету.
+func (s *Server) sectionOutline(sec content.Section) []render.Heading {
+	if headings := sec.Headings(); headings != nil {
+		return headings
+	}
+	return s.markdown.Outline(sec.BodyMD)
+}
+
+// annotateBareFences дописывает язык кодовым фенсам без него, чтобы
+// chroma подсветила и их. Язык угадывает ingest.DetectLanguage — та же
+// эвристика, что и при импорте уроков.
+func annotateBareFences(src string) string {
+	if !strings.Contains(src, "```") {
+		return src
+	}
+
+	lines := strings.Split(src, "\n")
+	var out []string
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case !inFence && trimmed == "```":
+			// Открывающий фенс без языка — определяем по содержимому
+			code := fenceBody(lines[i+1:])
+			out = append(out, line+ingest.DetectLanguage(code, ""))
+			inFence = true
+		case !inFence && strings.HasPrefix(trimmed, "```"):
+			out = append(out, line)
+			inFence = true
+		case inFence && strings.HasPrefix(trimmed, "```"):
+			out = append(out, line)
+			inFence = false
+		default:
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// fenceBody собирает содержимое фенса до закрывающих ```.
+func fenceBody(lines []string) string {
+	var body []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			break
+		}
+		body = append(body, line)
+	}
+	return strings.Join(body, "\n")
+}
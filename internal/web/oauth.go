@@ -0,0 +1,338 @@
+package web
+
+// Вход через внешние OAuth2-провайдеры (GitHub, Google) — альтернатива
+// ключу доступа в публичном режиме. Обмен кода на токен и запрос профиля
+// делаются стандартным net/http, без клиентских библиотек провайдеров.
+// Успешный вход создаёт сессию в kv-хранилище и даёт роль registered;
+// если посетитель уже вошёл по ключу, внешний аккаунт просто привязывается.
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/auth"
+	"golearning/internal/config"
+)
+
+const (
+	// oauthStateTTL — время жизни state между редиректом и callback.
+	oauthStateTTL = 10 * time.Minute
+	// oauthSessionTTL — время жизни сессии после входа через провайдера.
+	oauthSessionTTL = 30 * 24 * time.Hour
+	// oauthStatePrefix — префикс ключей state в kv-хранилище.
+	oauthStatePrefix = "oauth:state:"
+	// sessionPrefix — префикс ключей сессий в kv-хранилище.
+	sessionPrefix = "session:"
+)
+
+// oauthEndpoints — адреса OAuth2-провайдера.
+type oauthEndpoints struct {
+	title    string // человекочитаемое имя для страницы входа
+	authURL  string
+	tokenURL string
+	userURL  string
+	scope    string
+}
+
+// oauthProviders — поддерживаемые провайдеры. Включается провайдер
+// заполнением client_id в файловой конфигурации (секция oauth).
+var oauthProviders = map[string]oauthEndpoints{
+	"github": {
+		title:    "GitHub",
+		authURL:  "https://github.com/login/oauth/authorize",
+		tokenURL: "https://github.com/login/oauth/access_token",
+		userURL:  "https://api.github.com/user",
+		scope:    "read:user user:email",
+	},
+	"google": {
+		title:    "Google",
+		authURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+		tokenURL: "https://oauth2.googleapis.com/token",
+		userURL:  "https://openidconnect.googleapis.com/v1/userinfo",
+		scope:    "openid email profile",
+	},
+}
+
+// oauthClient — HTTP-клиент для запросов к провайдерам.
+var oauthClient = &http.Client{Timeout: 10 * time.Second}
+
+// SetOAuth подключает конфигурацию OAuth-провайдеров (секция oauth
+// файловой конфигурации).
+func (s *Server) SetOAuth(cfg config.OAuth) {
+	s.oauth = cfg
+}
+
+// SetIdentities подключает репозиторий внешних аккаунтов.
+func (s *Server) SetIdentities(repo *auth.Repository) {
+	s.identities = repo
+}
+
+// oauthProvider возвращает учётные данные и адреса провайдера по имени.
+// ok == false — провайдер неизвестен или не настроен.
+func (s *Server) oauthProvider(name string) (config.OAuthProvider, oauthEndpoints, bool) {
+	ep, ok := oauthProviders[name]
+	if !ok {
+		return config.OAuthProvider{}, oauthEndpoints{}, false
+	}
+	var creds config.OAuthProvider
+	switch name {
+	case "github":
+		creds = s.oauth.GitHub
+	case "google":
+		creds = s.oauth.Google
+	}
+	if creds.ClientID == "" {
+		return config.OAuthProvider{}, oauthEndpoints{}, false
+	}
+	return creds, ep, true
+}
+
+// oauthProviderViews возвращает настроенные провайдеры для страницы входа
+// в стабильном порядке.
+func (s *Server) oauthProviderViews() []map[string]string {
+	var views []map[string]string
+	for _, name := range []string{"github", "google"} {
+		if _, ep, ok := s.oauthProvider(name); ok {
+			views = append(views, map[string]string{"Name": name, "Title": ep.title})
+		}
+	}
+	return views
+}
+
+// oauthRedirectURL строит redirect_uri callback-а: он должен совпадать
+// с адресом, зарегистрированным у провайдера.
+func (s *Server) oauthRedirectURL(r *http.Request, provider string) string {
+	return s.requestScheme(r) + "://" + r.Host + s.opts.BasePath + "/login/oauth/" + provider + "/callback"
+}
+
+// randomToken возвращает криптографически случайную hex-строку
+// для state и сессионных токенов.
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("random token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// handleOAuthStart начинает вход через провайдера: запоминает state
+// и отправляет на страницу авторизации.
+func (s *Server) handleOAuthStart(w http.ResponseWriter, r *http.Request) {
+	if !s.opts.PublicMode {
+		http.Redirect(w, r, s.opts.BasePath+"/", http.StatusSeeOther)
+		return
+	}
+	name := chi.URLParam(r, "provider")
+	creds, ep, ok := s.oauthProvider(name)
+	if !ok {
+		s.badRequest(w, "Провайдер не настроен: "+name)
+		return
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if err := s.kv().Set(r.Context(), oauthStatePrefix+state, name, oauthStateTTL); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	q := url.Values{}
+	q.Set("client_id", creds.ClientID)
+	q.Set("redirect_uri", s.oauthRedirectURL(r, name))
+	q.Set("response_type", "code")
+	q.Set("scope", ep.scope)
+	q.Set("state", state)
+	http.Redirect(w, r, ep.authURL+"?"+q.Encode(), http.StatusSeeOther)
+}
+
+// handleOAuthCallback завершает вход: проверяет state, меняет код на
+// токен, читает профиль и выдаёт сессию. Если посетитель уже вошёл по
+// ключу, аккаунт только привязывается, роль не меняется.
+func (s *Server) handleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	if !s.opts.PublicMode {
+		http.Redirect(w, r, s.opts.BasePath+"/", http.StatusSeeOther)
+		return
+	}
+	name := chi.URLParam(r, "provider")
+	creds, ep, ok := s.oauthProvider(name)
+	if !ok {
+		s.badRequest(w, "Провайдер не настроен: "+name)
+		return
+	}
+
+	// Отказ на стороне провайдера (пользователь не дал доступ)
+	if r.URL.Query().Get("error") != "" {
+		http.Redirect(w, r, s.opts.BasePath+"/login?error=oauth", http.StatusSeeOther)
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	stored, ok, err := s.kv().Get(r.Context(), oauthStatePrefix+state)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if state == "" || !ok || stored != name {
+		s.badRequest(w, "Неверный или устаревший state: начните вход заново")
+		return
+	}
+	_ = s.kv().Delete(r.Context(), oauthStatePrefix+state)
+
+	token, err := s.oauthExchangeCode(creds, ep, r.URL.Query().Get("code"), s.oauthRedirectURL(r, name))
+	if err != nil {
+		log.Printf("Ошибка обмена OAuth-кода (%s): %v", name, err)
+		http.Redirect(w, r, s.opts.BasePath+"/login?error=oauth", http.StatusSeeOther)
+		return
+	}
+
+	ident, err := oauthFetchIdentity(name, ep, token)
+	if err != nil {
+		log.Printf("Ошибка запроса профиля OAuth (%s): %v", name, err)
+		http.Redirect(w, r, s.opts.BasePath+"/login?error=oauth", http.StatusSeeOther)
+		return
+	}
+
+	if s.identities != nil {
+		created, err := s.identities.Upsert(r.Context(), ident)
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		if created {
+			s.recordAudit("auth.provision", "identity", ident.ID, "Первый вход через "+name+": "+ident.Login)
+		}
+	}
+
+	// Уже вошёл по ключу — только привязка аккаунта, куку не трогаем
+	if s.viewer(r).canPractice() {
+		http.Redirect(w, r, s.opts.BasePath+"/login", http.StatusSeeOther)
+		return
+	}
+
+	session, err := randomToken()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if err := s.kv().Set(r.Context(), sessionPrefix+session, string(roleRegistered), oauthSessionTTL); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessCookie,
+		Value:    session,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, s.opts.BasePath+"/", http.StatusSeeOther)
+}
+
+// oauthExchangeCode меняет authorization code на access token.
+func (s *Server) oauthExchangeCode(creds config.OAuthProvider, ep oauthEndpoints, code, redirectURI string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("client_id", creds.ClientID)
+	form.Set("client_secret", creds.ClientSecret)
+	form.Set("redirect_uri", redirectURI)
+
+	req, err := http.NewRequest(http.MethodPost, ep.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := oauthClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request: статус %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("parse token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("token response: пустой access_token")
+	}
+	return body.AccessToken, nil
+}
+
+// oauthFetchIdentity запрашивает профиль пользователя у провайдера.
+func oauthFetchIdentity(provider string, ep oauthEndpoints, token string) (*auth.Identity, error) {
+	req, err := http.NewRequest(http.MethodGet, ep.userURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("userinfo request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := oauthClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("userinfo request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo request: статус %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read userinfo: %w", err)
+	}
+
+	ident := &auth.Identity{Provider: provider}
+	switch provider {
+	case "github":
+		var u struct {
+			ID    int64  `json:"id"`
+			Login string `json:"login"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal(data, &u); err != nil {
+			return nil, fmt.Errorf("parse userinfo: %w", err)
+		}
+		ident.ProviderID = strconv.FormatInt(u.ID, 10)
+		ident.Login = u.Login
+		ident.Name = u.Name
+		ident.Email = u.Email
+	case "google":
+		var u struct {
+			Sub   string `json:"sub"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal(data, &u); err != nil {
+			return nil, fmt.Errorf("parse userinfo: %w", err)
+		}
+		ident.ProviderID = u.Sub
+		ident.Login = u.Email
+		ident.Name = u.Name
+		ident.Email = u.Email
+	}
+	if ident.ProviderID == "" {
+		return nil, fmt.Errorf("userinfo: пустой идентификатор пользователя")
+	}
+	return ident, nil
+}
@@ -0,0 +1,66 @@
+package web
+
+// План чтения: форма генерации и чеклист живут на главной странице,
+// обработчики ниже только строят и удаляют план.
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golearning/internal/plan"
+)
+
+// SetPlanner подключает генератор плана чтения (блок на главной).
+func (s *Server) SetPlanner(p *plan.Planner) {
+	s.planner = p
+}
+
+// handleGeneratePlan строит план чтения по целевой дате и минутам в день.
+func (s *Server) handleGeneratePlan(w http.ResponseWriter, r *http.Request) {
+	if s.planner == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	target, err := time.Parse("2006-01-02", r.FormValue("target_date"))
+	if err != nil {
+		s.redirectPlanError(w, r, "Укажите целевую дату")
+		return
+	}
+	minutes, err := strconv.Atoi(r.FormValue("minutes_per_day"))
+	if err != nil {
+		s.redirectPlanError(w, r, "Укажите число минут в день")
+		return
+	}
+
+	if err := s.planner.Generate(r.Context(), target, minutes); err != nil {
+		s.redirectPlanError(w, r, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/#plan", http.StatusSeeOther)
+}
+
+// handleClearPlan удаляет текущий план чтения.
+func (s *Server) handleClearPlan(w http.ResponseWriter, r *http.Request) {
+	if s.planner == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := s.planner.Clear(r.Context()); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/#plan", http.StatusSeeOther)
+}
+
+// redirectPlanError возвращает на главную с текстом ошибки плана:
+// ошибки генерации — обычная обратная связь («не укладывается»),
+// а не повод показывать страницу 400.
+func (s *Server) redirectPlanError(w http.ResponseWriter, r *http.Request, msg string) {
+	http.Redirect(w, r, s.opts.BasePath+"/?plan_error="+url.QueryEscape(msg)+"#plan", http.StatusSeeOther)
+}
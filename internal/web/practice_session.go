@@ -0,0 +1,112 @@
+package web
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// handleCreatePracticeSession — POST /api/practice/session:
+// собирает сессию из N случайных нерешённых заданий завершённых модулей.
+func (s *Server) handleCreatePracticeSession(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Count       int `json:"count"`
+		DurationMin int `json:"duration_min"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if req.Count <= 0 {
+		req.Count = 5
+	}
+	if req.DurationMin <= 0 {
+		req.DurationMin = 15
+	}
+
+	candidates, err := s.progressRepo.ListPracticeCandidates(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if len(candidates) == 0 {
+		s.jsonResponse(w, map[string]interface{}{
+			"success": false,
+			"message": "Нет нерешённых заданий в завершённых модулях",
+		})
+		return
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if req.Count < len(candidates) {
+		candidates = candidates[:req.Count]
+	}
+
+	session, err := s.progressRepo.CreatePracticeSession(r.Context(), candidates, req.DurationMin)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	// Подгружаем детали заданий для клиента
+	type sessionTask struct {
+		ID          int64  `json:"id"`
+		LessonID    int64  `json:"lesson_id"`
+		Title       string `json:"title"`
+		PromptMD    string `json:"prompt_md"`
+		StarterCode string `json:"starter_code"`
+		Points      int    `json:"points"`
+	}
+	tasks := make([]sessionTask, 0, len(session.TaskIDs))
+	for _, taskID := range session.TaskIDs {
+		task, err := s.contentRepo.GetTaskByID(r.Context(), taskID)
+		if err != nil || task == nil {
+			continue
+		}
+		tasks = append(tasks, sessionTask{
+			ID:          task.ID,
+			LessonID:    task.LessonID,
+			Title:       task.Title,
+			PromptMD:    task.PromptMD,
+			StarterCode: task.StarterCode,
+			Points:      task.Points,
+		})
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"success":      true,
+		"session_id":   session.ID,
+		"duration_min": session.DurationMin,
+		"tasks":        tasks,
+	})
+}
+
+// handleFinishPracticeSession — POST /api/practice/session/{id}/finish:
+// фиксирует итоги сессии и пополняет очередь повторения.
+func (s *Server) handleFinishPracticeSession(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid session ID")
+		return
+	}
+
+	var req struct {
+		SolvedTaskIDs []int64 `json:"solved_task_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+
+	if err := s.progressRepo.FinishPracticeSession(r.Context(), id, req.SolvedTaskIDs); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true})
+}
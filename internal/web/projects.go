@@ -1,35 +1,123 @@
 package web
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
 
 	"golearning"
 )
 
+// Project — capstone-проект со встроенным ТЗ.
 type Project struct {
 	ID       string
 	Title    string
 	Subtitle string
 	SpecMD   string
+
+	// Чек-лист из ТЗ и прогресс по нему
+	Milestones []ProjectMilestone
+	DoneCount  int
+	Percent    int
+
+	// Рубрика ручной оценки (/admin/grading) и итог по ней
+	Rubric     []RubricCriterion
+	GradeTotal int
+	GradeMax   int
+	Graded     bool
 }
 
-func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
-	stats, _ := s.progressRepo.GetStats()
+// ProjectMilestone — пункт чек-листа ("- [ ]") из ТЗ проекта.
+type ProjectMilestone struct {
+	Index int
+	Text  string
+	Done  bool
+}
 
-	projects := []Project{
+// RubricCriterion — критерий рубрики ручной оценки проекта.
+type RubricCriterion struct {
+	Code      string
+	Title     string
+	MaxPoints int
+}
+
+// capstoneProjects возвращает список capstone-проектов с ТЗ и рубриками.
+func capstoneProjects() []Project {
+	return []Project{
 		{
 			ID:       "capstone-rest",
 			Title:    "Capstone REST: сервис заказов (Gin + Postgres)",
 			Subtitle: "JWT, миграции, интеграционные тесты, CI, Docker Compose, метрики/логи/трейсы, нагрузка и профили",
 			SpecMD:   golearning.CapstoneRESTSpecMD,
+			Rubric: []RubricCriterion{
+				{Code: "api", Title: "API: аутентификация, CRUD, пагинация, формат ошибок", MaxPoints: 25},
+				{Code: "tests", Title: "Тесты: unit + интеграционные с Postgres, -race в CI", MaxPoints: 20},
+				{Code: "observability", Title: "Наблюдаемость: метрики, JSON-логи, трейсы", MaxPoints: 20},
+				{Code: "operations", Title: "Эксплуатация: docker-compose, graceful shutdown, конфигурация", MaxPoints: 15},
+				{Code: "performance", Title: "Производительность: нагрузка, профили, отчёт «до/после»", MaxPoints: 10},
+				{Code: "code", Title: "Качество кода: структура, слои, README", MaxPoints: 10},
+			},
 		},
 		{
 			ID:       "capstone-grpc",
 			Title:    "Capstone gRPC: Users/Accounts сервис (gRPC + TLS/mTLS)",
 			Subtitle: "Interceptors, deadlines, безопасность, наблюдаемость; опционально grpc-gateway + OpenAPI",
 			SpecMD:   golearning.CapstoneGRPCSpecMD,
+			Rubric: []RubricCriterion{
+				{Code: "api", Title: "gRPC API: сервисы, дедлайны, обработка ошибок", MaxPoints: 25},
+				{Code: "security", Title: "Безопасность: TLS/mTLS, interceptors", MaxPoints: 25},
+				{Code: "observability", Title: "Наблюдаемость: трейсы в Jaeger/Tempo, корреляция логов", MaxPoints: 20},
+				{Code: "tests", Title: "Тесты и CI: линт, -race", MaxPoints: 20},
+				{Code: "code", Title: "Качество кода: структура, README", MaxPoints: 10},
+			},
 		},
 	}
+}
+
+// rubricMax суммирует максимум баллов по рубрике.
+func rubricMax(rubric []RubricCriterion) int {
+	total := 0
+	for _, c := range rubric {
+		total += c.MaxPoints
+	}
+	return total
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	projects := capstoneProjects()
+	for i := range projects {
+		p := &projects[i]
+		p.GradeMax = rubricMax(p.Rubric)
+		if grades, err := s.progressRepo.GetProjectGrades(r.Context(), p.ID); err == nil && len(grades) > 0 {
+			p.Graded = true
+			for _, c := range p.Rubric {
+				if g, ok := grades[c.Code]; ok {
+					p.GradeTotal += g.Points
+				}
+			}
+		}
+
+		p.Milestones = parseMilestones(p.SpecMD)
+		if len(p.Milestones) == 0 {
+			continue
+		}
+
+		done, _ := s.progressRepo.GetProjectMilestones(r.Context(), p.ID)
+		for j := range p.Milestones {
+			if done[p.Milestones[j].Index] {
+				p.Milestones[j].Done = true
+				p.DoneCount++
+			}
+		}
+		p.Percent = p.DoneCount * 100 / len(p.Milestones)
+		// Отметки подставляются в Markdown до рендеринга: чекбоксы
+		// в теле ТЗ показывают сохранённое состояние без JS
+		p.SpecMD = markDoneMilestones(p.SpecMD, done)
+	}
 
 	data := map[string]interface{}{
 		"Stats":    stats,
@@ -38,3 +126,93 @@ func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
 
 	s.render(w, "projects.html", data)
 }
+
+// handleProjectMilestone отмечает или снимает пункт чек-листа проекта.
+func (s *Server) handleProjectMilestone(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	var milestones []ProjectMilestone
+	for _, p := range capstoneProjects() {
+		if p.ID == projectID {
+			milestones = parseMilestones(p.SpecMD)
+			break
+		}
+	}
+	if len(milestones) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		Index int  `json:"index"`
+		Done  bool `json:"done"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if req.Index < 0 || req.Index >= len(milestones) {
+		s.badRequest(w, "Unknown milestone index")
+		return
+	}
+
+	if err := s.progressRepo.SetProjectMilestone(r.Context(), projectID, req.Index, req.Done); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	done, err := s.progressRepo.GetProjectMilestones(r.Context(), projectID)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	doneCount := 0
+	for _, m := range milestones {
+		if done[m.Index] {
+			doneCount++
+		}
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"done_count": doneCount,
+		"total":      len(milestones),
+		"percent":    doneCount * 100 / len(milestones),
+	})
+}
+
+// parseMilestones извлекает пункты чек-листа ("- [ ]" и "- [x]") из ТЗ.
+// Пункт идентифицируется порядковым номером в тексте.
+func parseMilestones(specMD string) []ProjectMilestone {
+	var items []ProjectMilestone
+	for _, line := range strings.Split(specMD, "\n") {
+		trimmed := strings.TrimSpace(line)
+		text, ok := strings.CutPrefix(trimmed, "- [ ] ")
+		if !ok {
+			text, ok = strings.CutPrefix(trimmed, "- [x] ")
+		}
+		if !ok {
+			continue
+		}
+		items = append(items, ProjectMilestone{Index: len(items), Text: strings.TrimSpace(text)})
+	}
+	return items
+}
+
+// markDoneMilestones проставляет "[x]" отмеченным пунктам чек-листа
+// в исходном Markdown.
+func markDoneMilestones(specMD string, done map[int]bool) string {
+	lines := strings.Split(specMD, "\n")
+	index := 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "- [ ] ") && !strings.HasPrefix(trimmed, "- [x] ") {
+			continue
+		}
+		if done[index] {
+			lines[i] = strings.Replace(line, "- [ ] ", "- [x] ", 1)
+		} else {
+			lines[i] = strings.Replace(line, "- [x] ", "- [ ] ", 1)
+		}
+		index++
+	}
+	return strings.Join(lines, "\n")
+}
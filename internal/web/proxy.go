@@ -0,0 +1,34 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+)
+
+// normalizeBasePath приводит префикс пути к виду "/golearn":
+// с ведущим слэшем, без завершающего. Пустая строка и "/" означают корень.
+func normalizeBasePath(p string) string {
+	p = strings.TrimSpace(p)
+	p = strings.TrimRight(p, "/")
+	if p == "" {
+		return ""
+	}
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return p
+}
+
+// requestScheme возвращает схему запроса с учётом прокси-заголовков.
+// X-Forwarded-Proto учитывается только при включённом TrustProxy.
+func (s *Server) requestScheme(r *http.Request) string {
+	if s.opts.TrustProxy {
+		if proto := r.Header.Get("X-Forwarded-Proto"); proto == "https" || proto == "http" {
+			return proto
+		}
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golearning/internal/kv"
+)
+
+// rateLimitWindow — окно подсчёта запросов rate-лимитера.
+const rateLimitWindow = time.Minute
+
+// rateLimitMiddleware ограничивает число API-запросов с одного IP в
+// минуту. Счётчики живут в kv-хранилище: с Redis лимит общий для всех
+// реплик, с in-memory — у каждой реплики свой. Страницы и статика не
+// ограничиваются.
+func (s *Server) rateLimitMiddleware(store kv.Store, perMinute int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// При --base-path chi.Mount не переписывает r.URL.Path,
+			// поэтому префикс нужно снять перед проверкой — иначе
+			// лимитер молча не срабатывает ни на одном запросе.
+			path := strings.TrimPrefix(r.URL.Path, s.opts.BasePath)
+			if !strings.HasPrefix(path, "/api") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := "ratelimit:" + clientIP(r)
+			count, err := store.Incr(r.Context(), key, rateLimitWindow)
+			if err != nil {
+				// Хранилище недоступно — пропускаем запрос, а не валим API
+				log.Printf("Ошибка rate-лимитера: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if count > int64(perMinute) {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(rateLimitWindow.Seconds())))
+				http.Error(w, "too many requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP возвращает IP клиента без порта. Заголовки прокси к этому
+// моменту уже учтены middleware.RealIP (при --trust-proxy).
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,138 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/analytics"
+	"golearning/internal/content"
+	"golearning/internal/progress"
+)
+
+// Режим чтения: вариант страницы урока без сайдбара и навигации.
+// Ширина колонки и типографика настраиваются и хранятся в settings,
+// так что выбор переживает перезапуск браузера.
+
+// readerDefaults — значения по умолчанию для режима чтения.
+const (
+	defaultReaderWidth = "normal"
+	defaultReaderFont  = "sans"
+	defaultReaderSize  = "normal"
+)
+
+// Допустимые значения настроек режима чтения.
+var (
+	readerWidths = map[string]bool{"narrow": true, "normal": true, "wide": true}
+	readerFonts  = map[string]bool{"sans": true, "serif": true}
+	readerSizes  = map[string]bool{"small": true, "normal": true, "large": true}
+)
+
+// readerPrefs — настройки режима чтения.
+type readerPrefs struct {
+	Width string `json:"width"`
+	Font  string `json:"font"`
+	Size  string `json:"size"`
+}
+
+// readerPrefs читает настройки режима чтения из settings.
+func (s *Server) readerPrefs(r *http.Request) readerPrefs {
+	prefs := readerPrefs{
+		Width: defaultReaderWidth,
+		Font:  defaultReaderFont,
+		Size:  defaultReaderSize,
+	}
+	if v, err := s.progressRepo.GetSetting(r.Context(), "reader.width"); err == nil && readerWidths[v] {
+		prefs.Width = v
+	}
+	if v, err := s.progressRepo.GetSetting(r.Context(), "reader.font"); err == nil && readerFonts[v] {
+		prefs.Font = v
+	}
+	if v, err := s.progressRepo.GetSetting(r.Context(), "reader.size"); err == nil && readerSizes[v] {
+		prefs.Size = v
+	}
+	return prefs
+}
+
+// handleLessonRead — страница урока в режиме чтения.
+func (s *Server) handleLessonRead(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	lesson, err := s.contentRepo.GetLessonBySlug(r.Context(), slug)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if lesson == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	role := s.viewer(r)
+	if !canViewModule(role, lesson.Module.Visibility) {
+		s.redirectToLogin(w, r, "/lessons/"+lesson.Slug+"/read")
+		return
+	}
+
+	// Чтение в любом режиме отмечает урок как «в процессе» (но не гостям)
+	prog, _ := s.progressRepo.GetProgress(r.Context(), lesson.ID)
+	if prog.Status == progress.StatusNew && role.canPractice() {
+		s.progressRepo.SetStatus(r.Context(), lesson.ID, progress.StatusReading)
+	}
+	s.track(r.Context(), analytics.EventLessonViewed, lesson.ID, 0)
+
+	// Соседние уроки в порядке курса
+	allLessons, _ := s.contentRepo.ListAllLessons(r.Context())
+	var prevLesson, nextLesson *content.Lesson
+	for i, l := range allLessons {
+		if l.ID == lesson.ID {
+			if i > 0 {
+				prevLesson = &allLessons[i-1]
+			}
+			if i < len(allLessons)-1 {
+				nextLesson = &allLessons[i+1]
+			}
+			break
+		}
+	}
+
+	data := map[string]interface{}{
+		"Lesson":      lesson,
+		"PrevLesson":  prevLesson,
+		"NextLesson":  nextLesson,
+		"Prefs":       s.readerPrefs(r),
+		"Translation": s.applyTranslation(r, lesson),
+	}
+
+	s.render(w, "read.html", data)
+}
+
+// handleSaveReaderPrefs — POST /api/reader/prefs: сохраняет настройки
+// режима чтения. Неизвестные значения отклоняются.
+func (s *Server) handleSaveReaderPrefs(w http.ResponseWriter, r *http.Request) {
+	var req readerPrefs
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if !readerWidths[req.Width] || !readerFonts[req.Font] || !readerSizes[req.Size] {
+		s.badRequest(w, "Недопустимое значение настройки чтения")
+		return
+	}
+
+	if err := s.progressRepo.SetSetting(r.Context(), "reader.width", req.Width); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if err := s.progressRepo.SetSetting(r.Context(), "reader.font", req.Font); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if err := s.progressRepo.SetSetting(r.Context(), "reader.size", req.Size); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"success": true})
+}
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// recoverMiddleware перехватывает паники в обработчиках: пишет стек в
+// журнал ошибок (если он подключён) и отдаёт 500 с коротким кодом
+// ошибки, по которому проблему можно найти на /admin/errors.
+func (s *Server) recoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			message := fmt.Sprintf("panic: %v", rec)
+			stack := string(debug.Stack())
+			log.Printf("%s\n%s", message, stack)
+
+			code := ""
+			if s.errorLog != nil {
+				code = s.errorLog.MustRecord(message, stack, r.URL.Path)
+			}
+
+			if code != "" {
+				http.Error(w, fmt.Sprintf("Внутренняя ошибка сервера (код %s)", code), http.StatusInternalServerError)
+			} else {
+				http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
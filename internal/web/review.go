@@ -0,0 +1,116 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/progress"
+)
+
+// reviewAssignments — сколько чужих решений предлагается на рецензию
+// после решения задания.
+const reviewAssignments = 2
+
+// reviewCandidateView — анонимное решение на рецензию для шаблона.
+type reviewCandidateView struct {
+	SubmissionID int64
+	Number       int // порядковый номер на странице, «Решение N»
+	Code         string
+}
+
+// reviewTaskView — состояние код-ревью задания на странице урока:
+// решения, ждущие рецензии, и уже полученные рецензии.
+type reviewTaskView struct {
+	Candidates []reviewCandidateView
+	Received   []progress.PeerReview
+}
+
+// buildReviewView подбирает анонимные решения задания на рецензию и
+// загружает полученные рецензии. Последняя успешная отправка исключается
+// из кандидатов: рецензируют чужой код, а не только что засчитанный свой.
+func (s *Server) buildReviewView(ctx context.Context, taskID int64) (reviewTaskView, error) {
+	// CURRENT_TIMESTAMP в SQLite даёт секундную точность, поэтому порядок
+	// «последних» отправок по времени неоднозначен — берём максимальный ID
+	var exceptID int64
+	if latest, err := s.progressRepo.GetSubmissionsByTaskID(ctx, taskID, 10); err == nil {
+		for _, sub := range latest {
+			if sub.Status == "success" && sub.ID > exceptID {
+				exceptID = sub.ID
+			}
+		}
+	}
+
+	candidates, err := s.progressRepo.GetReviewCandidates(ctx, taskID, exceptID, reviewAssignments)
+	if err != nil {
+		return reviewTaskView{}, err
+	}
+	received, err := s.progressRepo.GetPeerReviewsByTaskID(ctx, taskID)
+	if err != nil {
+		return reviewTaskView{}, err
+	}
+
+	view := reviewTaskView{Received: received}
+	for i, c := range candidates {
+		view.Candidates = append(view.Candidates, reviewCandidateView{
+			SubmissionID: c.ID,
+			Number:       i + 1,
+			Code:         c.Code,
+		})
+	}
+	return view, nil
+}
+
+// handleReviewSave — POST /api/tasks/{id}/reviews: сохранение рецензии
+// на чужое решение. За завершённую рецензию начисляется бонус.
+func (s *Server) handleReviewSave(w http.ResponseWriter, r *http.Request) {
+	taskID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid task ID")
+		return
+	}
+
+	var req struct {
+		SubmissionID int64  `json:"submission_id"`
+		Readability  int    `json:"readability"`
+		Correctness  int    `json:"correctness"`
+		Idiomatic    int    `json:"idiomatic"`
+		Comment      string `json:"comment"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if req.SubmissionID <= 0 {
+		s.badRequest(w, "Invalid submission ID")
+		return
+	}
+	for _, score := range []int{req.Readability, req.Correctness, req.Idiomatic} {
+		if score < 0 || score > 2 {
+			s.badRequest(w, "Оценка по критерию должна быть от 0 до 2")
+			return
+		}
+	}
+
+	review := &progress.PeerReview{
+		SubmissionID: req.SubmissionID,
+		TaskID:       taskID,
+		Readability:  req.Readability,
+		Correctness:  req.Correctness,
+		Idiomatic:    req.Idiomatic,
+		CommentMD:    req.Comment,
+		Points:       progress.PeerReviewBonus,
+	}
+	if err := s.progressRepo.CreatePeerReview(r.Context(), review); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"ok":     true,
+		"points": review.Points,
+	})
+}
@@ -0,0 +1,195 @@
+package web
+
+// Двухфакторная аутентификация для админ-ключа (ключа когорты):
+// TOTP-секрет регистрируется в приложении-аутентификаторе по QR-коду
+// или otpauth-ссылке, резервные коды хранятся хэшированными и гасятся
+// по одному. Включение подтверждается действующим кодом, чтобы не
+// запереть админку на неработающем секрете. На случай полной потери
+// доступа у cmd/server есть флаг --reset-2fa.
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"golearning/internal/auth"
+)
+
+// errNoAuthRepo — страница 2FA открыта без подключённого репозитория auth.
+var errNoAuthRepo = errors.New("репозиторий внешних аккаунтов не подключен")
+
+const (
+	// totpPendingKey — ключ kv-хранилища с незавершённой регистрацией 2FA.
+	totpPendingKey = "totp:pending"
+	// totpPendingTTL — сколько ждём подтверждения регистрации кодом.
+	totpPendingTTL = 15 * time.Minute
+	// totpBackupCount — число резервных кодов при включении 2FA.
+	totpBackupCount = 8
+	// totpIssuer — имя сервиса в приложении-аутентификаторе.
+	totpIssuer = "Go Learning"
+)
+
+// handleSecurity — страница настройки 2FA админ-ключа.
+func (s *Server) handleSecurity(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	secret, err := s.identities.TOTPSecret(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	enforced, err := s.identities.TOTPEnforced(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	backupLeft, err := s.identities.BackupCodesLeft(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "security.html", map[string]interface{}{
+		"Enabled":    secret != "",
+		"Enforced":   enforced,
+		"BackupLeft": backupLeft,
+		"PublicMode": s.opts.PublicMode,
+		"Error":      r.URL.Query().Get("error"),
+	})
+}
+
+// handleTOTPEnroll генерирует секрет и резервные коды и показывает их
+// один раз. 2FA включится только после подтверждения кодом.
+func (s *Server) handleTOTPEnroll(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	codes, err := auth.GenerateBackupCodes(totpBackupCount)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	// Хэши считаются при подтверждении, поэтому до него коды лежат
+	// в kv открытым текстом — недолго и только на сервере
+	pending := secret
+	for _, code := range codes {
+		pending += "," + code
+	}
+	if err := s.kv().Set(r.Context(), totpPendingKey, pending, totpPendingTTL); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "security.html", map[string]interface{}{
+		"Pending":         true,
+		"Secret":          secret,
+		"ProvisioningURL": auth.ProvisioningURL(secret, totpIssuer, "admin"),
+		"BackupCodes":     codes,
+		"PublicMode":      s.opts.PublicMode,
+	})
+}
+
+// handleTOTPConfirm сверяет код с ожидающим секретом и включает 2FA.
+func (s *Server) handleTOTPConfirm(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+	pending, ok, err := s.kv().Get(r.Context(), totpPendingKey)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if !ok {
+		http.Redirect(w, r, s.opts.BasePath+"/admin/security?error=expired", http.StatusSeeOther)
+		return
+	}
+	parts := strings.Split(pending, ",")
+	secret, codes := parts[0], parts[1:]
+	if !auth.VerifyTOTP(secret, r.FormValue("code"), time.Now()) {
+		http.Redirect(w, r, s.opts.BasePath+"/admin/security?error=code", http.StatusSeeOther)
+		return
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, auth.HashBackupCode(code))
+	}
+	if err := s.identities.EnableTOTP(r.Context(), secret, hashes); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	_ = s.kv().Delete(r.Context(), totpPendingKey)
+	s.recordAudit("auth.totp", "settings", 0, "Включена двухфакторная аутентификация")
+	http.Redirect(w, r, s.opts.BasePath+"/admin/security", http.StatusSeeOther)
+}
+
+// handleTOTPDisable выключает 2FA.
+func (s *Server) handleTOTPDisable(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	if err := s.identities.DisableTOTP(r.Context()); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.recordAudit("auth.totp", "settings", 0, "Выключена двухфакторная аутентификация")
+	http.Redirect(w, r, s.opts.BasePath+"/admin/security", http.StatusSeeOther)
+}
+
+// handleTOTPEnforce переключает требование кода 2FA при входе по админ-ключу.
+func (s *Server) handleTOTPEnforce(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+	enforced := r.FormValue("enforced") == "1"
+	if err := s.identities.SetTOTPEnforced(r.Context(), enforced); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	summary := "2FA для админ-ключа: не требуется"
+	if enforced {
+		summary = "2FA для админ-ключа: обязательна"
+	}
+	s.recordAudit("auth.totp", "settings", 0, summary)
+	http.Redirect(w, r, s.opts.BasePath+"/admin/security", http.StatusSeeOther)
+}
+
+// verifyCohortTOTP проверяет второй фактор при входе по ключу когорты:
+// сперва TOTP-код, затем одноразовый резервный код (восстановление).
+// Возвращает true, если 2FA не включена, не обязательна или код верен.
+func (s *Server) verifyCohortTOTP(r *http.Request, code string) bool {
+	if s.identities == nil {
+		return true
+	}
+	enforced, err := s.identities.TOTPEnforced(r.Context())
+	if err != nil || !enforced {
+		return err == nil
+	}
+	secret, err := s.identities.TOTPSecret(r.Context())
+	if err != nil || secret == "" {
+		return err == nil
+	}
+	if auth.VerifyTOTP(secret, code, time.Now()) {
+		return true
+	}
+	used, err := s.identities.UseBackupCode(r.Context(), code)
+	return err == nil && used
+}
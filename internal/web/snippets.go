@@ -0,0 +1,94 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/snippets"
+)
+
+// SetSnippets подключает репозиторий личной библиотеки фрагментов кода.
+func (s *Server) SetSnippets(repo *snippets.Repository) {
+	s.snippetsRepo = repo
+}
+
+// handleSnippets — страница библиотеки фрагментов кода.
+func (s *Server) handleSnippets(w http.ResponseWriter, r *http.Request) {
+	if s.snippetsRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+
+	items, err := s.snippetsRepo.List(query)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Snippets": items,
+		"Query":    query,
+		"Stats":    stats,
+	}
+
+	s.render(w, "snippets.html", data)
+}
+
+// handleCreateSnippet сохраняет новый фрагмент из формы.
+func (s *Server) handleCreateSnippet(w http.ResponseWriter, r *http.Request) {
+	if s.snippetsRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	title := strings.TrimSpace(r.FormValue("title"))
+	code := r.FormValue("code")
+	tags := strings.TrimSpace(r.FormValue("tags"))
+	lessonID, _ := strconv.ParseInt(r.FormValue("lesson_id"), 10, 64)
+
+	if title == "" || strings.TrimSpace(code) == "" {
+		s.badRequest(w, "Title and code are required")
+		return
+	}
+
+	snippet := &snippets.Snippet{
+		Title:    title,
+		Code:     code,
+		Tags:     tags,
+		LessonID: lessonID,
+	}
+	if err := s.snippetsRepo.Create(snippet); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/snippets", http.StatusSeeOther)
+}
+
+// handleDeleteSnippet удаляет фрагмент.
+func (s *Server) handleDeleteSnippet(w http.ResponseWriter, r *http.Request) {
+	if s.snippetsRepo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid snippet ID")
+		return
+	}
+
+	if err := s.snippetsRepo.Delete(id); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/snippets", http.StatusSeeOther)
+}
@@ -0,0 +1,81 @@
+package web
+
+// Выгрузка стартового каркаса capstone-проекта одним zip-архивом.
+// Файлы каркаса встроены рядом с ТЗ (lessons_mdx/Проекты/starter);
+// плейсхолдер __MODULE_PATH__ заменяется на module path из запроса.
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning"
+)
+
+// modulePathRE — допустимый go module path в ?module= (без пробелов,
+// кавычек и прочего, что могло бы сломать go.mod).
+var modulePathRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~/-]*$`)
+
+// handleProjectStarter отдаёт стартовый каркас проекта zip-архивом.
+func (s *Server) handleProjectStarter(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	known := false
+	for _, p := range capstoneProjects() {
+		if p.ID == projectID {
+			known = true
+			break
+		}
+	}
+	if !known {
+		http.NotFound(w, r)
+		return
+	}
+
+	modulePath := r.URL.Query().Get("module")
+	if modulePath == "" {
+		modulePath = "example.com/" + projectID
+	}
+	if !modulePathRE.MatchString(modulePath) {
+		s.badRequest(w, "Некорректный module path")
+		return
+	}
+
+	root := "lessons_mdx/Проекты/starter/" + projectID
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", projectID+"-starter.zip"))
+
+	zw := zip.NewWriter(w)
+	err := fs.WalkDir(golearning.StarterTemplatesFS, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := golearning.StarterTemplatesFS.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(path, root+"/")
+		// go.mod хранится как go.mod.tmpl (см. project_specs.go)
+		rel = strings.TrimSuffix(rel, ".tmpl")
+		f, err := zw.Create(projectID + "/" + rel)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(bytes.ReplaceAll(data, []byte("__MODULE_PATH__"), []byte(modulePath)))
+		return err
+	})
+	if err != nil {
+		// Заголовки уже ушли — остаётся только оборвать архив и залогировать
+		log.Printf("Ошибка сборки каркаса %s: %v", projectID, err)
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("Ошибка закрытия архива %s: %v", projectID, err)
+	}
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"context"
+
+	"golearning/internal/content"
+)
+
+// starterTaskView — состояние задания с обновлённым начальным кодом:
+// последняя отправка учащегося сделана до правки автором starter_code.
+type starterTaskView struct {
+	LastCode string // последний отправленный код учащегося
+}
+
+// buildStarterView проверяет, правился ли начальный код задания после
+// последней отправки учащегося. Если да — возвращает отправленный код,
+// чтобы страница показала предупреждение и дала вернуть его в редактор.
+// Второе значение false — предупреждение не нужно.
+func (s *Server) buildStarterView(ctx context.Context, task *content.Task) (starterTaskView, bool) {
+	if task.StarterVersion <= 1 {
+		return starterTaskView{}, false
+	}
+	subs, err := s.progressRepo.GetSubmissionsByTaskID(ctx, task.ID, 1)
+	if err != nil || len(subs) == 0 {
+		return starterTaskView{}, false
+	}
+	// Отправки до появления версий (starter_version = 0) не сравниваем:
+	// неизвестно, какой starter_code видел учащийся
+	last := subs[0]
+	if last.StarterVersion == 0 || last.StarterVersion >= task.StarterVersion {
+		return starterTaskView{}, false
+	}
+	return starterTaskView{LastCode: last.Code}, true
+}
@@ -1,11 +1,195 @@
 // Go Learning — JavaScript
 
+// Префикс пути при работе за reverse proxy (см. флаг --base-path)
+const BASE_PATH = document.documentElement.getAttribute('data-base-path') || '';
+
+function apiUrl(path) {
+    return BASE_PATH + path;
+}
+
 document.addEventListener('DOMContentLoaded', () => {
     initStatusButtons();
     initCodeEditors();
+    initPredictTasks();
+    initClozeTasks();
+    initGuidedTasks();
+    initPeerReviews();
     initNotesEditor();
+    initVocabSelection();
+    initDashboardWidgets();
+    initIssueReport();
+    initChangelogModal();
+    initAskCourse();
+    initTocScrollSync();
+    initReaderControls();
+    initHintTracking();
+    initProjectChecklists();
+    initTotpQR();
+    initSectionAudio();
 });
 
+// ========================================
+// Озвучка секций урока (TTS): позиция воспроизведения переживает
+// перезагрузку страницы через localStorage
+// ========================================
+
+function initSectionAudio() {
+    document.querySelectorAll('audio.section-audio[data-audio-key]').forEach((player) => {
+        const key = 'audio-pos:' + player.dataset.audioKey;
+
+        player.addEventListener('loadedmetadata', () => {
+            const saved = parseFloat(localStorage.getItem(key));
+            if (saved > 0 && saved < player.duration - 1) {
+                player.currentTime = saved;
+            }
+        });
+
+        let lastSaved = 0;
+        player.addEventListener('timeupdate', () => {
+            if (player.currentTime - lastSaved < 5) return;
+            lastSaved = player.currentTime;
+            localStorage.setItem(key, String(player.currentTime));
+        });
+
+        player.addEventListener('ended', () => {
+            localStorage.removeItem(key);
+        });
+
+        // Одновременно играет только один плеер
+        player.addEventListener('play', () => {
+            document.querySelectorAll('audio.section-audio').forEach((other) => {
+                if (other !== player) other.pause();
+            });
+        });
+    });
+}
+
+// ========================================
+// QR-код регистрации 2FA (страница /admin/security)
+// ========================================
+
+function initTotpQR() {
+    const el = document.querySelector('.totp-qr[data-url]');
+    if (!el || typeof QRCode === 'undefined') return;
+    new QRCode(el, { text: el.dataset.url, width: 192, height: 192 });
+}
+
+// ========================================
+// Project Checklists (чек-листы capstone-проектов)
+// ========================================
+
+function initProjectChecklists() {
+    document.querySelectorAll('.project-card[data-project-id]').forEach((card) => {
+        const projectId = card.dataset.projectId;
+        const label = card.querySelector('.project-progress-label');
+        const bar = card.querySelector('.project-progress .progress-bar');
+
+        card.querySelectorAll('.project-body input[type="checkbox"]').forEach((box, index) => {
+            box.disabled = false;
+            box.addEventListener('change', async () => {
+                try {
+                    const response = await fetch(apiUrl(`/api/projects/${projectId}/milestones`), {
+                        method: 'POST',
+                        headers: { 'Content-Type': 'application/json' },
+                        body: JSON.stringify({ index, done: box.checked }),
+                    });
+                    if (!response.ok) throw new Error();
+                    const data = await response.json();
+                    if (label) label.textContent = `Готово ${data.done_count} из ${data.total} (${data.percent}%)`;
+                    if (bar) bar.style.width = `${data.percent}%`;
+                } catch (e) {
+                    // Отметка не сохранилась — возвращаем чекбокс как было
+                    box.checked = !box.checked;
+                }
+            });
+        });
+    });
+}
+
+// ========================================
+// Hint Tracking (отметка раскрытия подсказок)
+// ========================================
+
+function initHintTracking() {
+    document.querySelectorAll('.task-card .task-hints').forEach((details) => {
+        let reported = false;
+        details.addEventListener('toggle', () => {
+            if (!details.open || reported) return;
+            reported = true;
+
+            const taskId = details.closest('.task-card').dataset.taskId;
+            // Фоновая отметка: сбой отправки не мешает читать подсказки
+            fetch(apiUrl(`/api/tasks/${taskId}/hint`), { method: 'POST' }).catch(() => {});
+        });
+    });
+}
+
+// ========================================
+// Reader Controls (режим чтения)
+// ========================================
+
+function initReaderControls() {
+    const body = document.querySelector('.read-body');
+    if (!body) return;
+
+    const current = () => ({
+        width: body.className.match(/read-width-(\w+)/)[1],
+        font: body.className.match(/read-font-(\w+)/)[1],
+        size: body.className.match(/read-size-(\w+)/)[1],
+    });
+
+    document.querySelectorAll('.read-control-group').forEach((group) => {
+        const pref = group.dataset.pref;
+        group.querySelectorAll('button').forEach((btn) => {
+            btn.addEventListener('click', async () => {
+                const prefs = current();
+                prefs[pref] = btn.dataset.value;
+
+                // Применяем сразу, сохраняем в фоне
+                body.className = `read-body read-width-${prefs.width} read-font-${prefs.font} read-size-${prefs.size}`;
+                group.querySelectorAll('button').forEach((b) => b.classList.remove('active'));
+                btn.classList.add('active');
+
+                try {
+                    await fetch(apiUrl('/api/reader/prefs'), {
+                        method: 'POST',
+                        headers: { 'Content-Type': 'application/json' },
+                        body: JSON.stringify(prefs),
+                    });
+                } catch (error) {
+                    // Настройка применена локально; сохранение повторится при следующем клике
+                }
+            });
+        });
+    });
+}
+
+// ========================================
+// TOC Scroll Sync (подсветка текущего раздела)
+// ========================================
+
+function initTocScrollSync() {
+    const toc = document.querySelector('.toc');
+    const article = document.querySelector('.lesson-content');
+    if (!toc || !article || !('IntersectionObserver' in window)) return;
+
+    // Секции урока и заголовки внутри них с якорями из оглавления
+    const targets = article.querySelectorAll('section[id], h2[id], h3[id], h4[id]');
+    if (!targets.length) return;
+
+    const observer = new IntersectionObserver((entries) => {
+        for (const entry of entries) {
+            if (!entry.isIntersecting) continue;
+            const link = toc.querySelector(`a[href="#${CSS.escape(entry.target.id)}"]`);
+            if (!link) continue;
+            toc.querySelectorAll('a.active').forEach((a) => a.classList.remove('active'));
+            link.classList.add('active');
+        }
+    }, { rootMargin: '0px 0px -70% 0px' });
+
+    targets.forEach((el) => observer.observe(el));
+}
+
 // ========================================
 // Status Buttons (прогресс урока)
 // ========================================
@@ -17,7 +201,7 @@ function initStatusButtons() {
             const status = btn.dataset.status;
             
             try {
-                const response = await fetch(`/api/progress/lesson/${lessonId}`, {
+                const response = await fetch(apiUrl(`/api/progress/lesson/${lessonId}`), {
                     method: 'POST',
                     headers: { 'Content-Type': 'application/json' },
                     body: JSON.stringify({ status })
@@ -88,6 +272,17 @@ function initCodeEditors() {
         // Функция получения кода
         const getCode = () => editor.getValue();
 
+        // Подчёркивание строк с ошибками компиляции пропадает при правке
+        editor.on('change', () => clearErrorLines(editor));
+
+        // Начальный код обновился — кнопка возвращает последний
+        // отправленный код учащегося в редактор
+        const restoreBtn = card.querySelector('.starter-restore-btn');
+        const lastCode = card.querySelector('.starter-last-code');
+        restoreBtn?.addEventListener('click', () => {
+            if (lastCode) editor.setValue(lastCode.value);
+        });
+
         // Запуск кода
         runBtn?.addEventListener('click', async () => {
             const code = getCode();
@@ -99,7 +294,7 @@ function initCodeEditors() {
             outputContent.textContent = 'Выполняется...';
             
             try {
-                const response = await fetch('/api/run', {
+                const response = await fetch(apiUrl('/api/run'), {
                     method: 'POST',
                     headers: { 'Content-Type': 'application/json' },
                     body: JSON.stringify({ code })
@@ -134,7 +329,7 @@ function initCodeEditors() {
             outputContent.textContent = 'Проверяем...';
             
             try {
-                const response = await fetch('/api/check', {
+                const response = await fetch(apiUrl('/api/check'), {
                     method: 'POST',
                     headers: { 'Content-Type': 'application/json' },
                     body: JSON.stringify({ task_id: parseInt(taskId), code })
@@ -160,13 +355,15 @@ function initCodeEditors() {
                         pointsBadge.classList.add('completed');
                     }
                     card.setAttribute('data-completed', 'true');
-                    
+                    clearErrorLines(editor);
+                    hideLLMHint(card);
+
                     // Обновляем статистику в шапке
                     updateHeaderStats();
                 } else {
                     outputDiv.className = 'task-output error';
                     let message = '❌ ' + (result.Error || 'Решение неверное');
-                    
+
                     // Показываем вывод программы если есть
                     if (result.Output) {
                         message += '\n\n📤 Ваш вывод:\n' + result.Output;
@@ -181,8 +378,14 @@ function initCodeEditors() {
                     if (result.Hints && result.Hints.length > 0) {
                         message += '\n\n💡 Подсказки:\n' + result.Hints.join('\n');
                     }
-                    
+
                     outputContent.textContent = message;
+
+                    // Подчёркиваем строки с ошибками компиляции/синтаксиса
+                    markErrorLines(editor, result.SyntaxErrors);
+
+                    // Предлагаем LLM-подсказку, если отправка записана
+                    offerLLMHint(card, result.SubmissionID);
                 }
             } catch (error) {
                 outputDiv.className = 'task-output error';
@@ -195,6 +398,376 @@ function initCodeEditors() {
     });
 }
 
+// ========================================
+// LLM-подсказки по проваленным проверкам (флаг llm-hints)
+// ========================================
+
+function hideLLMHint(card) {
+    const block = card.querySelector('.llm-hint');
+    if (block) block.style.display = 'none';
+}
+
+function offerLLMHint(card, submissionId) {
+    const block = card.querySelector('.llm-hint');
+    if (!block || !submissionId) return;
+
+    block.style.display = 'block';
+    const btn = block.querySelector('.llm-hint-btn');
+    const text = block.querySelector('.llm-hint-text');
+    text.style.display = 'none';
+    btn.style.display = '';
+    btn.disabled = false;
+
+    btn.onclick = async () => {
+        btn.disabled = true;
+        btn.textContent = '⏳ Думаю...';
+        try {
+            const response = await fetch(apiUrl(`/api/submissions/${submissionId}/llm-hint`), { method: 'POST' });
+            if (!response.ok) {
+                const detail = await response.text();
+                text.textContent = '❌ ' + (detail.trim() || 'Не удалось получить подсказку');
+            } else {
+                const result = await response.json();
+                text.textContent = '💡 ' + result.hint;
+                btn.style.display = 'none';
+            }
+            text.style.display = 'block';
+        } catch (error) {
+            text.textContent = 'Ошибка сети: ' + error.message;
+            text.style.display = 'block';
+        } finally {
+            btn.disabled = false;
+            btn.textContent = '💡 Попросить подсказку';
+        }
+    };
+}
+
+// ========================================
+// Подсветка строк с ошибками компиляции в CodeMirror
+// ========================================
+
+function clearErrorLines(editor) {
+    (editor._errorLines || []).forEach((line) => {
+        editor.removeLineClass(line, 'background', 'error-line');
+    });
+    editor._errorLines = [];
+}
+
+function markErrorLines(editor, errors) {
+    clearErrorLines(editor);
+    if (!errors) return;
+    errors.forEach((e) => {
+        // Позиции приходят в виде {file, line, col, message}, строки с 1
+        const line = e.line - 1;
+        if (line < 0 || line >= editor.lineCount()) return;
+        editor.addLineClass(line, 'background', 'error-line');
+        editor._errorLines.push(line);
+    });
+}
+
+// ========================================
+// Predict-the-output Tasks (задания «предскажи вывод»)
+// ========================================
+
+function initPredictTasks() {
+    document.querySelectorAll('.task-card').forEach(card => {
+        const taskId = card.dataset.taskId;
+        const input = card.querySelector('.predict-input');
+        const checkBtn = card.querySelector('.predict-check-btn');
+        const outputDiv = card.querySelector('.task-output');
+        const outputContent = card.querySelector('.output-content');
+
+        if (!input || !checkBtn) return;
+
+        checkBtn.addEventListener('click', async () => {
+            const prediction = input.value;
+
+            if (!prediction.trim()) {
+                outputDiv.style.display = 'block';
+                outputDiv.className = 'task-output error';
+                outputContent.textContent = 'Введите ваш прогноз вывода';
+                return;
+            }
+
+            checkBtn.disabled = true;
+            checkBtn.textContent = '⏳ Проверка...';
+            outputDiv.style.display = 'block';
+            outputDiv.className = 'task-output';
+            outputContent.textContent = 'Проверяем...';
+
+            try {
+                // Прогноз отправляется в поле code — сервер сам знает,
+                // что это задание типа predict_output
+                const response = await fetch(apiUrl('/api/check'), {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ task_id: parseInt(taskId), code: prediction })
+                });
+
+                const result = await response.json();
+
+                if (result.Success) {
+                    outputDiv.className = 'task-output success';
+                    let message = '✅ Прогноз верный!';
+                    if (result.PointsAwarded) {
+                        message += `\n🏆 +${result.PointsAwarded} очков!`;
+                    }
+                    outputContent.textContent = message;
+
+                    const pointsBadge = card.querySelector('.task-points');
+                    if (pointsBadge && !pointsBadge.classList.contains('completed')) {
+                        pointsBadge.textContent = '✅ Выполнено';
+                        pointsBadge.classList.add('completed');
+                    }
+                    card.setAttribute('data-completed', 'true');
+
+                    updateHeaderStats();
+                } else {
+                    outputDiv.className = 'task-output error';
+                    let message = '❌ ' + (result.Error || 'Прогноз неверный');
+                    if (result.Hints && result.Hints.length > 0) {
+                        message += '\n\n💡 ' + result.Hints.join('\n');
+                    }
+                    outputContent.textContent = message;
+                }
+            } catch (error) {
+                outputDiv.className = 'task-output error';
+                outputContent.textContent = 'Ошибка сети: ' + error.message;
+            } finally {
+                checkBtn.disabled = false;
+                checkBtn.textContent = '✓ Проверить прогноз';
+            }
+        });
+    });
+}
+
+// ========================================
+// Guided Tasks (пошаговые задания)
+// ========================================
+
+function initGuidedTasks() {
+    document.querySelectorAll('.task-card').forEach(card => {
+        const taskId = card.dataset.taskId;
+        const textarea = card.querySelector('.guided-input');
+        const checkBtn = card.querySelector('.guided-check-btn');
+        const outputDiv = card.querySelector('.task-output');
+        const outputContent = card.querySelector('.output-content');
+
+        if (!textarea || !checkBtn) return;
+
+        // Редактор для текущего шага (если CodeMirror доступен)
+        let editor = null;
+        if (typeof CodeMirror !== 'undefined') {
+            editor = CodeMirror.fromTextArea(textarea, {
+                mode: 'text/x-go',
+                theme: 'monokai',
+                lineNumbers: true,
+                indentUnit: 4,
+                tabSize: 4,
+                indentWithTabs: true,
+                matchBrackets: true,
+                autoCloseBrackets: true
+            });
+            editor.setSize(null, 250);
+        }
+        const getCode = () => editor ? editor.getValue() : textarea.value;
+
+        checkBtn.addEventListener('click', async () => {
+            const code = getCode();
+            const step = parseInt(checkBtn.dataset.step);
+
+            checkBtn.disabled = true;
+            checkBtn.textContent = '⏳ Проверка...';
+            outputDiv.style.display = 'block';
+            outputDiv.className = 'task-output';
+            outputContent.textContent = 'Проверяем...';
+
+            try {
+                const response = await fetch(apiUrl(`/api/tasks/${taskId}/guided/check`), {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ step, code })
+                });
+
+                const result = await response.json();
+
+                if (result.Success) {
+                    outputDiv.className = 'task-output success';
+                    let message = result.Completed
+                        ? '🎉 Последний шаг пройден — задание выполнено!'
+                        : '✅ Шаг пройден! Открываем следующий...';
+                    if (result.PointsAwarded) {
+                        message += `\n🏆 +${result.PointsAwarded} очков!`;
+                    }
+                    if (result.Output) {
+                        message += '\n\n📤 Вывод программы:\n' + result.Output;
+                    }
+                    outputContent.textContent = message;
+
+                    // Перезагрузка открывает следующий шаг с перенесённым кодом
+                    updateHeaderStats();
+                } else {
+                    outputDiv.className = 'task-output error';
+                    let message = '❌ ' + (result.Error || 'Шаг не пройден');
+                    if (result.Output) {
+                        message += '\n\n📤 Ваш вывод:\n' + result.Output;
+                    }
+                    if (result.Expected) {
+                        message += '\n\n📋 Ожидаемый вывод:\n' + result.Expected;
+                    }
+                    if (result.Hints && result.Hints.length > 0) {
+                        message += '\n\n💡 Подсказки:\n' + result.Hints.join('\n');
+                    }
+                    outputContent.textContent = message;
+                }
+            } catch (error) {
+                outputDiv.className = 'task-output error';
+                outputContent.textContent = 'Ошибка сети: ' + error.message;
+            } finally {
+                checkBtn.disabled = false;
+                checkBtn.textContent = '✓ Проверить шаг';
+            }
+        });
+    });
+}
+
+// ========================================
+// Cloze Tasks (задания с пропусками)
+// ========================================
+
+function initClozeTasks() {
+    document.querySelectorAll('.task-card').forEach(card => {
+        const taskId = card.dataset.taskId;
+        const blanks = card.querySelectorAll('.cloze-blank');
+        const checkBtn = card.querySelector('.cloze-check-btn');
+        const outputDiv = card.querySelector('.task-output');
+        const outputContent = card.querySelector('.output-content');
+
+        if (!blanks.length || !checkBtn) return;
+
+        checkBtn.addEventListener('click', async () => {
+            const answers = Array.from(blanks).map(input => input.value);
+
+            if (answers.some(a => !a.trim())) {
+                outputDiv.style.display = 'block';
+                outputDiv.className = 'task-output error';
+                outputContent.textContent = 'Заполните все пропуски';
+                return;
+            }
+
+            checkBtn.disabled = true;
+            checkBtn.textContent = '⏳ Проверка...';
+            outputDiv.style.display = 'block';
+            outputDiv.className = 'task-output';
+            outputContent.textContent = 'Проверяем...';
+
+            try {
+                const response = await fetch(apiUrl('/api/check'), {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ task_id: parseInt(taskId), blanks: answers })
+                });
+
+                const result = await response.json();
+
+                if (result.Success) {
+                    outputDiv.className = 'task-output success';
+                    let message = '✅ Задание выполнено правильно!';
+                    if (result.PointsAwarded) {
+                        message += `\n🏆 +${result.PointsAwarded} очков!`;
+                    }
+                    if (result.Output) {
+                        message += '\n\n📤 Вывод программы:\n' + result.Output;
+                    }
+                    outputContent.textContent = message;
+
+                    const pointsBadge = card.querySelector('.task-points');
+                    if (pointsBadge && !pointsBadge.classList.contains('completed')) {
+                        pointsBadge.textContent = '✅ Выполнено';
+                        pointsBadge.classList.add('completed');
+                    }
+                    card.setAttribute('data-completed', 'true');
+
+                    updateHeaderStats();
+                } else {
+                    outputDiv.className = 'task-output error';
+                    let message = '❌ ' + (result.Error || 'Решение неверное');
+                    if (result.Output) {
+                        message += '\n\n📤 Ваш вывод:\n' + result.Output;
+                    }
+                    if (result.Expected) {
+                        message += '\n\n📋 Ожидаемый вывод:\n' + result.Expected;
+                    }
+                    if (result.Hints && result.Hints.length > 0) {
+                        message += '\n\n💡 Подсказки:\n' + result.Hints.join('\n');
+                    }
+                    outputContent.textContent = message;
+                }
+            } catch (error) {
+                outputDiv.className = 'task-output error';
+                outputContent.textContent = 'Ошибка сети: ' + error.message;
+            } finally {
+                checkBtn.disabled = false;
+                checkBtn.textContent = '✓ Проверить';
+            }
+        });
+    });
+}
+
+// ========================================
+// Peer Review (код-ревью чужих решений)
+// ========================================
+
+function initPeerReviews() {
+    document.querySelectorAll('.review-candidate').forEach(candidate => {
+        const card = candidate.closest('.task-card');
+        const taskId = card ? card.dataset.taskId : null;
+        const submitBtn = candidate.querySelector('.review-submit-btn');
+        if (!taskId || !submitBtn) return;
+
+        submitBtn.addEventListener('click', async () => {
+            const scores = {};
+            candidate.querySelectorAll('.review-score').forEach(select => {
+                scores[select.dataset.criterion] = parseInt(select.value);
+            });
+            const comment = candidate.querySelector('.review-comment').value;
+
+            submitBtn.disabled = true;
+            submitBtn.textContent = '⏳ Отправка...';
+
+            try {
+                const response = await fetch(apiUrl(`/api/tasks/${taskId}/reviews`), {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({
+                        submission_id: parseInt(candidate.dataset.submissionId),
+                        readability: scores.readability,
+                        correctness: scores.correctness,
+                        idiomatic: scores.idiomatic,
+                        comment: comment
+                    })
+                });
+
+                if (!response.ok) {
+                    throw new Error('Сервер вернул ошибку');
+                }
+                const result = await response.json();
+
+                const done = document.createElement('p');
+                done.className = 'review-done';
+                done.textContent = `✅ Рецензия сохранена! 🏆 +${result.points} очков`;
+                candidate.replaceChildren(done);
+
+                updateHeaderStats();
+            } catch (error) {
+                submitBtn.disabled = false;
+                submitBtn.textContent = '📨 Отправить рецензию';
+                alert('Не удалось сохранить рецензию: ' + error.message);
+            }
+        });
+    });
+}
+
 // Обновление статистики в шапке после получения очков
 async function updateHeaderStats() {
     try {
@@ -228,7 +801,7 @@ function initTaskActionsFallback() {
             outputContent.textContent = 'Выполняется...';
             
             try {
-                const response = await fetch('/api/run', {
+                const response = await fetch(apiUrl('/api/run'), {
                     method: 'POST',
                     headers: { 'Content-Type': 'application/json' },
                     body: JSON.stringify({ code })
@@ -262,7 +835,7 @@ function initTaskActionsFallback() {
             outputContent.textContent = 'Проверяем...';
             
             try {
-                const response = await fetch('/api/check', {
+                const response = await fetch(apiUrl('/api/check'), {
                     method: 'POST',
                     headers: { 'Content-Type': 'application/json' },
                     body: JSON.stringify({ task_id: parseInt(taskId), code })
@@ -317,6 +890,240 @@ function initTaskActionsFallback() {
     });
 }
 
+// ========================================
+// Changelog («что нового» после обновления)
+// ========================================
+
+async function initChangelogModal() {
+    let release;
+    try {
+        const response = await fetch(apiUrl('/api/changelog'));
+        if (!response.ok || !(response.headers.get('Content-Type') || '').includes('json')) return;
+        release = await response.json();
+    } catch (error) {
+        return;
+    }
+    if (!release.show) return;
+
+    const overlay = document.createElement('div');
+    overlay.className = 'changelog-overlay';
+    overlay.innerHTML = `
+        <div class="changelog-modal">
+            <h2>🎉 Что нового в версии ${release.version}</h2>
+            <div class="changelog-notes markdown"></div>
+            <div class="task-actions">
+                <button class="btn btn-primary changelog-ok-btn">Понятно</button>
+            </div>
+        </div>`;
+    overlay.querySelector('.changelog-notes').innerHTML = release.html;
+    document.body.appendChild(overlay);
+
+    overlay.querySelector('.changelog-ok-btn').addEventListener('click', async () => {
+        overlay.remove();
+        try {
+            await fetch(apiUrl('/api/changelog/ack'), {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ version: release.version })
+            });
+        } catch (error) {
+            // Не подтвердилось — покажем ещё раз в следующий визит
+        }
+    });
+}
+
+// ========================================
+// Issue Report (жалобы на ошибки в материалах)
+// ========================================
+
+function initIssueReport() {
+    const form = document.querySelector('.issue-form');
+    if (!form) return;
+
+    const lessonId = form.dataset.lessonId;
+    const target = form.querySelector('.issue-form-target');
+    const statusEl = form.querySelector('.issue-status');
+    let taskId = '';
+
+    document.querySelectorAll('.issue-report-btn').forEach(btn => {
+        btn.addEventListener('click', () => {
+            taskId = btn.dataset.taskId || '';
+            target.hidden = !taskId;
+            if (taskId) target.querySelector('span').textContent = btn.dataset.taskTitle;
+            form.hidden = false;
+            statusEl.textContent = '';
+            form.scrollIntoView({ behavior: 'smooth', block: 'center' });
+            form.querySelector('.issue-message').focus();
+        });
+    });
+
+    form.querySelector('.issue-cancel-btn').addEventListener('click', () => {
+        form.hidden = true;
+    });
+
+    form.querySelector('.issue-submit-btn').addEventListener('click', async () => {
+        const message = form.querySelector('.issue-message').value.trim();
+        if (!message) {
+            statusEl.textContent = 'Опишите проблему';
+            return;
+        }
+        try {
+            const response = await fetch(apiUrl('/api/issues'), {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({
+                    lesson_id: Number(lessonId),
+                    task_id: Number(taskId) || 0,
+                    kind: form.querySelector('.issue-kind').value,
+                    message
+                })
+            });
+            if (response.ok) {
+                statusEl.textContent = '✓ Спасибо! Жалоба отправлена.';
+                form.querySelector('.issue-message').value = '';
+                setTimeout(() => { form.hidden = true; }, 1500);
+            } else {
+                statusEl.textContent = '❌ Не удалось отправить жалобу';
+            }
+        } catch (error) {
+            statusEl.textContent = '❌ Ошибка сети';
+        }
+    });
+}
+
+// ========================================
+// Dashboard Widgets (настройка главной страницы)
+// ========================================
+
+function initDashboardWidgets() {
+    const container = document.querySelector('.widgets');
+    const editBtn = document.querySelector('.widgets-edit-btn');
+    if (!container || !editBtn) return;
+
+    const disabledPanel = document.querySelector('.widgets-disabled');
+    let editing = false;
+
+    function currentLayout() {
+        return [...container.querySelectorAll('.widget')].map(w => w.dataset.widgetId);
+    }
+
+    async function saveLayout(reload) {
+        try {
+            const response = await fetch(apiUrl('/api/dashboard/widgets'), {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ widgets: currentLayout() })
+            });
+            if (response.ok && reload) window.location.reload();
+        } catch (error) {
+            // Порядок останется прежним после перезагрузки — не страшно
+        }
+    }
+
+    editBtn.addEventListener('click', () => {
+        editing = !editing;
+        editBtn.textContent = editing ? '✓ Готово' : '⚙ Настроить виджеты';
+        if (disabledPanel) disabledPanel.hidden = !editing;
+        container.querySelectorAll('.widget').forEach(w => {
+            w.draggable = editing;
+            w.classList.toggle('widget-editing', editing);
+            w.querySelector('.widget-remove-btn').hidden = !editing;
+        });
+    });
+
+    // Перетаскивание виджетов в режиме настройки
+    let dragged = null;
+    container.addEventListener('dragstart', (e) => {
+        dragged = e.target.closest('.widget');
+    });
+    container.addEventListener('dragover', (e) => {
+        if (!dragged) return;
+        e.preventDefault();
+        const over = e.target.closest('.widget');
+        if (!over || over === dragged) return;
+        const rect = over.getBoundingClientRect();
+        const before = e.clientY < rect.top + rect.height / 2;
+        container.insertBefore(dragged, before ? over : over.nextSibling);
+    });
+    container.addEventListener('dragend', () => {
+        if (!dragged) return;
+        dragged = null;
+        saveLayout(false);
+    });
+
+    // Скрытие и возврат виджетов
+    container.querySelectorAll('.widget-remove-btn').forEach(btn => {
+        btn.addEventListener('click', () => {
+            btn.closest('.widget').remove();
+            saveLayout(true);
+        });
+    });
+    document.querySelectorAll('.widget-enable-btn').forEach(btn => {
+        btn.addEventListener('click', async () => {
+            const layout = currentLayout();
+            layout.push(btn.dataset.widgetId);
+            try {
+                const response = await fetch(apiUrl('/api/dashboard/widgets'), {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ widgets: layout })
+                });
+                if (response.ok) window.location.reload();
+            } catch (error) {
+                // Страница просто останется прежней
+            }
+        });
+    });
+}
+
+// ========================================
+// Vocabulary (добавление термина выделением в уроке)
+// ========================================
+
+function initVocabSelection() {
+    const content = document.querySelector('.lesson-content');
+    if (!content) return;
+
+    const lessonId = document.querySelector('[data-lesson-id]')?.dataset.lessonId || '';
+
+    const btn = document.createElement('button');
+    btn.className = 'btn btn-secondary vocab-select-btn';
+    btn.textContent = '➕ В словарь';
+    btn.hidden = true;
+    document.body.appendChild(btn);
+
+    document.addEventListener('selectionchange', () => {
+        const selection = window.getSelection();
+        const term = selection ? selection.toString().trim() : '';
+        // Кнопка только для коротких выделений внутри текста урока
+        if (!term || term.length > 60 || !selection.anchorNode ||
+            !content.contains(selection.anchorNode)) {
+            btn.hidden = true;
+            return;
+        }
+        const rect = selection.getRangeAt(0).getBoundingClientRect();
+        btn.style.top = (window.scrollY + rect.bottom + 8) + 'px';
+        btn.style.left = (window.scrollX + rect.left) + 'px';
+        btn.hidden = false;
+    });
+
+    btn.addEventListener('click', async () => {
+        const term = window.getSelection()?.toString().trim();
+        if (!term) return;
+        const body = new URLSearchParams({ term, lesson_id: lessonId });
+        try {
+            const response = await fetch(apiUrl('/vocabulary/add'), { method: 'POST', body });
+            btn.textContent = response.ok ? '✓ Добавлено' : '❌ Ошибка';
+        } catch (error) {
+            btn.textContent = '❌ Ошибка сети';
+        }
+        setTimeout(() => {
+            btn.hidden = true;
+            btn.textContent = '➕ В словарь';
+        }, 1500);
+    });
+}
+
 // ========================================
 // Notes Editor
 // ========================================
@@ -350,7 +1157,7 @@ function initNotesEditor() {
         statusSpan.textContent = 'Сохранение...';
         
         try {
-            const response = await fetch(`/api/notes/lesson/${lessonId}`, {
+            const response = await fetch(apiUrl(`/api/notes/lesson/${lessonId}`), {
                 method: 'POST',
                 headers: { 'Content-Type': 'application/json' },
                 body: JSON.stringify({ note })
@@ -380,7 +1187,7 @@ async function resetProgress() {
     }
     
     try {
-        const response = await fetch('/api/progress/reset', {
+        const response = await fetch(apiUrl('/api/progress/reset'), {
             method: 'POST',
             headers: { 'Content-Type': 'application/json' }
         });
@@ -395,3 +1202,59 @@ async function resetProgress() {
         alert('❌ Ошибка сети: ' + error.message);
     }
 }
+
+// ========================================
+// «Спросить курс» (страница /ask)
+function initAskCourse() {
+    const form = document.getElementById('ask-form');
+    if (!form) return;
+
+    const question = document.getElementById('ask-question');
+    const statusEl = document.getElementById('ask-status');
+    const answerEl = document.getElementById('ask-answer');
+    const sourcesEl = document.getElementById('ask-sources');
+    const sourcesList = document.getElementById('ask-sources-list');
+
+    form.addEventListener('submit', async (e) => {
+        e.preventDefault();
+        const text = question.value.trim();
+        if (!text) return;
+
+        statusEl.textContent = '⏳ Ищем ответ в уроках...';
+        answerEl.hidden = true;
+        sourcesEl.hidden = true;
+
+        try {
+            const response = await fetch(apiUrl('/api/ask'), {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ question: text })
+            });
+            if (!response.ok) {
+                statusEl.textContent = '❌ Не удалось получить ответ';
+                return;
+            }
+            const data = await response.json();
+
+            statusEl.textContent = '';
+            answerEl.textContent = data.answer;
+            answerEl.hidden = false;
+
+            sourcesList.innerHTML = '';
+            const seen = new Set();
+            (data.sources || []).forEach((src, i) => {
+                const li = document.createElement('li');
+                const link = document.createElement('a');
+                link.href = apiUrl('/lessons/' + src.lesson_slug);
+                link.textContent = src.lesson_title;
+                li.append('[' + (i + 1) + '] ', link);
+                if (seen.has(src.lesson_slug)) li.classList.add('ask-source-repeat');
+                seen.add(src.lesson_slug);
+                sourcesList.appendChild(li);
+            });
+            sourcesEl.hidden = sourcesList.children.length === 0;
+        } catch (error) {
+            statusEl.textContent = '❌ Ошибка сети: ' + error.message;
+        }
+    });
+}
@@ -0,0 +1,63 @@
+package web
+
+// Темизация (--theme-dir): оператор перекрывает любой встроенный
+// шаблон или файл статики, положив одноимённый файл в каталог темы
+// (templates/ и static/ внутри него). Порядок поиска — тема, затем
+// встроенные файлы, так что брендирование не требует форка.
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// themeFS — файловая система с приоритетом темы: файл ищется в
+// каталоге темы, при отсутствии — во встроенной статике.
+type themeFS struct {
+	theme    fs.FS
+	fallback fs.FS
+}
+
+func (t themeFS) Open(name string) (fs.File, error) {
+	if f, err := t.theme.Open(name); err == nil {
+		return f, nil
+	}
+	return t.fallback.Open(name)
+}
+
+// overlayThemeStatic накладывает static/ темы поверх встроенной
+// статики и пересчитывает хэши перекрытых файлов, чтобы cache-busting
+// работал и для файлов темы. Без каталога static/ возвращается
+// встроенная статика как есть.
+func overlayThemeStatic(themeDir string, embedded fs.FS, hashes map[string]string) (fs.FS, error) {
+	staticDir := filepath.Join(themeDir, "static")
+	if info, err := os.Stat(staticDir); err != nil || !info.IsDir() {
+		return embedded, nil
+	}
+
+	themeStatic := os.DirFS(staticDir)
+	themeHashes, err := hashStaticAssets(themeStatic)
+	if err != nil {
+		return nil, fmt.Errorf("hash theme assets: %w", err)
+	}
+	for name, hashed := range themeHashes {
+		hashes[name] = hashed
+	}
+	return themeFS{theme: themeStatic, fallback: embedded}, nil
+}
+
+// loadThemeTemplates допарсивает шаблоны темы поверх уже загруженных:
+// одноимённый шаблон из темы замещает встроенный. Отсутствие
+// templates/ в теме — не ошибка.
+func loadThemeTemplates(tmpl *template.Template, themeDir string) error {
+	matches, err := filepath.Glob(filepath.Join(themeDir, "templates", "*.html"))
+	if err != nil || len(matches) == 0 {
+		return err
+	}
+	if _, err := tmpl.ParseFiles(matches...); err != nil {
+		return fmt.Errorf("parse theme templates: %w", err)
+	}
+	return nil
+}
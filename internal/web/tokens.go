@@ -0,0 +1,183 @@
+package web
+
+// API-токены для REST API (/api/v1): создаются на /admin/tokens,
+// передаются клиентом в заголовке Authorization: Bearer. Токен даёт
+// роль registered (доступ к закрытым модулям уровня registered),
+// запросы с ним считаются и ограничиваются персональным rate-лимитом.
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golearning/internal/auth"
+)
+
+// apiTokenMiddleware аутентифицирует запросы REST API по Bearer-токену.
+// Запросы без заголовка проходят как раньше — анонимно; неизвестный
+// токен отклоняется, чтобы клиент заметил опечатку или отзыв.
+func (s *Server) apiTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") || s.identities == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		plain := strings.TrimPrefix(header, "Bearer ")
+		token, err := s.identities.FindTokenByHash(r.Context(), auth.HashToken(plain))
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		if token == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Неизвестный или отозванный API-токен"}`))
+			return
+		}
+
+		if token.RateLimitPerMin > 0 {
+			count, err := s.kv().Incr(r.Context(), fmt.Sprintf("tokenrate:%d", token.ID), rateLimitWindow)
+			if err != nil {
+				// Хранилище недоступно — пропускаем запрос, а не валим API
+				log.Printf("Ошибка rate-лимитера токена: %v", err)
+			} else if count > int64(token.RateLimitPerMin) {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(rateLimitWindow.Seconds())))
+				http.Error(w, "too many requests", http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		// Метрики использования: счётчик запросов и last_used_at
+		if err := s.identities.TouchToken(r.Context(), token.ID); err != nil {
+			log.Printf("Ошибка учёта API-токена: %v", err)
+		}
+		next.ServeHTTP(w, withRole(r, roleRegistered))
+	})
+}
+
+// handleTokens — страница управления API-токенами.
+func (s *Server) handleTokens(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	tokens, err := s.identities.ListTokens(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "tokens.html", map[string]interface{}{
+		"Tokens": tokens,
+	})
+}
+
+// handleTokenCreate создаёт токен и показывает его один раз.
+func (s *Server) handleTokenCreate(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		s.badRequest(w, "Укажите имя токена")
+		return
+	}
+	limit, err := tokenLimitValue(r.FormValue("rate_limit"))
+	if err != nil {
+		s.badRequest(w, "Некорректный rate-лимит: ожидается неотрицательное число")
+		return
+	}
+
+	plain, token, err := s.identities.CreateToken(r.Context(), name, limit)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.recordAudit("tokens.create", "token", token.ID, "Создан API-токен "+name)
+
+	tokens, err := s.identities.ListTokens(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "tokens.html", map[string]interface{}{
+		"Tokens":       tokens,
+		"CreatedName":  name,
+		"CreatedToken": plain,
+	})
+}
+
+// handleTokenLimit сохраняет персональный rate-лимит токена.
+func (s *Server) handleTokenLimit(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Некорректный ID токена")
+		return
+	}
+	limit, err := tokenLimitValue(r.FormValue("rate_limit"))
+	if err != nil {
+		s.badRequest(w, "Некорректный rate-лимит: ожидается неотрицательное число")
+		return
+	}
+
+	if err := s.identities.SetTokenLimit(r.Context(), id, limit); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	// Новый лимит действует со следующего окна — сбрасываем счётчик
+	_ = s.kv().Delete(r.Context(), fmt.Sprintf("tokenrate:%d", id))
+	s.recordAudit("tokens.limit", "token", id, fmt.Sprintf("Rate-лимит токена: %d/мин", limit))
+	http.Redirect(w, r, s.opts.BasePath+"/admin/tokens", http.StatusSeeOther)
+}
+
+// handleTokenDelete отзывает токен.
+func (s *Server) handleTokenDelete(w http.ResponseWriter, r *http.Request) {
+	if s.identities == nil {
+		s.serverError(w, errNoAuthRepo)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.badRequest(w, "Некорректная форма")
+		return
+	}
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Некорректный ID токена")
+		return
+	}
+	if err := s.identities.DeleteToken(r.Context(), id); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.recordAudit("tokens.delete", "token", id, fmt.Sprintf("Отозван API-токен #%d", id))
+	http.Redirect(w, r, s.opts.BasePath+"/admin/tokens", http.StatusSeeOther)
+}
+
+// tokenLimitValue разбирает rate-лимит из формы; пустое поле — без лимита.
+func tokenLimitValue(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("bad rate limit %q", value)
+	}
+	return limit, nil
+}
@@ -0,0 +1,20 @@
+package web
+
+import "golearning/internal/selfupdate"
+
+// SetToolchainVersion сообщает серверу версию установленного Go
+// toolchain (например "1.22.0"). Уроки с min_go новее этой версии
+// помечаются: локальное окружение их задания не выполнит. Пустая
+// строка — версия неизвестна (remote/fake runner), пометок нет.
+func (s *Server) SetToolchainVersion(v string) {
+	s.toolchainVersion = v
+}
+
+// goUnsupported сообщает, что урок требует версию Go новее
+// установленного toolchain.
+func (s *Server) goUnsupported(minGo string) bool {
+	if minGo == "" || s.toolchainVersion == "" {
+		return false
+	}
+	return selfupdate.IsNewer(minGo, s.toolchainVersion)
+}
@@ -0,0 +1,125 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"golearning/internal/content"
+	"golearning/internal/translate"
+)
+
+// SetTranslations подключает переводы уроков: параметр ?lang= на
+// странице урока и вычитка в админке (/admin/translations).
+func (s *Server) SetTranslations(svc *translate.Service) {
+	s.translations = svc
+}
+
+// translationState — состояние перевода для шаблона урока.
+type translationState struct {
+	// Active — язык показанного перевода; пусто — оригинал.
+	Active string
+	// Machine — показан машинный перевод без вычитки.
+	Machine bool
+	// ToggleLang — значение ?lang= для переключателя; пусто — оригинал.
+	ToggleLang string
+	// ToggleLabel — подпись переключателя языка.
+	ToggleLabel string
+}
+
+// translationLabels — подписи переключателя по языку перевода.
+var translationLabels = map[string]string{
+	translate.LangRU: "🌐 Русский",
+	translate.LangEN: "🌐 English",
+}
+
+// applyTranslation накладывает перевод на урок по параметру ?lang=
+// и возвращает состояние для шаблона. Без сервиса переводов или без
+// готового перевода урок остаётся на языке оригинала.
+func (s *Server) applyTranslation(r *http.Request, lesson *content.Lesson) translationState {
+	var state translationState
+	if s.translations == nil {
+		return state
+	}
+
+	original := translate.DetectLang(lesson.Title + " " + lesson.BodyMD)
+	target := translate.LangRU
+	if original == translate.LangRU {
+		target = translate.LangEN
+	}
+
+	parts, err := s.translations.ListByLesson(r.Context(), lesson.ID, target)
+	if err != nil || len(parts) == 0 {
+		return state
+	}
+
+	if r.URL.Query().Get("lang") != target {
+		// Показан оригинал, но есть перевод — предлагаем переключиться
+		state.ToggleLang = target
+		state.ToggleLabel = translationLabels[target]
+		return state
+	}
+
+	state.Active = target
+	state.ToggleLabel = "🌐 Оригинал"
+	if t, ok := parts[0]; ok && t.Title != "" {
+		lesson.Title = t.Title
+		state.Machine = state.Machine || !t.Reviewed
+	}
+	for i := range lesson.Sections {
+		t, ok := parts[lesson.Sections[i].ID]
+		if !ok {
+			continue
+		}
+		lesson.Sections[i].Title = t.Title
+		lesson.Sections[i].BodyMD = t.BodyMD
+		// Оглавление пересобирается из переведённого текста
+		lesson.Sections[i].Outline = ""
+		state.Machine = state.Machine || !t.Reviewed
+	}
+	return state
+}
+
+// handleTranslations — GET /admin/translations: сводка переводов
+// с отметками вычитки.
+func (s *Server) handleTranslations(w http.ResponseWriter, r *http.Request) {
+	if s.translations == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	items, err := s.translations.ListForReview(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "translations.html", map[string]interface{}{
+		"Items": items,
+	})
+}
+
+// handleTranslationReview — POST /admin/translations/review: отмечает
+// перевод урока вычитанным или снимает отметку.
+func (s *Server) handleTranslationReview(w http.ResponseWriter, r *http.Request) {
+	if s.translations == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	lessonID, err := strconv.ParseInt(r.FormValue("lesson_id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid lesson ID")
+		return
+	}
+	lang := r.FormValue("lang")
+	if lang != translate.LangRU && lang != translate.LangEN {
+		s.badRequest(w, "Неизвестный язык перевода")
+		return
+	}
+	reviewed := r.FormValue("reviewed") == "1"
+
+	if err := s.translations.SetReviewed(r.Context(), lessonID, lang, reviewed); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	http.Redirect(w, r, s.opts.BasePath+"/admin/translations", http.StatusSeeOther)
+}
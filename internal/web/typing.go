@@ -0,0 +1,151 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+	"golearning/internal/practice"
+	"golearning/internal/progress"
+)
+
+// Границы примеров для тренажёра: однострочники набирать бессмысленно,
+// а простыни утомляют и ничего не закрепляют.
+const (
+	typingMinLines    = 3
+	typingMaxChars    = 1200
+	typingMaxTypedLen = 16 << 10 // защита от мусора в POST
+)
+
+// typingExampleView — пример кода вместе с лучшим результатом набора.
+type typingExampleView struct {
+	BlockID int64
+	Index   int // номер примера внутри урока, с единицы
+	Code    string
+	Lines   int
+	Result  progress.TypingResult
+}
+
+// typingLessonView — группа примеров одного урока.
+type typingLessonView struct {
+	LessonSlug  string
+	LessonTitle string
+	Examples    []typingExampleView
+}
+
+// handleTyping — страница клавиатурного тренажёра: примеры Go-кода из
+// видимых уроков с таблицей лучших результатов.
+func (s *Server) handleTyping(w http.ResponseWriter, r *http.Request) {
+	blocks, err := s.contentRepo.ListExamples(r.Context(), "go", typingMaxChars)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	results, err := s.progressRepo.GetTypingResults(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	role := s.viewer(r)
+	var lessons []typingLessonView
+	typed := 0
+	total := 0
+	for _, b := range blocks {
+		if !typingSuitable(b) {
+			continue
+		}
+		if !canViewModule(role, s.moduleVisibility(r.Context(), b.ModuleID)) {
+			continue
+		}
+
+		view := typingExampleView{
+			BlockID: b.ID,
+			Code:    b.Code,
+			Lines:   strings.Count(b.Code, "\n") + 1,
+			Result:  results[practice.TypingKey(b.Code)],
+		}
+		total++
+		if view.Result.Attempts > 0 {
+			typed++
+		}
+
+		if len(lessons) == 0 || lessons[len(lessons)-1].LessonSlug != b.LessonSlug {
+			lessons = append(lessons, typingLessonView{
+				LessonSlug:  b.LessonSlug,
+				LessonTitle: b.LessonTitle,
+			})
+		}
+		last := &lessons[len(lessons)-1]
+		view.Index = len(last.Examples) + 1
+		last.Examples = append(last.Examples, view)
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Lessons": lessons,
+		"Typed":   typed,
+		"Total":   total,
+		"Stats":   stats,
+	}
+
+	s.render(w, "typing.html", data)
+}
+
+// handleTypingResult принимает набранный текст, считает точность и
+// скорость и записывает результат.
+func (s *Server) handleTypingResult(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Некорректный id примера")
+		return
+	}
+
+	block, err := s.contentRepo.GetExampleByID(r.Context(), id)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if block == nil || !typingSuitable(*block) {
+		http.NotFound(w, r)
+		return
+	}
+	if !canViewModule(s.viewer(r), s.moduleVisibility(r.Context(), block.ModuleID)) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		Typed     string `json:"typed"`
+		ElapsedMS int64  `json:"elapsed_ms"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.badRequest(w, "Invalid JSON")
+		return
+	}
+	if len(req.Typed) > typingMaxTypedLen {
+		s.badRequest(w, "Слишком длинный текст")
+		return
+	}
+
+	score := practice.ScoreTyping(block.Code, req.Typed, req.ElapsedMS)
+	key := practice.TypingKey(block.Code)
+	if err := s.progressRepo.RecordTypingAttempt(r.Context(), key, block.LessonID, score.Accuracy, score.WPM, score.Points); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, score)
+}
+
+// typingSuitable отбирает примеры, которые имеет смысл перепечатывать:
+// Go-код от typingMinLines строк (длину сверху режет запрос в БД).
+func typingSuitable(b content.ExampleBlock) bool {
+	return b.Language == "go" && strings.Count(b.Code, "\n")+1 >= typingMinLines
+}
@@ -0,0 +1,10 @@
+package web
+
+import "golearning/internal/selfupdate"
+
+// SetUpdateChecker подключает проверку новых версий: когда конечная
+// точка релизов сообщает версию новее текущей, страницы админки
+// показывают баннер с заметками релиза.
+func (s *Server) SetUpdateChecker(c *selfupdate.Checker) {
+	s.updateChecker = c
+}
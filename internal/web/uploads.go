@@ -0,0 +1,225 @@
+package web
+
+// Чанковая загрузка больших Markdown-уроков для внешнего тулинга.
+// Тело урока передаётся частями и собирается в хранилище артефактов,
+// минуя обычные лимиты на размер запроса; на коммите разбирается
+// YAML front matter, урок сохраняется и автоматически режется на
+// секции по заголовкам второго уровня. Формат файла описан
+// в internal/ingest (тот же, что у content sync).
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"golearning/internal/content"
+	"golearning/internal/ingest"
+	"golearning/internal/storage"
+)
+
+const (
+	// maxUploadChunk — лимит одной части загрузки.
+	maxUploadChunk = 8 << 20
+	// uploadTTL — срок жизни незакоммиченной сессии загрузки.
+	uploadTTL = 2 * time.Hour
+)
+
+// upload — активная сессия чанковой загрузки.
+type upload struct {
+	parts   int
+	started time.Time
+}
+
+// SetArtifacts подключает хранилище артефактов (чанковая загрузка уроков).
+func (s *Server) SetArtifacts(st storage.Store) {
+	s.artifacts = st
+}
+
+// handleUploadStart создаёт сессию загрузки урока.
+func (s *Server) handleUploadStart(w http.ResponseWriter, r *http.Request) {
+	if s.artifacts == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	id := hex.EncodeToString(buf)
+
+	s.uploadsMu.Lock()
+	if s.uploads == nil {
+		s.uploads = make(map[string]*upload)
+	}
+	s.pruneUploadsLocked(r)
+	s.uploads[id] = &upload{started: time.Now()}
+	s.uploadsMu.Unlock()
+
+	s.jsonResponse(w, map[string]interface{}{"upload_id": id})
+}
+
+// handleUploadChunk добавляет очередную часть тела урока.
+// Части нумеруются по порядку поступления.
+func (s *Server) handleUploadChunk(w http.ResponseWriter, r *http.Request) {
+	if s.artifacts == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	s.uploadsMu.Lock()
+	up := s.uploads[id]
+	s.uploadsMu.Unlock()
+	if up == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	chunk, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadChunk))
+	if err != nil {
+		s.badRequest(w, "Chunk too large")
+		return
+	}
+	if len(chunk) == 0 {
+		s.badRequest(w, "Empty chunk")
+		return
+	}
+
+	s.uploadsMu.Lock()
+	up.parts++
+	part := up.parts
+	s.uploadsMu.Unlock()
+
+	key := uploadChunkKey(id, part)
+	if err := s.artifacts.Put(r.Context(), key, strings.NewReader(string(chunk))); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	s.jsonResponse(w, map[string]interface{}{"parts": part})
+}
+
+// handleUploadCommit собирает части, валидирует front matter и
+// сохраняет урок с разрезанием на секции.
+func (s *Server) handleUploadCommit(w http.ResponseWriter, r *http.Request) {
+	if s.artifacts == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	s.uploadsMu.Lock()
+	up := s.uploads[id]
+	s.uploadsMu.Unlock()
+	if up == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var doc strings.Builder
+	for part := 1; part <= up.parts; part++ {
+		rc, err := s.artifacts.Get(r.Context(), uploadChunkKey(id, part))
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		_, err = io.Copy(&doc, rc)
+		rc.Close()
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+	}
+	if doc.Len() == 0 {
+		s.badRequest(w, "Upload is empty")
+		return
+	}
+
+	fm, body, err := ingest.ParseLessonFile(doc.String())
+	if err != nil {
+		s.badRequest(w, err.Error())
+		return
+	}
+
+	module, err := s.contentRepo.GetModuleBySlug(r.Context(), fm.Module)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if module == nil {
+		s.badRequest(w, fmt.Sprintf("Module not found: %s", fm.Module))
+		return
+	}
+
+	lesson := &content.Lesson{
+		ModuleID:       module.ID,
+		Slug:           fm.Slug,
+		Title:          fm.Title,
+		OrderIndex:     fm.Order,
+		SourceURL:      fm.SourceURL,
+		BodyMD:         body,
+		ReadingTimeMin: fm.ReadingTime,
+		MinGoVersion:   fm.MinGo,
+	}
+	if lesson.ReadingTimeMin <= 0 {
+		lesson.ReadingTimeMin = ingest.EstimateReadingTime(body)
+	}
+
+	if err := s.contentRepo.CreateLesson(r.Context(), lesson); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	// Секции пересоздаются целиком: загрузка — источник истины
+	sections := ingest.SplitLessonSections(lesson.ID, body)
+	if err := s.contentRepo.DeleteSectionsByLessonID(r.Context(), lesson.ID); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if err := s.contentRepo.CreateSections(r.Context(), sections); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	// Части и сессия больше не нужны
+	for part := 1; part <= up.parts; part++ {
+		_ = s.artifacts.Delete(r.Context(), uploadChunkKey(id, part))
+	}
+	s.uploadsMu.Lock()
+	delete(s.uploads, id)
+	s.uploadsMu.Unlock()
+
+	s.recordAudit("lessons.upload", "lesson", lesson.ID, fmt.Sprintf("Загружен урок %s (%d секций)", lesson.Slug, len(sections)))
+
+	s.jsonResponse(w, map[string]interface{}{
+		"lesson_id": lesson.ID,
+		"slug":      lesson.Slug,
+		"sections":  len(sections),
+	})
+}
+
+// pruneUploadsLocked удаляет протухшие сессии и их части.
+// Вызывается под uploadsMu.
+func (s *Server) pruneUploadsLocked(r *http.Request) {
+	for id, up := range s.uploads {
+		if time.Since(up.started) < uploadTTL {
+			continue
+		}
+		for part := 1; part <= up.parts; part++ {
+			_ = s.artifacts.Delete(r.Context(), uploadChunkKey(id, part))
+		}
+		delete(s.uploads, id)
+	}
+}
+
+func uploadChunkKey(id string, part int) string {
+	return fmt.Sprintf("uploads/%s/part-%06d", id, part)
+}
@@ -0,0 +1,131 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// dueVocabularyLimit — сколько карточек показывается за одно повторение.
+const dueVocabularyLimit = 20
+
+// handleVocabulary — личный словарь терминов: карточки к повторению
+// и управление списком.
+func (s *Server) handleVocabulary(w http.ResponseWriter, r *http.Request) {
+	due, err := s.progressRepo.DueVocabulary(r.Context(), dueVocabularyLimit)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	terms, err := s.progressRepo.ListVocabulary(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	stats, _ := s.progressRepo.GetStats(r.Context())
+
+	data := map[string]interface{}{
+		"Due":   due,
+		"Terms": terms,
+		"Added": r.URL.Query().Get("added") == "1",
+		"Stats": stats,
+	}
+
+	s.render(w, "vocabulary.html", data)
+}
+
+// handleVocabularyAdd добавляет термин из формы или выделения в уроке.
+func (s *Server) handleVocabularyAdd(w http.ResponseWriter, r *http.Request) {
+	term := strings.TrimSpace(r.FormValue("term"))
+	if term == "" {
+		s.badRequest(w, "Term is required")
+		return
+	}
+	definition := r.FormValue("definition")
+	lessonID, _ := strconv.ParseInt(r.FormValue("lesson_id"), 10, 64)
+
+	if _, err := s.progressRepo.AddVocabularyTerm(r.Context(), term, definition, lessonID); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/vocabulary?added=1", http.StatusSeeOther)
+}
+
+// handleVocabularyReview фиксирует итог повторения карточки.
+func (s *Server) handleVocabularyReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid term ID")
+		return
+	}
+
+	if err := s.progressRepo.ReviewVocabularyTerm(r.Context(), id, r.FormValue("remembered") == "1"); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/vocabulary", http.StatusSeeOther)
+}
+
+// handleVocabularyDelete удаляет термин из словаря.
+func (s *Server) handleVocabularyDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		s.badRequest(w, "Invalid term ID")
+		return
+	}
+
+	if err := s.progressRepo.DeleteVocabularyTerm(r.Context(), id); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, s.opts.BasePath+"/vocabulary", http.StatusSeeOther)
+}
+
+// apiVocabularyTerm — представление термина словаря в REST API.
+type apiVocabularyTerm struct {
+	ID         int64     `json:"id"`
+	Term       string    `json:"term"`
+	Definition string    `json:"definition"`
+	LessonSlug string    `json:"lesson_slug,omitempty"`
+	DueAt      time.Time `json:"due_at"`
+	Reviews    int       `json:"reviews"`
+}
+
+// handleAPIVocabulary — GET /api/v1/vocabulary: словарь целиком;
+// ?due=1 оставляет только термины, срок повторения которых наступил.
+func (s *Server) handleAPIVocabulary(w http.ResponseWriter, r *http.Request) {
+	terms, err := s.progressRepo.ListVocabulary(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	dueOnly := r.URL.Query().Get("due") == "1"
+	now := time.Now()
+	items := make([]apiVocabularyTerm, 0, len(terms))
+	for _, t := range terms {
+		if dueOnly && t.DueAt.After(now) {
+			continue
+		}
+		items = append(items, apiVocabularyTerm{
+			ID:         t.ID,
+			Term:       t.Term,
+			Definition: t.Definition,
+			LessonSlug: t.LessonSlug,
+			DueAt:      t.DueAt,
+			Reviews:    t.Reviews,
+		})
+	}
+
+	s.jsonResponse(w, map[string]interface{}{
+		"total": len(items),
+		"terms": items,
+	})
+}
@@ -0,0 +1 @@
+-- TODO: таблицы users, products, orders, order_items (см. ТЗ).
@@ -1,6 +1,6 @@
 package golearning
 
-import _ "embed"
+import "embed"
 
 // Project specs live in lessons_mdx so they are easy to edit alongside lessons content.
 
@@ -9,3 +9,11 @@ var CapstoneRESTSpecMD string
 
 //go:embed lessons_mdx/Проекты/capstone-grpc.md
 var CapstoneGRPCSpecMD string
+
+// Starter skeletons live next to the specs; see lessons_mdx/Проекты/starter/<project-id>/.
+// Files may contain the __MODULE_PATH__ placeholder, substituted on download.
+// go.mod and .go files are stored with a .tmpl suffix — otherwise the skeletons
+// would be treated as part of this module by the go tool; the suffix is stripped in the zip.
+
+//go:embed all:lessons_mdx/Проекты/starter
+var StarterTemplatesFS embed.FS